@@ -1,17 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 
 	"github.com/diegojromerolopez/congolway/pkg/animator"
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
 
 func main() {
-	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt), cells (.cells) or life (.life) file")
+	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt/.congol), cells (.cells), life (.life), RLE (.rle) or macrocell (.mc) file, optionally compressed (.gz or .zst suffix)")
 	outputFilePath := flag.String("outputFilePath", "out.apng", "File path where the output apng will be saved")
 	generations := flag.Int("generations", 100, "Number of generations of the cellular automaton")
 	procsHelp := fmt.Sprintf(
@@ -19,6 +23,7 @@ func main() {
 			"enter a positive integer to set a custom number of proceses", gol.CPUS,
 	)
 	procs := flag.Int("procs", gol.CPUS, procsHelp)
+	showProgress := flag.Bool("progress", false, "Show a progress bar on the standard error")
 
 	flag.Parse()
 
@@ -34,15 +39,25 @@ func main() {
 	gr := input.NewGolReader(new(gol.Gol))
 	gi, gError := gr.ReadFile(*inputFilePath, nil)
 	if gError != nil {
-		fmt.Println(gError.Error())
-		return
+		fmt.Fprintf(os.Stderr, "%s\n", gError)
+		os.Exit(1)
 	}
 	g := gi.(*gol.Gol)
 	g.SetProcesses(*procs)
 
-	apngError := animator.MakeApng(g, *outputFilePath, *generations)
+	ctx, stop := utils.InterruptContext()
+	defer stop()
+	var progress base.ProgressFunc
+	if *showProgress {
+		progress = base.ProgressBar(os.Stderr)
+	}
+	apngError := animator.MakeApngContext(ctx, g, *outputFilePath, *generations, progress)
+	if errors.Is(apngError, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "\nInterrupted: the frames made so far were saved\n")
+		os.Exit(1)
+	}
 	if apngError != nil {
-		fmt.Fprintf(os.Stderr, apngError.Error())
+		fmt.Fprintf(os.Stderr, "%s\n", apngError)
 		os.Exit(1)
 	}
 }
@@ -11,8 +11,14 @@ import (
 )
 
 func main() {
-	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt/.congol), cells (.cells) or life (.life) file")
-	outputFilePath := flag.String("outputFilePath", "", "File path of the output Congolway (.txt/.congol), cells (.cells) or life (.life) file")
+	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt/.congol), cells (.cells), life (.life), RLE (.rle) or macrocell (.mc) file, optionally compressed (.gz or .zst suffix)")
+	outputFilePath := flag.String("outputFilePath", "", "File path of the output Congolway (.txt/.congol), cells (.cells), life (.life), RLE (.rle) or macrocell (.mc) file, optionally compressed (.gz or .zst suffix)")
+	imageThreshold := flag.Float64("imageThreshold", 1,
+		"Only used for image files (.png, .gif, .bmp, .jpg). Luminance (from 0 to 1) under which pixels are alive cells")
+	imageInvert := flag.Bool("imageInvert", false,
+		"Only used for image files (.png, .gif, .bmp, .jpg). Invert the luminance, so the light pixels are alive cells")
+	imageCellSize := flag.Int("imageCellSize", 1,
+		"Only used for image files (.png, .gif, .bmp, .jpg). Side in pixels of the squares drawn for each cell")
 
 	flag.Parse()
 
@@ -32,12 +38,16 @@ func main() {
 	}
 
 	gr := input.NewGolReader(new(gol.Gol))
+	gr.SetImageOptions(&input.ImageOptions{Threshold: *imageThreshold, Invert: *imageInvert, CellSize: *imageCellSize})
 	gi, gError := gr.ReadFile(*inputFilePath, nil)
 	if gError != nil {
-		fmt.Println(gError.Error())
-		return
+		fmt.Fprintf(os.Stderr, "%s\n", gError)
+		os.Exit(1)
 	}
 	g := gi.(*gol.Gol)
 	writer := output.NewGolOutputer(g)
-	writer.SaveToFile(*outputFilePath)
+	if saveError := writer.SaveToFile(*outputFilePath); saveError != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", saveError)
+		os.Exit(1)
+	}
 }
@@ -1,17 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 
 	"github.com/diegojromerolopez/congolway/pkg/animator"
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
 
 func main() {
-	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt), cells (.cells) or life (.life) file")
+	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt/.congol), cells (.cells), life (.life), RLE (.rle) or macrocell (.mc) file, optionally compressed (.gz or .zst suffix)")
 	outputFilePath := flag.String("outputFilePath", "out.gif", "File path where the output gif will be saved")
 	outputWidth := flag.Int("outputWitdh", -1, "Width of the output gif image. If -1, this image will not be scaled")
 	outputHeight := flag.Int("outputHeight", -1, "Height of the output gif image. If -1, this image will not be scaled")
@@ -22,6 +26,14 @@ func main() {
 			"enter a positive integer to set a custom number of proceses", gol.CPUS,
 	)
 	procs := flag.Int("procs", gol.CPUS, procsHelp)
+	engineHelp := fmt.Sprintf(
+		"Engine used to compute generations: \"%s\" (visit every cell of the grid) or "+
+			"\"%s\" (jump over generations by using the HashLife algorithm, only in unbounded grids of "+
+			"two-state totalistic rules with the Moore neighborhood, the grid engine is used otherwise)",
+		gol.GridEngine, gol.HashLifeEngine,
+	)
+	engine := flag.String("engine", gol.GridEngine, engineHelp)
+	showProgress := flag.Bool("progress", false, "Show a progress bar on the standard error")
 
 	flag.Parse()
 
@@ -36,7 +48,12 @@ func main() {
 
 	var scaler *animator.ImgScaler
 	if *outputWidth > -1 && *outputHeight > -1 {
-		scaler = animator.NewImgScaler(*outputWidth, *outputHeight, "NearestNeighbor")
+		var scalerError error
+		scaler, scalerError = animator.NewImgScaler(*outputWidth, *outputHeight, "NearestNeighbor")
+		if scalerError != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", scalerError)
+			os.Exit(1)
+		}
 	} else {
 		scaler = nil
 	}
@@ -44,15 +61,33 @@ func main() {
 	gr := input.NewGolReader(new(gol.Gol))
 	gi, gError := gr.ReadFile(*inputFilePath, nil)
 	if gError != nil {
-		fmt.Println(gError.Error())
-		return
+		fmt.Fprintf(os.Stderr, "%s\n", gError)
+		os.Exit(1)
 	}
 	g := gi.(*gol.Gol)
 	g.SetProcesses(*procs)
+	if engineError := g.SetEngine(*engine); engineError != nil {
+		fmt.Fprintf(os.Stderr, "argument invalid: -engine: %s\n", engineError)
+		os.Exit(2)
+	}
+	if *engine == gol.HashLifeEngine && !g.UsesHashLife() {
+		fmt.Fprintf(os.Stderr, "warning: -engine: the HashLife engine cannot be used in this grid, "+
+			"the grid engine is used instead\n")
+	}
 
-	gifError := animator.MakeGif(g, *outputFilePath, *generations, *delay, scaler)
+	ctx, stop := utils.InterruptContext()
+	defer stop()
+	var progress base.ProgressFunc
+	if *showProgress {
+		progress = base.ProgressBar(os.Stderr)
+	}
+	gifError := animator.MakeGifContext(ctx, g, *outputFilePath, *generations, *delay, scaler, progress)
+	if errors.Is(gifError, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "\nInterrupted: the frames made so far were saved\n")
+		os.Exit(1)
+	}
 	if gifError != nil {
-		fmt.Fprintf(os.Stderr, gifError.Error())
+		fmt.Fprintf(os.Stderr, "%s\n", gifError)
 		os.Exit(1)
 	}
 }
@@ -1,17 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
 	"github.com/diegojromerolopez/congolway/pkg/output"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
 
 func main() {
-	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt), cells (.cells) or life (.life) file")
+	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt/.congol), cells (.cells), life (.life), RLE (.rle) or macrocell (.mc) file, optionally compressed (.gz or .zst suffix)")
 	outputFilePath := flag.String("outputFilePath", "out.txt", "File path where the output .txt will be saved")
 	generations := flag.Int("generations", 100, "Number of generations of the cellular automaton")
 	procsHelp := fmt.Sprintf(
@@ -19,6 +23,20 @@ func main() {
 			"enter a positive integer to set a custom number of proceses", gol.CPUS,
 	)
 	procs := flag.Int("procs", gol.CPUS, procsHelp)
+	engineHelp := fmt.Sprintf(
+		"Engine used to compute generations: \"%s\" (visit every cell of the grid) or "+
+			"\"%s\" (jump over generations by using the HashLife algorithm, only in unbounded grids of "+
+			"two-state totalistic rules with the Moore neighborhood, the grid engine is used otherwise)",
+		gol.GridEngine, gol.HashLifeEngine,
+	)
+	engine := flag.String("engine", gol.GridEngine, engineHelp)
+	npyGenerations := flag.Int("npyGenerations", 1,
+		"Only used for NumPy files (.npy). Number of generations, starting with the fast forwarded one, stacked in a 3-D array")
+	showProgress := flag.Bool("progress", false, "Show a progress bar on the standard error")
+	stopWhenStable := flag.Bool("stopWhenStable", false,
+		"Stop before the number of generations if a generation is equal to the previous one")
+	stopWhenExtinct := flag.Bool("stopWhenExtinct", false,
+		"Stop before the number of generations if a generation has no alive cells")
 
 	flag.Parse()
 
@@ -34,12 +52,59 @@ func main() {
 	gr := input.NewGolReader(new(gol.Gol))
 	gi, gError := gr.ReadFile(*inputFilePath, nil)
 	if gError != nil {
-		fmt.Println(gError.Error())
-		return
+		fmt.Fprintf(os.Stderr, "%s\n", gError)
+		os.Exit(1)
 	}
 	g := gi.(*gol.Gol)
 	g.SetProcesses(*procs)
-	ffg := g.FastForward(*generations).(*gol.Gol)
+	if engineError := g.SetEngine(*engine); engineError != nil {
+		fmt.Fprintf(os.Stderr, "argument invalid: -engine: %s\n", engineError)
+		os.Exit(2)
+	}
+	if *engine == gol.HashLifeEngine && !g.UsesHashLife() {
+		fmt.Fprintf(os.Stderr, "warning: -engine: the HashLife engine cannot be used in this grid, "+
+			"the grid engine is used instead\n")
+	}
+	ctx, stop := utils.InterruptContext()
+	defer stop()
+	// The generations are computed in one stride (so the HashLife engine
+	// can jump over them), unless the stop conditions are checked in each one
+	options := gol.RunOptions{
+		Stride:          *generations,
+		MaxGenerations:  utils.MinInt(2, *generations+1),
+		StopWhenStable:  *stopWhenStable,
+		StopWhenExtinct: *stopWhenExtinct,
+	}
+	if *stopWhenStable || *stopWhenExtinct {
+		options.Stride = 1
+		options.MaxGenerations = *generations + 1
+	}
+	if *showProgress {
+		options.Progress = base.ProgressBar(os.Stderr)
+	}
+	// The yielded instance is the last computed generation
+	// when the run stops, even if it is interrupted
+	var ffg *gol.Gol
+	runError := g.Run(ctx, options, func(runG *gol.Gol) bool {
+		ffg = runG
+		return true
+	})
+	if ffg == nil {
+		ffg = g
+	}
 	writer := output.NewGolOutputer(ffg)
-	writer.SaveToFile(*outputFilePath)
+	var saveError error
+	if *npyGenerations > 1 {
+		saveError = writer.SaveGenerationsToNpyFile(*outputFilePath, *npyGenerations)
+	} else {
+		saveError = writer.SaveToFile(*outputFilePath)
+	}
+	if saveError != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", saveError)
+		os.Exit(1)
+	}
+	if errors.Is(runError, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "\nInterrupted: the generation %d was saved\n", ffg.Generation())
+		os.Exit(1)
+	}
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -8,10 +10,11 @@ import (
 	"github.com/diegojromerolopez/congolway/pkg/animator"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
 
 func main() {
-	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt), cells (.cells) or life (.life) file")
+	inputFilePath := flag.String("inputFilePath", "", "File path of the Congolway (.txt/.congol), cells (.cells), life (.life), RLE (.rle) or macrocell (.mc) file, optionally compressed (.gz or .zst suffix)")
 	generations := flag.Int("generations", 100, "Number of generations of the cellular automaton")
 	delay := flag.Int("delay", 500, "Delay between frames, in milliseconds")
 
@@ -35,15 +38,20 @@ func main() {
 	gr := input.NewGolReader(new(gol.Gol))
 	gi, gError := gr.ReadFile(*inputFilePath, nil)
 	if gError != nil {
-		fmt.Println(gError.Error())
-		return
+		fmt.Fprintf(os.Stderr, "%s\n", gError)
+		os.Exit(1)
 	}
 	g := gi.(*gol.Gol)
 	g.SetProcesses(*procs)
 
-	stdoutError := animator.MakeStdout(g, *generations, *delay)
+	ctx, stop := utils.InterruptContext()
+	defer stop()
+	stdoutError := animator.MakeStdoutContext(ctx, g, *generations, *delay, nil)
+	if errors.Is(stdoutError, context.Canceled) {
+		return
+	}
 	if stdoutError != nil {
-		fmt.Fprintf(os.Stderr, stdoutError.Error())
+		fmt.Fprintf(os.Stderr, "%s\n", stdoutError)
 		os.Exit(1)
 	}
 }
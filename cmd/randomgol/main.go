@@ -4,30 +4,31 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"regexp"
 
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/output"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
 )
 
 func main() {
 	name := flag.String("name", "Random Gol", "Name of the game of life instance that will be created")
 	description := flag.String("description", "", "Description of the game of life instance that will be created")
 	outputFilePath := flag.String("outputFilePath", "out.txt",
-		"File path where the random grid will be saved (.txt, .cells and life extensions are allowed)")
+		"File path where the random grid will be saved (.txt, .congol, .cells, .life, .rle and .mc extensions are allowed, optionally followed by .gz or .zst)")
 	rows := flag.Int("rows", 100, "Number of rows of the grid")
 	cols := flag.Int("columns", 100, "Number of columns of the grid")
 	circularRows := flag.String("circularRows", "yes", "Should the rows be circular (yes) or be limited (no)")
 	circularCols := flag.String("circularCols", "yes", "Should the columns be circular (yes) or be limited (no)")
-	rules := flag.String("rules", "23/3", "Survival and birth rules")
+	rulestring := flag.String("rules", "B3/S23",
+		"Birth and survival rules in B/S (e.g. B3/S23) or S/B (e.g. 23/3) notation")
 	randomSeed := flag.Int64("randomSeed", 0, "Random seed")
 	outputFormat := flag.String("outputFormat", "",
-		"Only used for congolway files (.txt files). File format \"dense\" or \"sparse\"")
+		"Only used for congolway files (.txt files). File format \"dense\", \"sparse\" or \"rle\"")
 
 	flag.Parse()
 
-	rulesMatch, rulerMatchError := regexp.MatchString(`\d+/\d+`, *rules)
-	if rulerMatchError != nil || !rulesMatch {
+	rule, ruleError := rules.Parse(*rulestring)
+	if ruleError != nil {
 		fmt.Fprintf(os.Stderr, "argument invalid: -rules\n")
 		os.Exit(2)
 	}
@@ -44,11 +45,20 @@ func main() {
 		colLimitation = "unlimited"
 	}
 
-	g := gol.NewRandomGol(*name, *description, *rules, gridType, rowLimitation, colLimitation, *rows, *cols, *randomSeed)
+	g, gError := gol.NewRandomGol(*name, *description, rule.String(), gridType, rowLimitation, colLimitation, *rows, *cols, *randomSeed)
+	if gError != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", gError)
+		os.Exit(1)
+	}
 	writer := output.NewGolOutputer(g)
+	var saveError error
 	if *outputFormat != "" {
-		writer.SaveToCongolwayFile(*outputFilePath, *outputFormat)
+		saveError = writer.SaveToCongolwayFile(*outputFilePath, *outputFormat)
 	} else {
-		writer.SaveToFile(*outputFilePath)
+		saveError = writer.SaveToFile(*outputFilePath)
+	}
+	if saveError != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", saveError)
+		os.Exit(1)
 	}
 }
@@ -7,6 +7,7 @@ require (
 	github.com/ajstarks/deck/generate v0.0.0-20200503150220-33b0966b380f // indirect
 	github.com/ajstarks/svgo v0.0.0-20200320125537-f189e35d30ca
 	github.com/kettek/apng v0.0.0-20191108220231-414630eed80f
+	github.com/klauspost/compress v1.10.10
 	golang.org/x/image v0.0.0-20200430140353-33d19683fad8
 	honnef.co/go/tools v0.0.1-2020.1.3 // indirect
 )
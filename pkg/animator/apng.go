@@ -1,14 +1,14 @@
 package animator
 
 import (
+	"context"
 	"fmt"
-	"image"
-	"image/color"
 	"image/png"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/kettek/apng"
 )
@@ -16,34 +16,44 @@ import (
 // MakeApng : make an animated-png (apng) for a number of generations
 // for a game-of-life instance.
 func MakeApng(g *gol.Gol, outputFilepath string, generations int) error {
+	return MakeApngContext(context.Background(), g, outputFilepath, generations, nil)
+}
+
+// MakeApngContext : make an animated-png (apng) for a number of generations
+// (see MakeApng), calling the progress function (if not nil) after each
+// frame. If the context is done, the frames made so far are saved and
+// the error of the context is returned.
+func MakeApngContext(ctx context.Context, g *gol.Gol, outputFilepath string, generations int,
+	progress base.ProgressFunc) error {
+	// Every frame must have the same size
+	window, windowError := animationWindow(ctx, g, generations)
+	if windowError != nil {
+		return windowError
+	}
 	tempDir, tempDirError := ioutil.TempDir("", "")
 	if tempDirError != nil {
 		return tempDirError
 	}
 	defer os.RemoveAll(tempDir)
 
-	numberOfFrames := generations
-	imagePaths := make([]string, 0, numberOfFrames)
-	for frameIndex := 0; frameIndex < numberOfFrames; frameIndex++ {
+	imagePaths := make([]string, 0, generations)
+	animationError := animateFrames(ctx, g, generations, progress, func(frameG *gol.Gol, frameIndex int) error {
 		frameOutputFilepath := filepath.Join(tempDir, fmt.Sprintf("png_%d.png", frameIndex))
-		pngError := makePng(g, frameOutputFilepath)
+		pngError := makePng(frameG, window, frameOutputFilepath)
 		if pngError != nil {
 			return pngError
 		}
 		imagePaths = append(imagePaths, frameOutputFilepath)
-		g = g.NextGeneration().(*gol.Gol)
+		return nil
+	})
+	// The frames made so far are saved if the context is done
+	if animationError != nil && ctx.Err() == nil {
+		return animationError
 	}
 
 	animation := apng.APNG{
 		Frames: make([]apng.Frame, len(imagePaths)),
 	}
-
-	outputFile, outputFileError := os.Create(outputFilepath)
-	if outputFileError != nil {
-		return outputFileError
-	}
-	defer outputFile.Close()
-
 	for i, imagePath := range imagePaths {
 		apngImage, imageError := os.Open(imagePath)
 		if imageError != nil {
@@ -56,30 +66,35 @@ func MakeApng(g *gol.Gol, outputFilepath string, generations int) error {
 		}
 		animation.Frames[i].Image = pngImage
 	}
-	// Write APNG to our output file
-	apng.Encode(outputFile, animation)
 
-	return nil
+	// Write APNG to our output file
+	outputFile, outputFileError := os.Create(outputFilepath)
+	if outputFileError != nil {
+		return outputFileError
+	}
+	encodeError := apng.Encode(outputFile, animation)
+	closeError := outputFile.Close()
+	if animationError != nil {
+		return animationError
+	}
+	if encodeError != nil {
+		return encodeError
+	}
+	return closeError
 }
 
-// makePng : make a png for a generation of the game of life
-func makePng(g *gol.Gol, outputFilepath string) error {
+// makePng : make a png for the window of a generation of the game of life
+func makePng(g *gol.Gol, window frameWindow, outputFilepath string) error {
 	outputFile, outputFileError := os.Create(outputFilepath)
 	if outputFileError != nil {
 		return outputFileError
 	}
-	// TODO: fix palette to be a map and don't depend on ALIVE = 1, DEAD = 0
-	palette := []color.Color{color.White, color.Black}
-	rows := g.Rows()
-	cols := g.Cols()
-	rect := image.Rect(0, 0, cols, rows)
-	pngImage := image.NewPaletted(rect, palette)
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			cell := g.Get(i, j)
-			pngImage.SetColorIndex(j, i, uint8(cell))
-		}
+	palette := StatesPalette(g.States())
+	pngImage := frameImage(g, window, palette)
+	encodeError := png.Encode(outputFile, pngImage)
+	closeError := outputFile.Close()
+	if encodeError != nil {
+		return encodeError
 	}
-	png.Encode(outputFile, pngImage)
-	return nil
+	return closeError
 }
@@ -0,0 +1,73 @@
+package animator
+
+import (
+	"context"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// animateFrames : call the frame function with the first generations of
+// the game of life instance (see gol.Run), reporting the progress after each
+// frame, until all of them are animated, the frame function returns an error
+// or the context is done (and then the error of the context is returned)
+func animateFrames(ctx context.Context, g *gol.Gol, generations int, progress base.ProgressFunc,
+	frame func(frameG *gol.Gol, frameIndex int) error) error {
+	if generations <= 0 {
+		return nil
+	}
+	tracker := base.NewProgressTracker(generations, progress)
+	frameIndex := 0
+	var frameError error
+	runError := g.Run(ctx, gol.RunOptions{MaxGenerations: generations}, func(frameG *gol.Gol) bool {
+		if frameError = frame(frameG, frameIndex); frameError != nil {
+			return false
+		}
+		frameIndex++
+		tracker.Report(frameG, frameIndex)
+		return true
+	})
+	if frameError != nil {
+		return frameError
+	}
+	return runError
+}
+
+// frameWindow : area of the grid that is drawn in every frame of an
+// animation, in absolute coordinates (see gol.Origin)
+type frameWindow struct {
+	originI int
+	originJ int
+	rows    int
+	cols    int
+}
+
+// animationWindow : return the area that contains every frame of an
+// animation of the first generations of the game of life instance: its
+// grid, or in unbounded grids (that grow and move) the union of the grids
+// of all the frames, that are computed for that
+func animationWindow(ctx context.Context, g *gol.Gol, generations int) (frameWindow, error) {
+	originI, originJ := g.Origin()
+	window := frameWindow{originI: originI, originJ: originJ, rows: g.Rows(), cols: g.Cols()}
+	if !g.Unbounded() || generations <= 0 {
+		return window, nil
+	}
+	minI, minJ := originI, originJ
+	maxI, maxJ := originI+g.Rows(), originJ+g.Cols()
+	runError := g.Run(ctx, gol.RunOptions{MaxGenerations: generations}, func(frameG *gol.Gol) bool {
+		frameOriginI, frameOriginJ := frameG.Origin()
+		minI = utils.MinInt(minI, frameOriginI)
+		minJ = utils.MinInt(minJ, frameOriginJ)
+		maxI = utils.MaxInt(maxI, frameOriginI+frameG.Rows())
+		maxJ = utils.MaxInt(maxJ, frameOriginJ+frameG.Cols())
+		return true
+	})
+	return frameWindow{originI: minI, originJ: minJ, rows: maxI - minI, cols: maxJ - minJ}, runError
+}
+
+// get : return the state of the cell i, j of the window in a frame
+func (w frameWindow) get(frameG *gol.Gol, i, j int) int {
+	originI, originJ := frameG.Origin()
+	return frameG.Get(w.originI+i-originI, w.originJ+j-originJ)
+}
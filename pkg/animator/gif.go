@@ -1,45 +1,54 @@
 package animator
 
 import (
-	"image"
-	"image/color"
+	"context"
 	"image/gif"
 	"os"
 
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 )
 
 // MakeGif : make a gif animation for some generations
 func MakeGif(g *gol.Gol, outputFilepath string, generations int, delay int, scaler *ImgScaler) error {
+	return MakeGifContext(context.Background(), g, outputFilepath, generations, delay, scaler, nil)
+}
+
+// MakeGifContext : make a gif animation for some generations (see MakeGif),
+// calling the progress function (if not nil) after each frame. If the
+// context is done, the frames made so far are saved and the error of the
+// context is returned.
+func MakeGifContext(ctx context.Context, g *gol.Gol, outputFilepath string, generations int, delay int,
+	scaler *ImgScaler, progress base.ProgressFunc) error {
+	// Every frame must have the same size
+	window, windowError := animationWindow(ctx, g, generations)
+	if windowError != nil {
+		return windowError
+	}
 	outputFile, outputFileError := os.Create(outputFilepath)
 	if outputFileError != nil {
 		return outputFileError
 	}
-	// TODO: fix palette to be a map and don't depend on ALIVE = 1, DEAD = 0
-	palette := []color.Color{color.White, color.Black}
-	rows := g.Rows()
-	cols := g.Cols()
-	numberOfFrames := generations
+	palette := StatesPalette(g.States())
 	gifAnimation := gif.GIF{LoopCount: 0}
-	for frameIndex := 0; frameIndex < numberOfFrames; frameIndex++ {
-		rect := image.Rect(0, 0, cols, rows)
-		frameImage := image.NewPaletted(rect, palette)
-		for i := 0; i < rows; i++ {
-			for j := 0; j < cols; j++ {
-				cell := g.Get(i, j)
-				frameImage.SetColorIndex(j, i, uint8(cell))
-			}
-		}
+	animationError := animateFrames(ctx, g, generations, progress, func(frameG *gol.Gol, frameIndex int) error {
+		frame := frameImage(frameG, window, palette)
 
 		gifAnimation.Delay = append(gifAnimation.Delay, delay)
 		if scaler != nil {
-			gifAnimation.Image = append(gifAnimation.Image, scaler.ScalePaletted(frameImage))
+			gifAnimation.Image = append(gifAnimation.Image, scaler.ScalePaletted(frame))
 		} else {
-			gifAnimation.Image = append(gifAnimation.Image, frameImage)
+			gifAnimation.Image = append(gifAnimation.Image, frame)
 		}
-
-		g = g.NextGeneration().(*gol.Gol)
+		return nil
+	})
+	encodeError := gif.EncodeAll(outputFile, &gifAnimation)
+	closeError := outputFile.Close()
+	if animationError != nil {
+		return animationError
+	}
+	if encodeError != nil {
+		return encodeError
 	}
-	gif.EncodeAll(outputFile, &gifAnimation)
-	return nil
+	return closeError
 }
@@ -2,13 +2,20 @@ package animator
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"image/gif"
 	"io/ioutil"
+	"math"
+	"os"
 	"testing"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 func TestMakeGif(t *testing.T) {
@@ -48,6 +55,151 @@ func testMakeGif(t *testing.T, filename string, expectedGifFilename string) {
 	}
 }
 
+func TestMakeGifContext(t *testing.T) {
+	g, readError := readCongolwayFile("10x10.txt")
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	gifOutputFile, err := ioutil.TempFile("", "temp_gol*.gif")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	gifOutputFile.Close()
+	defer os.Remove(gifOutputFile.Name())
+
+	// The run is cancelled after the third frame
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var progresses []base.Progress
+	progress := func(p base.Progress) {
+		progresses = append(progresses, p)
+		if p.Computed == 3 {
+			cancel()
+		}
+	}
+	gifError := MakeGifContext(ctx, g.(*gol.Gol), gifOutputFile.Name(), 10, 5, nil, progress)
+	if !errors.Is(gifError, context.Canceled) {
+		t.Errorf("The making of the gif should have been cancelled, found %v", gifError)
+	}
+	if len(progresses) != 3 || progresses[2].Total != 10 || progresses[2].Generation != g.Generation()+2 {
+		t.Errorf("The progress should have been reported for 3 frames, found %v", progresses)
+	}
+
+	// The frames made before the cancellation are saved
+	gifFile, openError := os.Open(gifOutputFile.Name())
+	if openError != nil {
+		t.Error(openError)
+		return
+	}
+	defer gifFile.Close()
+	gifAnimation, decodeError := gif.DecodeAll(gifFile)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	if len(gifAnimation.Image) != 3 {
+		t.Errorf("The gif should have 3 frames, found %d", len(gifAnimation.Image))
+	}
+}
+
+func TestMakeGifWithDyingStates(t *testing.T) {
+	g, readError := readCongolwayFile("brians_brain.txt")
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+
+	gifOutputFile, err := ioutil.TempFile("", "temp_gol.gif")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer os.Remove(gifOutputFile.Name())
+	defer gifOutputFile.Close()
+
+	generations := 4
+	gifError := MakeGif(g.(*gol.Gol), gifOutputFile.Name(), generations, 5, nil)
+	if gifError != nil {
+		t.Error(gifError)
+		return
+	}
+
+	gifAnimation, decodeError := gif.DecodeAll(gifOutputFile)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	if len(gifAnimation.Image) != generations {
+		t.Errorf("The gif should have %d frames, found %d", generations, len(gifAnimation.Image))
+		return
+	}
+	for _, frame := range gifAnimation.Image {
+		// Gif palettes are padded to a power of two
+		if len(frame.Palette) < 3 || frame.Palette[2] == frame.Palette[0] || frame.Palette[2] == frame.Palette[1] {
+			t.Errorf("The palette should have a different color for each state, found %v", frame.Palette)
+			return
+		}
+		for i := 0; i < g.Rows(); i++ {
+			for j := 0; j < g.Cols(); j++ {
+				if int(frame.ColorIndexAt(j, i)) != g.Get(i, j) {
+					t.Errorf("Pixel %d,%d should have the color of state %d, found %d", j, i, g.Get(i, j), frame.ColorIndexAt(j, i))
+				}
+			}
+		}
+		g = g.NextGeneration()
+	}
+}
+
+func TestMakeGifWithPolygonalCells(t *testing.T) {
+	for _, neighborhoodType := range []int{neighborhood.HEXAGONAL, neighborhood.TRIANGULAR} {
+		g, _ := gol.NewGol("Tiling", "", "B2/S34", "dense", "limited", "limited", 4, 6, 0)
+		g.SetNeighborhoodType(neighborhoodType)
+		g.Set(1, 1, statuses.ALIVE)
+		g.Set(2, 4, statuses.ALIVE)
+
+		gifOutputFile, err := ioutil.TempFile("", "temp_gol.gif")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer os.Remove(gifOutputFile.Name())
+		defer gifOutputFile.Close()
+
+		if gifError := MakeGif(g, gifOutputFile.Name(), 1, 5, nil); gifError != nil {
+			t.Error(gifError)
+			return
+		}
+		gifAnimation, decodeError := gif.DecodeAll(gifOutputFile)
+		if decodeError != nil {
+			t.Error(decodeError)
+			return
+		}
+		frame := gifAnimation.Image[0]
+		width, height := tilingSize(neighborhoodType, g.Rows(), g.Cols(), tilingCellSize)
+		if frame.Bounds().Dx() != int(math.Ceil(width)) || frame.Bounds().Dy() != int(math.Ceil(height)) {
+			t.Errorf("The %s frame should be %fx%f, found %v",
+				g.NeighborhoodTypeString(), width, height, frame.Bounds())
+		}
+		// The centroid of each cell has the color of its state
+		for i := 0; i < g.Rows(); i++ {
+			for j := 0; j < g.Cols(); j++ {
+				xs, ys := cellPolygon(neighborhoodType, i, j, i, j, tilingCellSize)
+				x, y := 0.0, 0.0
+				for vertex := range xs {
+					x += xs[vertex] / float64(len(xs))
+					y += ys[vertex] / float64(len(ys))
+				}
+				if int(frame.ColorIndexAt(int(x), int(y))) != g.Get(i, j) {
+					t.Errorf("The %s cell %d,%d should have the color of state %d, found %d",
+						g.NeighborhoodTypeString(), i, j, g.Get(i, j), frame.ColorIndexAt(int(x), int(y)))
+				}
+			}
+		}
+	}
+}
+
 func readCongolwayFile(filename string) (base.GolInterface, error) {
 	dataFilePath, dataFilePathError := base.GetTestdataFilePath(filename)
 	if dataFilePathError != nil {
@@ -61,3 +213,55 @@ func readCongolwayFile(filename string) (base.GolInterface, error) {
 	}
 	return gol, nil
 }
+
+func TestMakeGifWithUnboundedGrid(t *testing.T) {
+	// A glider that moves out of its initial grid
+	g, _ := gol.NewGol("Glider", "", "B3/S23", "dok", "unbounded", "unbounded", 3, 3, 0)
+	for _, cell := range [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}} {
+		g.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+
+	gifOutputFile, err := ioutil.TempFile("", "temp_gol.gif")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer os.Remove(gifOutputFile.Name())
+	defer gifOutputFile.Close()
+
+	generations := 8
+	if gifError := MakeGif(g, gifOutputFile.Name(), generations, 5, nil); gifError != nil {
+		t.Error(gifError)
+		return
+	}
+	gifAnimation, decodeError := gif.DecodeAll(gifOutputFile)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	if len(gifAnimation.Image) != generations {
+		t.Errorf("The gif should have %d frames, found %d", generations, len(gifAnimation.Image))
+		return
+	}
+	bounds := gifAnimation.Image[0].Bounds()
+	if bounds.Dx() <= g.Cols() || bounds.Dy() <= g.Rows() {
+		t.Errorf("The frames should contain every generation of the glider, found %v", bounds)
+	}
+	for frameIndex, frame := range gifAnimation.Image {
+		if frame.Bounds() != bounds {
+			t.Errorf("The frame %d should be %v, found %v", frameIndex, bounds, frame.Bounds())
+		}
+		// No cell of the glider is left out of the frame
+		alive := 0
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				if int(frame.ColorIndexAt(x, y)) == statuses.ALIVE {
+					alive++
+				}
+			}
+		}
+		if alive != 5 {
+			t.Errorf("The frame %d should have the 5 alive cells of the glider, found %d", frameIndex, alive)
+		}
+	}
+}
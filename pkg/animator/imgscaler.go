@@ -1,6 +1,7 @@
 package animator
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color/palette"
@@ -8,6 +9,9 @@ import (
 	"golang.org/x/image/draw"
 )
 
+// ErrInvalidInterpolator : the interpolator is not one of the known ones
+var ErrInvalidInterpolator = errors.New("Invalid interpolator")
+
 // ImgScaler : scales images to a width and height
 // following an interpolation algorithm
 type ImgScaler struct {
@@ -17,8 +21,13 @@ type ImgScaler struct {
 }
 
 // NewImgScaler : creates a new ImgScaler from a with, height and interpolator string
-func NewImgScaler(width, height int, interpolatorStr string) *ImgScaler {
-	return &ImgScaler{width, height, interpolatorFromString(interpolatorStr)}
+// or returns an error if the interpolator is not known
+func NewImgScaler(width, height int, interpolatorStr string) (*ImgScaler, error) {
+	interpolator, interpolatorError := interpolatorFromString(interpolatorStr)
+	if interpolatorError != nil {
+		return nil, interpolatorError
+	}
+	return &ImgScaler{width, height, interpolator}, nil
 }
 
 // ScaleRGBA : scale a RGB image
@@ -37,22 +46,22 @@ func (is *ImgScaler) ScalePaletted(src image.Image) *image.Paletted {
 	return dst
 }
 
-func interpolatorFromString(interpolatorStr string) draw.Interpolator {
+func interpolatorFromString(interpolatorStr string) (draw.Interpolator, error) {
 	if interpolatorStr == "NearestNeighbor" {
-		return draw.NearestNeighbor
+		return draw.NearestNeighbor, nil
 	}
 	if interpolatorStr == "ApproxBiLinear" {
-		return draw.ApproxBiLinear
+		return draw.ApproxBiLinear, nil
 	}
 	if interpolatorStr == "BiLinear" {
-		return draw.BiLinear
+		return draw.BiLinear, nil
 	}
 	if interpolatorStr == "CatmullRom" {
-		return draw.CatmullRom
+		return draw.CatmullRom, nil
 	}
-	panic(fmt.Sprintf(
-		"%s is not a recognized interpolator. "+
+	return nil, fmt.Errorf(
+		"%w: %s is not a recognized interpolator. "+
 			"Available options are "+
 			"\"NearestNeighbor\", \"ApproxBiLinear\", \"BiLinear\" or \"CatmullRom\"",
-		interpolatorStr))
+		ErrInvalidInterpolator, interpolatorStr)
 }
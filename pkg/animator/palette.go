@@ -0,0 +1,27 @@
+package animator
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// StatesPalette : palette with a color for each state of the cells, so
+// the state of a cell is its color index: white for dead cells, black
+// for alive cells and shades of gray, from darker to lighter, for the
+// dying cells of Generations rules.
+func StatesPalette(states int) color.Palette {
+	palette := color.Palette{color.White, color.Black}
+	for state := statuses.DYING; state < states; state++ {
+		level := uint8(255 * (state - 1) / states)
+		palette = append(palette, color.Gray{Y: level})
+	}
+	return palette
+}
+
+// svgColor : return the color in the #rrggbb format
+func svgColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
@@ -1,9 +1,11 @@
 package animator
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/output"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
@@ -11,6 +13,15 @@ import (
 
 // MakeStdout : make a terminal animation for some generations
 func MakeStdout(g *gol.Gol, generations int, delay int) error {
+	return MakeStdoutContext(context.Background(), g, generations, delay, nil)
+}
+
+// MakeStdoutContext : make a terminal animation for some generations (see
+// MakeStdout), calling the progress function (if not nil) after each frame.
+// If the context is done, the animation stops (leaving the cursor after the
+// last frame) and the error of the context is returned.
+func MakeStdoutContext(ctx context.Context, g *gol.Gol, generations int, delay int,
+	progress base.ProgressFunc) error {
 	delayInDuration, delayInDurationError := time.ParseDuration(fmt.Sprintf("%dms", delay))
 	if delayInDurationError != nil {
 		return delayInDurationError
@@ -19,12 +30,26 @@ func MakeStdout(g *gol.Gol, generations int, delay int) error {
 		statuses.DEAD:  "░",
 		statuses.ALIVE: "█",
 	}
-	for generationI := 0; generationI < generations; generationI++ {
-		gout := output.NewGolOutputer(g)
-		terminalRowsUsed := gout.Stdout(cellStringCorrespondence)
-		time.Sleep(delayInDuration)
-		fmt.Printf("\033[%dA", terminalRowsUsed)
-		g = g.NextGeneration().(*gol.Gol)
+	for state := statuses.DYING; state < g.States(); state++ {
+		cellStringCorrespondence[state] = "▒"
 	}
-	return nil
+	rows, cols := g.Rows(), g.Cols()
+	return animateFrames(ctx, g, generations, progress, func(frameG *gol.Gol, frameIndex int) error {
+		gout := output.NewGolOutputer(frameG)
+		var terminalRowsUsed int
+		// Only the changed cells are printed over the previous generation
+		if cells, cellsKnown := frameG.ChangedCells(); frameIndex > 0 && cellsKnown && frameG.Rows() == rows && frameG.Cols() == cols {
+			terminalRowsUsed = gout.StdoutChanges(cellStringCorrespondence, cells)
+		} else {
+			terminalRowsUsed = gout.Stdout(cellStringCorrespondence)
+		}
+		rows, cols = frameG.Rows(), frameG.Cols()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delayInDuration):
+		}
+		fmt.Printf("\033[%dA", terminalRowsUsed)
+		return nil
+	})
 }
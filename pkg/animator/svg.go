@@ -1,57 +1,134 @@
 package animator
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"os"
 
 	svg "github.com/ajstarks/svgo"
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
+// svgTilingCellSize : side of the hexagons and triangles of the svg animations
+const svgTilingCellSize = 10
+
 // MakeSvg : make a svg animation for some generations
 func MakeSvg(g *gol.Gol, outputFilepath string, generations int, delay int) error {
-	rows := g.Rows()
-	cols := g.Cols()
+	return MakeSvgContext(context.Background(), g, outputFilepath, generations, delay, nil)
+}
+
+// MakeSvgContext : make a svg animation for some generations (see MakeSvg),
+// calling the progress function (if not nil) after each frame. If the
+// context is done, the frames made so far are saved and the error of the
+// context is returned.
+func MakeSvgContext(ctx context.Context, g *gol.Gol, outputFilepath string, generations int, delay int,
+	progress base.ProgressFunc) error {
+	// The cells of the svg are the ones of the window of every frame
+	window, windowError := animationWindow(ctx, g, generations)
+	if windowError != nil {
+		return windowError
+	}
+	rows := window.rows
+	cols := window.cols
 
 	outputFile, outputFileError := os.Create(outputFilepath)
 	if outputFileError != nil {
 		return outputFileError
 	}
 	canvas := svg.New(outputFile)
-	canvas.Start(cols, rows)
+	neighborhoodType := g.NeighborhoodType()
+	if isPolygonalTiling(neighborhoodType) {
+		width, height := tilingSize(neighborhoodType, rows, cols, svgTilingCellSize)
+		canvas.Start(int(math.Ceil(width)), int(math.Ceil(height)))
+	} else {
+		canvas.Start(cols, rows)
+	}
 
+	palette := StatesPalette(g.States())
+	// States of the cells in the previous frame
+	earlierStates := make([]int, rows*cols)
 	for i := 0; i < rows; i++ {
 		for j := 0; j < cols; j++ {
 			cellID := fmt.Sprintf("c_%d_%d", i, j)
-			if g.Get(i, j) == statuses.ALIVE {
-				canvas.Square(j, i, 1, `fill="black"`, fmt.Sprintf(`id="%s"`, cellID))
+			cellValue := window.get(g, i, j)
+			earlierStates[i*cols+j] = cellValue
+			var attributes []string
+			if cellValue == statuses.ALIVE {
+				attributes = []string{`fill="black"`, fmt.Sprintf(`id="%s"`, cellID)}
+			} else if cellValue == statuses.DEAD {
+				attributes = []string{`fill="black"`, `opacity="0"`, fmt.Sprintf(`id="%s"`, cellID)}
 			} else {
-				canvas.Square(j, i, 1, `fill="black"`, `opacity="0"`, fmt.Sprintf(`id="%s"`, cellID))
+				attributes = []string{fmt.Sprintf(`fill="%s"`, svgColor(palette[cellValue])), fmt.Sprintf(`id="%s"`, cellID)}
+			}
+			if isPolygonalTiling(neighborhoodType) {
+				xs, ys := cellPolygon(neighborhoodType, i, j, window.originI+i, window.originJ+j, svgTilingCellSize)
+				canvas.Polygon(roundCoordinates(xs), roundCoordinates(ys), attributes...)
+			} else {
+				canvas.Square(j, i, 1, attributes...)
 			}
 		}
 	}
-	earlierG := g
-	numberOfFrames := generations
-	for frameIndex := 0; frameIndex < numberOfFrames; frameIndex++ {
+	animationError := animateFrames(ctx, g, generations, progress, func(frameG *gol.Gol, frameIndex int) error {
 		animationDelay := delay * frameIndex
-		for i := 0; i < rows; i++ {
-			for j := 0; j < cols; j++ {
-				cellID := fmt.Sprintf("c_%d_%d", i, j)
-				cellSelector := fmt.Sprintf("#%s", cellID)
-				cellValue := g.Get(i, j)
-				if earlierG.Get(i, j) != cellValue {
-					if cellValue == statuses.ALIVE {
-						canvas.Animate(cellSelector, "opacity", 0, 1, float64(delay), 0, fmt.Sprintf(`begin="%ds"`, animationDelay))
-					} else if cellValue == statuses.DEAD {
-						canvas.Animate(cellSelector, "opacity", 1, 0, float64(delay), 0, fmt.Sprintf(`begin="%ds"`, animationDelay))
-					}
+		for _, cell := range changedCells(frameG, frameIndex, rows, cols) {
+			i, j := cell[0], cell[1]
+			cellID := fmt.Sprintf("c_%d_%d", i, j)
+			cellSelector := fmt.Sprintf("#%s", cellID)
+			cellValue := window.get(frameG, i, j)
+			earlierCellValue := earlierStates[i*cols+j]
+			if earlierCellValue != cellValue {
+				earlierStates[i*cols+j] = cellValue
+				if cellValue == statuses.DEAD {
+					canvas.Animate(cellSelector, "opacity", 1, 0, float64(delay), 0, fmt.Sprintf(`begin="%ds"`, animationDelay))
+					continue
+				}
+				if earlierCellValue == statuses.DEAD {
+					canvas.Animate(cellSelector, "opacity", 0, 1, float64(delay), 0, fmt.Sprintf(`begin="%ds"`, animationDelay))
+				}
+				if frameG.States() > 2 {
+					// The color of the cell depends on its state
+					canvas.Writer.Write([]byte(fmt.Sprintf(
+						"<set xlink:href=\"%s\" attributeName=\"fill\" to=\"%s\" begin=\"%ds\" />\n",
+						cellSelector, svgColor(palette[cellValue]), animationDelay,
+					)))
 				}
 			}
 		}
-		earlierG = g
-		g = g.NextGeneration().(*gol.Gol)
-	}
+		return nil
+	})
 	canvas.End()
-	return nil
+	return animationError
+}
+
+// changedCells : return the cells of the rows x cols window that can be
+// different in a frame and in the previous one: none in the first frame,
+// and then the changed cells of its generation if they are known (they
+// are not in unbounded grids, whose window is not their grid), or else
+// every cell of the window
+func changedCells(frameG *gol.Gol, frameIndex int, rows, cols int) [][2]int {
+	if frameIndex == 0 {
+		return nil
+	}
+	if cells, cellsKnown := frameG.ChangedCells(); cellsKnown && frameG.Rows() == rows && frameG.Cols() == cols {
+		return cells
+	}
+	cells := make([][2]int, 0, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			cells = append(cells, [2]int{i, j})
+		}
+	}
+	return cells
+}
+
+// roundCoordinates : round the coordinates of the vertices of a polygon
+func roundCoordinates(coordinates []float64) []int {
+	rounded := make([]int, len(coordinates))
+	for i, coordinate := range coordinates {
+		rounded[i] = int(math.Round(coordinate))
+	}
+	return rounded
 }
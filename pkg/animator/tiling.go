@@ -0,0 +1,134 @@
+package animator
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+)
+
+// tilingCellSize : side (in pixels) of the hexagons and triangles
+// drawn for the cells of hexagonal and triangular tilings
+const tilingCellSize = 6
+
+// sqrt3 : square root of 3, used in the geometry of hexagons and triangles
+var sqrt3 = math.Sqrt(3)
+
+// isPolygonalTiling : inform if the cells of a neighborhood type
+// are not squares, so they must be drawn as polygons
+func isPolygonalTiling(neighborhoodType int) bool {
+	return neighborhoodType == neighborhood.HEXAGONAL || neighborhoodType == neighborhood.TRIANGULAR
+}
+
+// tilingSize : return the width and height of a tiling of rows x cols
+// cells whose polygons have a side of size
+func tilingSize(neighborhoodType, rows, cols int, size float64) (float64, float64) {
+	switch neighborhoodType {
+	case neighborhood.HEXAGONAL:
+		// Pointy-top hexagons, odd rows are shifted half a hexagon to the right
+		return sqrt3 * size * (float64(cols) + 0.5), size * (1.5*float64(rows) + 0.5)
+	case neighborhood.TRIANGULAR:
+		return size * float64(cols+1) / 2, sqrt3 / 2 * size * float64(rows)
+	}
+	return size * float64(cols), size * float64(rows)
+}
+
+// cellPolygon : return the x and y coordinates of the vertices of the cell i, j.
+// The absolute row and column of the cell (absI, absJ) decide its orientation.
+func cellPolygon(neighborhoodType, i, j, absI, absJ int, size float64) ([]float64, []float64) {
+	switch neighborhoodType {
+	case neighborhood.HEXAGONAL:
+		width := sqrt3 * size
+		centerX := width * (float64(j) + 0.5)
+		if absI&1 == 1 {
+			centerX += width / 2
+		}
+		centerY := size * (1.5*float64(i) + 1)
+		xs := make([]float64, 6)
+		ys := make([]float64, 6)
+		for vertex := 0; vertex < 6; vertex++ {
+			angle := math.Pi/6 + float64(vertex)*math.Pi/3
+			xs[vertex] = centerX + size*math.Cos(angle)
+			ys[vertex] = centerY + size*math.Sin(angle)
+		}
+		return xs, ys
+	case neighborhood.TRIANGULAR:
+		height := sqrt3 / 2 * size
+		left := float64(j) * size / 2
+		top := float64(i) * height
+		if (absI+absJ)&1 == 0 {
+			// Pointing up
+			return []float64{left, left + size/2, left + size}, []float64{top + height, top, top + height}
+		}
+		// Pointing down
+		return []float64{left, left + size, left + size/2}, []float64{top, top, top + height}
+	}
+	left := float64(j) * size
+	top := float64(i) * size
+	return []float64{left, left + size, left + size, left}, []float64{top, top, top + size, top + size}
+}
+
+// frameImage : draw the window of a generation of the game of life, one
+// pixel per cell for square cells and one polygon per cell for the other tilings
+func frameImage(g *gol.Gol, window frameWindow, palette color.Palette) *image.Paletted {
+	if isPolygonalTiling(g.NeighborhoodType()) {
+		return tilingImage(g, window, palette)
+	}
+	img := image.NewPaletted(image.Rect(0, 0, window.cols, window.rows), palette)
+	for i := 0; i < window.rows; i++ {
+		for j := 0; j < window.cols; j++ {
+			img.SetColorIndex(j, i, uint8(window.get(g, i, j)))
+		}
+	}
+	return img
+}
+
+// tilingImage : draw each cell of the window of the game of life as
+// a polygon whose color is the one of its state in the palette
+func tilingImage(g *gol.Gol, window frameWindow, palette color.Palette) *image.Paletted {
+	neighborhoodType := g.NeighborhoodType()
+	width, height := tilingSize(neighborhoodType, window.rows, window.cols, tilingCellSize)
+	img := image.NewPaletted(image.Rect(0, 0, int(math.Ceil(width)), int(math.Ceil(height))), palette)
+	for i := 0; i < window.rows; i++ {
+		for j := 0; j < window.cols; j++ {
+			xs, ys := cellPolygon(neighborhoodType, i, j, window.originI+i, window.originJ+j, tilingCellSize)
+			fillPolygon(img, xs, ys, uint8(window.get(g, i, j)))
+		}
+	}
+	return img
+}
+
+// fillPolygon : set the color of the pixels whose center
+// is inside of a convex polygon
+func fillPolygon(img *image.Paletted, xs, ys []float64, colorIndex uint8) {
+	minX, maxX := xs[0], xs[0]
+	minY, maxY := ys[0], ys[0]
+	for vertex := range xs {
+		minX = math.Min(minX, xs[vertex])
+		maxX = math.Max(maxX, xs[vertex])
+		minY = math.Min(minY, ys[vertex])
+		maxY = math.Max(maxY, ys[vertex])
+	}
+	for y := int(math.Floor(minY)); y < int(math.Ceil(maxY)); y++ {
+		for x := int(math.Floor(minX)); x < int(math.Ceil(maxX)); x++ {
+			if isInsideConvexPolygon(float64(x)+0.5, float64(y)+0.5, xs, ys) {
+				img.SetColorIndex(x, y, colorIndex)
+			}
+		}
+	}
+}
+
+// isInsideConvexPolygon : inform if the point x, y is inside of a convex
+// polygon, i.e. it is at the same side of all of its edges
+func isInsideConvexPolygon(x, y float64, xs, ys []float64) bool {
+	hasPositive, hasNegative := false, false
+	for vertex := range xs {
+		next := (vertex + 1) % len(xs)
+		cross := (xs[next]-xs[vertex])*(y-ys[vertex]) - (ys[next]-ys[vertex])*(x-xs[vertex])
+		hasPositive = hasPositive || cross > 0
+		hasNegative = hasNegative || cross < 0
+	}
+	return !(hasPositive && hasNegative)
+}
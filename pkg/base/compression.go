@@ -0,0 +1,111 @@
+package base
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Extensions of the compressed files, that can be
+// added to the extension of any format (e.g. "gun.rle.gz")
+const (
+	GzipExtension = ".gz"
+	ZstdExtension = ".zst"
+)
+
+// CompressionExtension : return the compression extension of
+// a file (".gz" or ".zst"), or an empty string if it has none
+func CompressionExtension(filename string) string {
+	extension := strings.ToLower(FileExtension(filename))
+	if extension == GzipExtension || extension == ZstdExtension {
+		return extension
+	}
+	return ""
+}
+
+// FormatExtension : return the extension of the format of a file,
+// ignoring its compression extension (e.g. ".rle" for "gun.rle.gz")
+func FormatExtension(filename string) string {
+	compressionExtension := CompressionExtension(filename)
+	return FileExtension(filename[:len(filename)-len(compressionExtension)])
+}
+
+// OpenFile : open a file to read it, decompressing its
+// content if it has a compression extension
+func OpenFile(filename string) (io.ReadCloser, error) {
+	file, fileError := os.Open(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	switch CompressionExtension(filename) {
+	case GzipExtension:
+		gzipReader, gzipError := gzip.NewReader(file)
+		if gzipError != nil {
+			file.Close()
+			return nil, gzipError
+		}
+		return &compressedReader{gzipReader, func() { gzipReader.Close() }, file}, nil
+	case ZstdExtension:
+		zstdReader, zstdError := zstd.NewReader(file)
+		if zstdError != nil {
+			file.Close()
+			return nil, zstdError
+		}
+		return &compressedReader{zstdReader, zstdReader.Close, file}, nil
+	}
+	return file, nil
+}
+
+// CreateFile : create a file to write it, compressing its
+// content if it has a compression extension. The file must
+// be closed to write the end of the compressed content.
+func CreateFile(filename string) (io.WriteCloser, error) {
+	file, fileError := os.Create(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	switch CompressionExtension(filename) {
+	case GzipExtension:
+		return &compressedWriter{gzip.NewWriter(file), file}, nil
+	case ZstdExtension:
+		zstdWriter, zstdError := zstd.NewWriter(file)
+		if zstdError != nil {
+			file.Close()
+			return nil, zstdError
+		}
+		return &compressedWriter{zstdWriter, file}, nil
+	}
+	return file, nil
+}
+
+// compressedReader : reader of the decompressed content of a file
+type compressedReader struct {
+	io.Reader
+	closeDecompressor func()
+	file              *os.File
+}
+
+// Close : release the decompressor and close the file
+func (cr *compressedReader) Close() error {
+	cr.closeDecompressor()
+	return cr.file.Close()
+}
+
+// compressedWriter : writer of the compressed content of a file
+type compressedWriter struct {
+	io.WriteCloser
+	file *os.File
+}
+
+// Close : write the end of the compressed content and close the file
+func (cw *compressedWriter) Close() error {
+	compressorError := cw.WriteCloser.Close()
+	fileError := cw.file.Close()
+	if compressorError != nil {
+		return compressorError
+	}
+	return fileError
+}
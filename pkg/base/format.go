@@ -0,0 +1,31 @@
+package base
+
+import "strings"
+
+// Names of the file formats of the Game of Life instances
+const (
+	CongolwayFormat = "congolway"
+	CellsFormat     = "cells"
+	// Life 1.05 or 1.06, depending on the header
+	LifeFormat      = "life"
+	Life105Format   = "life105"
+	Life106Format   = "life106"
+	GifFormat       = "gif"
+	PngFormat       = "png"
+	BmpFormat       = "bmp"
+	JpegFormat      = "jpeg"
+	RleFormat       = "rle"
+	MacrocellFormat = "macrocell"
+	NpyFormat       = "npy"
+	CsvFormat       = "csv"
+)
+
+// FileExtension : return the extension of a file
+// (e.g. ".rle"), or an empty string if it has none
+func FileExtension(filename string) string {
+	lastDotIndex := strings.LastIndex(filename, ".")
+	if lastDotIndex < 0 || strings.Contains(filename[lastDotIndex:], "/") {
+		return ""
+	}
+	return filename[lastDotIndex:]
+}
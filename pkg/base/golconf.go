@@ -2,12 +2,13 @@ package base
 
 import "github.com/diegojromerolopez/congolway/pkg/neighborhood"
 
-const DefaultRules = "23/3"
+const DefaultRules = "B3/S23"
 const DefaultRowLimitation = "limited"
 const DefaultColLimitation = "limited"
 const DefaultGridType = "dok"
 const DefaultGeneration = 0
 const DefaultNeighborhoodType = neighborhood.MOORE
+const DefaultEngine = "grid"
 
 // GolConf : configuration for Game of Life instances
 type GolConf struct {
@@ -17,6 +18,7 @@ type GolConf struct {
 	colLimitation    string
 	generation       int
 	neighborhoodType int
+	engine           string
 }
 
 // NewDefaultGolConf : returns a default configuration
@@ -28,20 +30,23 @@ func NewDefaultGolConf() *GolConf {
 		DefaultRowLimitation,
 		DefaultColLimitation,
 		DefaultGeneration,
-		DefaultNeighborhoodType}
+		DefaultNeighborhoodType,
+		DefaultEngine}
 }
 
 // NewGolConf : returns a default configuration
 // but overwritting the fields that are passed
 // as a map
 func NewGolConf(overwrittenAttrs map[string]interface{}) *GolConf {
-	gconf := &GolConf{
-		DefaultRules,
-		DefaultGridType,
-		DefaultRowLimitation,
-		DefaultColLimitation,
-		DefaultGeneration,
-		DefaultNeighborhoodType}
+	return NewDefaultGolConf().Copy(overwrittenAttrs)
+}
+
+// Copy : returns a copy of this configuration
+// but overwritting the fields that are passed
+// as a map
+func (gc *GolConf) Copy(overwrittenAttrs map[string]interface{}) *GolConf {
+	gconf := new(GolConf)
+	*gconf = *gc
 
 	if overwrittenAttrs["rules"] != nil {
 		gconf.rules = overwrittenAttrs["rules"].(string)
@@ -61,6 +66,9 @@ func NewGolConf(overwrittenAttrs map[string]interface{}) *GolConf {
 	if overwrittenAttrs["neighborhoodType"] != nil {
 		gconf.neighborhoodType = overwrittenAttrs["neighborhoodType"].(int)
 	}
+	if overwrittenAttrs["engine"] != nil {
+		gconf.engine = overwrittenAttrs["engine"].(string)
+	}
 	return gconf
 }
 
@@ -87,3 +95,7 @@ func (gc *GolConf) Generation() int {
 func (gc *GolConf) NeighborhoodType() int {
 	return gc.neighborhoodType
 }
+
+func (gc *GolConf) Engine() string {
+	return gc.engine
+}
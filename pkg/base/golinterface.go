@@ -3,10 +3,14 @@ package base
 // GolInterface : minimal Gol interface.
 type GolInterface interface {
 	// Initializer of a Gol
-	InitFromConf(name, description string, rows, cols int, gconf *GolConf)
+	InitFromConf(name, description string, rows, cols int, gconf *GolConf) error
 	// Dummy-property methods
 	Name() string
 	Description() string
+	// Custom metadata methods
+	Metadata(key string) string
+	MetadataKeys() []string
+	SetMetadata(key, value string)
 	// Row and cols related methods
 	Rows() int
 	Cols() int
@@ -14,15 +18,21 @@ type GolInterface interface {
 	SetLimitRows(limitRows bool)
 	LimitCols() bool
 	SetLimitCols(limitCols bool)
+	Unbounded() bool
+	Origin() (int, int)
+	SetOrigin(i, j int) error
 	// Cloning
 	Clone() GolInterface
 	// Indexing methods
 	Get(i int, j int) int
-	Set(i int, j int, value int)
-	SetAll(value int)
+	Set(i int, j int, value int) error
+	SetAll(value int) error
+	// Number of alive cells
+	Population() int
 	// Rules methods
 	Rules() string
-	SetRules(rules string)
+	SetRules(rules string) error
+	States() int
 	// Debug methods
 	DbgStdout()
 	// Generation methods
@@ -34,13 +44,16 @@ type GolInterface interface {
 	EqualsError(g GolInterface) error
 	// NeighborhoodType-related methods
 	NeighborhoodTypeString() string
-	SetNeighborhoodType(neighborhoodType int)
-	SetNeighborhoodTypeString(neighborhoodType string)
+	SetNeighborhoodType(neighborhoodType int) error
+	SetNeighborhoodTypeString(neighborhoodType string) error
 	// Concurrency-related methods
 	Processes() int
 	SetProcesses(processes int)
 	ThreadPoolSize() int
 	SetThreadPoolSize(threadPoolSize int)
+	// Engine-related methods
+	Engine() string
+	SetEngine(engine string) error
 	// Changes that can be applied at any moment
 	// without calling nextGeneration
 	ChangeCells(changes [][]int) GolInterface
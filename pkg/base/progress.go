@@ -0,0 +1,93 @@
+package base
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Progress : progress of a run of a number of generations
+type Progress struct {
+	// Generation of the game of life instance
+	Generation int
+	// Number of generations computed in the run so far,
+	// and number of generations of the whole run
+	Computed int
+	Total    int
+	// Number of alive cells
+	Population int
+	// Time since the start of the run
+	Elapsed time.Duration
+}
+
+// ProgressFunc : function that is called when a run of
+// a number of generations progresses
+type ProgressFunc func(progress Progress)
+
+// ProgressTracker : reporter of the progress of a run to a ProgressFunc
+type ProgressTracker struct {
+	start    time.Time
+	total    int
+	progress ProgressFunc
+}
+
+// NewProgressTracker : start tracking the progress of a run of a number
+// of generations. The progress function can be nil (nothing is reported).
+func NewProgressTracker(total int, progress ProgressFunc) *ProgressTracker {
+	return &ProgressTracker{start: time.Now(), total: total, progress: progress}
+}
+
+// Report : report the progress of the run once
+// some generations of it have been computed
+func (pt *ProgressTracker) Report(g GolInterface, computed int) {
+	if pt.progress == nil {
+		return
+	}
+	pt.progress(Progress{
+		Generation: g.Generation(),
+		Computed:   computed,
+		Total:      pt.total,
+		Population: g.Population(),
+		Elapsed:    time.Since(pt.start),
+	})
+}
+
+// End : report the progress of a run that ends once some generations
+// of it have been computed, even if they are less than its total
+func (pt *ProgressTracker) End(g GolInterface, computed int) {
+	pt.total = computed
+	pt.Report(g, computed)
+}
+
+// progressBarWidth : number of characters of the bars drawn by ProgressBar
+const progressBarWidth = 30
+
+// ProgressBar : return a progress function that draws a progress bar, with
+// the generation, the population and the elapsed time, in the last line of a
+// terminal (e.g. os.Stderr). The line is ended when the run is completed.
+// Runs with an unknown total (0) are drawn without the bar, in a line that
+// is never ended.
+func ProgressBar(w io.Writer) ProgressFunc {
+	return func(progress Progress) {
+		elapsed := progress.Elapsed.Round(time.Millisecond)
+		if progress.Total <= 0 {
+			fmt.Fprintf(w, "\rgeneration %d, population %d, %s\033[K",
+				progress.Generation, progress.Population, elapsed)
+			return
+		}
+		filled := progressBarWidth * progress.Computed / progress.Total
+		if filled < 0 {
+			filled = 0
+		} else if filled > progressBarWidth {
+			filled = progressBarWidth
+		}
+		percentage := 100 * progress.Computed / progress.Total
+		fmt.Fprintf(w, "\r[%s%s] %3d%% generation %d, population %d, %s\033[K",
+			strings.Repeat("#", filled), strings.Repeat(" ", progressBarWidth-filled), percentage,
+			progress.Generation, progress.Population, elapsed)
+		if progress.Computed >= progress.Total {
+			fmt.Fprintln(w)
+		}
+	}
+}
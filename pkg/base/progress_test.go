@@ -0,0 +1,37 @@
+package base
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressBar(t *testing.T) {
+	var output bytes.Buffer
+	progressBar := ProgressBar(&output)
+
+	progressBar(Progress{Generation: 5, Computed: 5, Total: 10, Population: 7, Elapsed: time.Second})
+	expected := "\r[" + strings.Repeat("#", 15) + strings.Repeat(" ", 15) + "]  50% generation 5, population 7, 1s\033[K"
+	if output.String() != expected {
+		t.Errorf("The progress bar should be %q, found %q", expected, output.String())
+	}
+
+	// The bar is not overfilled and the line is ended when the run is completed
+	output.Reset()
+	progressBar(Progress{Generation: 12, Computed: 12, Total: 10, Population: 7, Elapsed: time.Second})
+	if !strings.HasPrefix(output.String(), "\r["+strings.Repeat("#", progressBarWidth)+"]") ||
+		!strings.HasSuffix(output.String(), "\n") {
+		t.Errorf("The progress bar should be full and ended, found %q", output.String())
+	}
+}
+
+func TestProgressBarUnknownTotal(t *testing.T) {
+	var output bytes.Buffer
+	progressBar := ProgressBar(&output)
+	progressBar(Progress{Generation: 3, Computed: 3, Total: 0, Population: 4, Elapsed: 2 * time.Second})
+	expected := "\rgeneration 3, population 4, 2s\033[K"
+	if output.String() != expected {
+		t.Errorf("The progress of a run with an unknown total should be %q, found %q", expected, output.String())
+	}
+}
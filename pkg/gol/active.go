@@ -0,0 +1,80 @@
+package gol
+
+import (
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// ChangedCells : return the coordinates (in row-major order) of the cells
+// whose state changed in the last generation, and whether they are known.
+// They are not known before the first generation, after the cells, the rules
+// or the neighborhood are changed (e.g. by Set), in unbounded grids and when
+// the next generation was computed by the HashLife, bit-packed or Larger than
+// Life engines. Callers can use them to only render the changed cells.
+func (g *Gol) ChangedCells() ([][2]int, bool) {
+	return g.changedCells, g.changedCellsKnown
+}
+
+// forgetChangedCells : mark the changed cells of the last generation as
+// not known, so the next generation is computed for every cell of the grid
+func (g *Gol) forgetChangedCells() {
+	// Checking first avoids writing when cells are set concurrently
+	if g.changedCellsKnown {
+		g.changedCells = nil
+		g.changedCellsKnown = false
+	}
+}
+
+// tracksChangedCells : inform if the next generation can be computed
+// by only re-evaluating the changed cells and their neighbors
+func (g *Gol) tracksChangedCells() bool {
+	return g.changedCellsKnown && !g.grid.Unbounded()
+}
+
+// computesChangedCells : inform if the changed cells will be
+// known once the next generation is computed (see ChangedCells)
+func (g *Gol) computesChangedCells() bool {
+	return !g.rule.IsLargerThanLife() && !g.UsesHashLife() && !g.usesBitpacked() && !g.grid.Unbounded()
+}
+
+// activeCells : return the cells (in row-major order) whose state can change
+// in the next generation: the changed cells of the last generation and the
+// ones that have any of them in their neighborhood
+func (g *Gol) activeCells() [][2]int {
+	rows := g.Rows()
+	cols := g.Cols()
+	radius := utils.MaxInt(g.rule.Radius(), neighborhood.Radius(g.neighborhoodType))
+	active := make([]bool, rows*cols)
+	activeCount := 0
+	for _, cell := range g.changedCells {
+		for i := cell[0] - radius; i <= cell[0]+radius; i++ {
+			actualI, iIsIn := wrapIndex(i, rows, g.LimitRows())
+			if !iIsIn {
+				continue
+			}
+			for j := cell[1] - radius; j <= cell[1]+radius; j++ {
+				actualJ, jIsIn := wrapIndex(j, cols, g.LimitCols())
+				if jIsIn && !active[actualI*cols+actualJ] {
+					active[actualI*cols+actualJ] = true
+					activeCount++
+				}
+			}
+		}
+	}
+	cells := make([][2]int, 0, activeCount)
+	for cell, isActive := range active {
+		if isActive {
+			cells = append(cells, [2]int{cell / cols, cell % cols})
+		}
+	}
+	return cells
+}
+
+// wrapIndex : return the index of a row (or column) of a grid with
+// that many rows (or columns), and whether it is in the grid
+func wrapIndex(index, size int, limited bool) (int, bool) {
+	if limited {
+		return index, index >= 0 && index < size
+	}
+	return ((index % size) + size) % size, true
+}
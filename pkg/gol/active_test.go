@@ -0,0 +1,127 @@
+package gol
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestChangedCells(t *testing.T) {
+	grids := map[string]*Gol{}
+	grids["limited"], _ = NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 30, 40, int64(42))
+	grids["torus"], _ = NewRandomGol("Random", "", "B36/S23", "dok", "unlimited", "unlimited", 25, 31, int64(7))
+	grids["generations"], _ = NewRandomGol("Random", "", "B2/S/C4", "dense", "unlimited", "limited", 20, 20, int64(3))
+	grids["triangular"], _ = NewRandomGol("Random", "", "B4/S345", "dok", "unlimited", "unlimited", 16, 24, int64(5))
+	grids["triangular"].SetNeighborhoodType(neighborhood.TRIANGULAR)
+	mask, _ := neighborhood.ParseMask("0,0,1,0,0;0,1,1,1,0;1,1,0,1,1;0,1,1,1,0;0,0,1,0,0")
+	grids["custom"], _ = NewRandomGol("Random", "", "B3/S23", "dense", "limited", "unlimited", 20, 20, int64(11))
+	grids["custom"].SetNeighborhoodType(neighborhood.RegisterMask(mask))
+	tallMask, _ := neighborhood.ParseMask("1;1;0;1;1")
+	grids["tall custom"], _ = NewRandomGol("Random", "", "B1/S", "dense", "limited", "unlimited", 20, 20, int64(13))
+	grids["tall custom"].SetNeighborhoodType(neighborhood.RegisterMask(tallMask))
+
+	for name, g := range grids {
+		for _, processes := range []int{SERIAL, 3} {
+			g.SetProcesses(processes)
+			if _, changedCellsKnown := g.ChangedCells(); changedCellsKnown {
+				t.Errorf("%s: the changed cells should not be known before the first generation", name)
+			}
+			previousG := g
+			nextG := g.NextGeneration().(*Gol)
+			for generation := 1; generation <= 30; generation++ {
+				changedCells, changedCellsKnown := nextG.ChangedCells()
+				if !changedCellsKnown {
+					t.Errorf("%s: the changed cells of the generation %d should be known", name, generation)
+					break
+				}
+				if expectedChangedCells := diffCells(previousG, nextG); !reflect.DeepEqual(changedCells, expectedChangedCells) {
+					t.Errorf("%s: the changed cells of the generation %d should be %v, found %v",
+						name, generation, expectedChangedCells, changedCells)
+					break
+				}
+				// Forgetting the changed cells makes every cell to be computed
+				fullG := nextG.Clone().(*Gol)
+				fullG.forgetChangedCells()
+				previousG = nextG
+				nextG = nextG.NextGeneration().(*Gol)
+				if equalsError := fullG.NextGeneration().EqualsError(nextG); equalsError != nil {
+					t.Errorf("%s: generation %d: %s", name, generation+1, equalsError)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestChangedCellsForgotten(t *testing.T) {
+	g, _ := NewGol("Blinker", "", "B3/S23", "dense", "limited", "limited", 5, 5, 0)
+	for j := 1; j < 4; j++ {
+		g.Set(2, j, statuses.ALIVE)
+	}
+	nextG := g.NextGeneration().(*Gol)
+	if changedCells, _ := nextG.ChangedCells(); len(changedCells) != 4 {
+		t.Errorf("4 cells of the blinker should have changed, found %v", changedCells)
+	}
+
+	// Setting a cell makes it to be considered in the next generation
+	nextG.Set(0, 0, statuses.ALIVE)
+	if _, changedCellsKnown := nextG.ChangedCells(); changedCellsKnown {
+		t.Errorf("The changed cells should not be known after a cell is set")
+	}
+	if nextG.NextGeneration().Get(0, 0) != statuses.DEAD {
+		t.Errorf("The set cell should have died")
+	}
+
+	// Still lifes do not change
+	block, _ := NewGol("Block", "", "B3/S23", "dok", "limited", "limited", 4, 4, 0)
+	for _, cell := range [][]int{{1, 1}, {1, 2}, {2, 1}, {2, 2}} {
+		block.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	ffBlock := block.FastForward(3).(*Gol)
+	if changedCells, changedCellsKnown := ffBlock.ChangedCells(); !changedCellsKnown || len(changedCells) != 0 {
+		t.Errorf("No cell of a block should change, found %v", changedCells)
+	}
+	if !ffBlock.GridEquals(block, "values") {
+		t.Errorf("The block should not change")
+	}
+
+	// Unbounded grids can change their size
+	unbounded, _ := NewGol("Blinker", "", "B3/S23", "dok", "unbounded", "unbounded", 1, 3, 0)
+	unbounded.SetAll(statuses.ALIVE)
+	if _, changedCellsKnown := unbounded.NextGeneration().(*Gol).ChangedCells(); changedCellsKnown {
+		t.Errorf("The changed cells of unbounded grids should not be known")
+	}
+}
+
+// diffCells : return the cells whose state is different in two instances
+func diffCells(g, other *Gol) [][2]int {
+	cells := [][2]int{}
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			if g.Get(i, j) != other.Get(i, j) {
+				cells = append(cells, [2]int{i, j})
+			}
+		}
+	}
+	return cells
+}
+
+func TestChangedCellsNonSquareMask(t *testing.T) {
+	// The neighbors of the mask are 2 rows away, farther than its columns
+	mask, _ := neighborhood.ParseMask("1;1;0;1;1")
+	g, _ := NewGol("Tall mask", "", "B1/S", "dense", "limited", "limited", 11, 3, 0)
+	g.SetNeighborhoodType(neighborhood.RegisterMask(mask))
+	g.Set(5, 1, statuses.ALIVE)
+	nextG := g.NextGeneration().(*Gol)
+	for generation := 2; generation <= 3; generation++ {
+		// A fresh instance with the same cells computes every cell
+		fullG := nextG.Clone().(*Gol)
+		fullG.forgetChangedCells()
+		nextG = nextG.NextGeneration().(*Gol)
+		if equalsError := fullG.NextGeneration().EqualsError(nextG); equalsError != nil {
+			t.Errorf("Generation %d: %s", generation, equalsError)
+		}
+	}
+}
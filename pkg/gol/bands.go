@@ -0,0 +1,194 @@
+package gol
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/grid"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// band : contiguous part of the next generation that is computed
+// by a worker from the (read-only) current one. It is either the rows
+// [firstRow, lastRow) or, if the changed cells are tracked, some of
+// the active cells (see activeCells).
+type band struct {
+	g        *Gol
+	nextGrid *grid.Grid
+	padding  int
+	firstRow int
+	lastRow  int
+	active   bool
+	cells    [][2]int
+	// Cells of the band whose state changed (only
+	// computed if the grid is not unbounded)
+	trackChanges bool
+	changes      [][2]int
+}
+
+// compute : compute the cells of the band
+func (b *band) compute() {
+	if b.active {
+		for _, cell := range b.cells {
+			b.computeCell(cell[0], cell[1])
+		}
+		return
+	}
+	cols := b.nextGrid.Cols()
+	for i := b.firstRow; i < b.lastRow; i++ {
+		for j := 0; j < cols; j++ {
+			b.computeCell(i, j)
+		}
+	}
+}
+
+// computeCell : compute the cell i, j of the next generation
+func (b *band) computeCell(i, j int) {
+	nextStatus := b.g.nextCell(i-b.padding, j-b.padding)
+	changed := b.trackChanges && nextStatus != b.g.Get(i, j)
+	// Every cell of the grid of the next generation is set, unless only the
+	// active cells are computed (and then it is a copy of the current one)
+	if !b.active || changed {
+		b.nextGrid.Set(i, j, nextStatus)
+	}
+	if changed {
+		b.changes = append(b.changes, [2]int{i, j})
+	}
+}
+
+// nextGenerationBands : return the game of life instance where the next
+// generation will be stored and at most n bands of (almost) the same size
+// that compute it
+func (g *Gol) nextGenerationBands(n int) (*Gol, []*band) {
+	if g.tracksChangedCells() {
+		nextG := g.copyWithEmptyGrid().(*Gol)
+		nextG.grid = g.grid.Clone()
+		return nextG, g.activeBands(nextG.grid, n)
+	}
+	nextG, padding := g.emptyNextGeneration()
+	return nextG, g.rowBands(nextG.grid, padding, n)
+}
+
+// rowBands : return at most n bands of (almost) the same number of rows
+// that compute every cell of the next generation in nextGrid
+func (g *Gol) rowBands(nextGrid *grid.Grid, padding int, n int) []*band {
+	var bands []*band
+	rows := nextGrid.Rows()
+	n = utils.MinInt(n, rows)
+	for bandI := 0; bandI < n; bandI++ {
+		bands = append(bands, &band{
+			g: g, nextGrid: nextGrid, padding: padding, trackChanges: !g.grid.Unbounded(),
+			firstRow: bandI * rows / n, lastRow: (bandI + 1) * rows / n,
+		})
+	}
+	return bands
+}
+
+// activeBands : return at most n bands of (almost) the same number of active
+// cells that compute the next generation in nextGrid, a copy of the grid
+func (g *Gol) activeBands(nextGrid *grid.Grid, n int) []*band {
+	var bands []*band
+	cells := g.activeCells()
+	for bandI, firstCell := 0, 0; bandI < n && firstCell < len(cells); bandI++ {
+		// Bands do not share rows, as the cells of a row can be
+		// stored in the same word (e.g. in bit-packed grids)
+		lastCell := utils.MaxInt(firstCell+1, (bandI+1)*len(cells)/n)
+		for lastCell < len(cells) && cells[lastCell][0] == cells[lastCell-1][0] {
+			lastCell++
+		}
+		bands = append(bands, &band{
+			g: g, nextGrid: nextGrid, active: true, trackChanges: true, cells: cells[firstCell:lastCell],
+		})
+		firstCell = lastCell
+	}
+	return bands
+}
+
+// endNextGeneration : finish the next generation
+// once all of its bands have been computed
+func (nextG *Gol) endNextGeneration(bands []*band) *Gol {
+	nextG.grid.Fit()
+	nextG.generation++
+	nextG.setChangedCells(bands)
+	return nextG
+}
+
+// setChangedCells : set the changed cells of the bands
+// that computed the last generation, unless the grid is unbounded
+func (g *Gol) setChangedCells(bands []*band) {
+	if g.grid.Unbounded() {
+		return
+	}
+	g.changedCells = [][2]int{}
+	for _, b := range bands {
+		g.changedCells = append(g.changedCells, b.changes...)
+	}
+	g.changedCellsKnown = true
+}
+
+// computeBands : compute the bands without running threads
+func computeBands(bands []*band) {
+	for _, b := range bands {
+		b.compute()
+	}
+}
+
+// bandWorkers : pool of goroutines that compute the next generation
+// of a game of life instance, each one of them in a band of rows (or
+// of active cells). The same workers can compute any number of generations.
+type bandWorkers struct {
+	// workers : number of goroutines, and of bands of each generation
+	workers int
+	bands   chan *band
+	pending sync.WaitGroup
+}
+
+// newBandWorkers : start a pool of band workers
+func newBandWorkers(workers int) *bandWorkers {
+	bw := &bandWorkers{workers: workers, bands: make(chan *band, workers)}
+	for worker := 0; worker < workers; worker++ {
+		go bw.work()
+	}
+	return bw
+}
+
+// work : compute the bands sent to the workers until they are stopped
+func (bw *bandWorkers) work() {
+	for b := range bw.bands {
+		b.compute()
+		bw.pending.Done()
+	}
+}
+
+// nextGeneration : compute the next generation by splitting
+// it in as many bands as workers
+func (bw *bandWorkers) nextGeneration(g *Gol) base.GolInterface {
+	nextG, bands := g.nextGenerationBands(bw.workers)
+	bw.compute(bands)
+	return nextG.endNextGeneration(bands)
+}
+
+// compute : compute the bands in the workers
+// and wait until all of them are computed
+func (bw *bandWorkers) compute(bands []*band) {
+	bw.pending.Add(len(bands))
+	for _, b := range bands {
+		bw.bands <- b
+	}
+	bw.pending.Wait()
+}
+
+// stop : stop the workers of the pool
+func (bw *bandWorkers) stop() {
+	close(bw.bands)
+}
+
+// parallelWorkers : return the number of workers used by
+// the parallel engine (see Processes and SetProcesses)
+func parallelWorkers(g *Gol) int {
+	if g.processes == CPUS || g.processes < 1 {
+		return runtime.NumCPU()
+	}
+	return g.processes
+}
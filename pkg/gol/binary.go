@@ -0,0 +1,365 @@
+package gol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// binaryMagic : first bytes of the binary representation
+const binaryMagic = "CGLW"
+
+// Wire types of the fields of the binary representation,
+// with the same meaning as in protocol buffers
+const (
+	wireVarint          = 0
+	wireLengthDelimited = 2
+)
+
+// Field numbers of the binary representation. Integer fields are
+// zigzag-encoded varints and fields with zero values are omitted.
+const (
+	fieldName             = 1
+	fieldDescription      = 2
+	fieldMetadata         = 3
+	fieldRules            = 4
+	fieldGeneration       = 5
+	fieldNeighborhoodType = 6
+	fieldRows             = 7
+	fieldCols             = 8
+	fieldRowLimitation    = 9
+	fieldColLimitation    = 10
+	fieldOrigin           = 11
+	fieldGridType         = 12
+	fieldProcesses        = 13
+	fieldThreadPoolSize   = 14
+	fieldEngine           = 15
+	fieldRuns             = 16
+)
+
+// Field numbers of the metadata entries
+const (
+	fieldMetadataKey   = 1
+	fieldMetadataValue = 2
+)
+
+// MarshalBinary : return the compact binary representation of the game of
+// life instance: the magic string "CGLW", the version of the serialization
+// and a sequence of protobuf-style tagged fields, with the cells encoded
+// as packed runs of cells with the same state
+func (g *Gol) MarshalBinary() ([]byte, error) {
+	s := g.snapshot()
+	var w binaryWriter
+	w.buffer.WriteString(binaryMagic)
+	w.buffer.WriteByte(byte(s.Version))
+
+	w.writeString(fieldName, s.Name)
+	w.writeString(fieldDescription, s.Description)
+	metadataKeys := make([]string, 0, len(s.Metadata))
+	for key := range s.Metadata {
+		metadataKeys = append(metadataKeys, key)
+	}
+	sort.Strings(metadataKeys)
+	for _, key := range metadataKeys {
+		var entry binaryWriter
+		entry.writeString(fieldMetadataKey, key)
+		entry.writeString(fieldMetadataValue, s.Metadata[key])
+		w.writeBytes(fieldMetadata, entry.buffer.Bytes())
+	}
+	w.writeString(fieldRules, s.Rules)
+	w.writeInt(fieldGeneration, s.Generation)
+	w.writeString(fieldNeighborhoodType, s.NeighborhoodType)
+	w.writeInt(fieldRows, s.Rows)
+	w.writeInt(fieldCols, s.Cols)
+	w.writeString(fieldRowLimitation, s.RowLimitation)
+	w.writeString(fieldColLimitation, s.ColLimitation)
+	if len(s.Origin) > 0 {
+		w.writePackedInts(fieldOrigin, s.Origin)
+	}
+	w.writeString(fieldGridType, s.GridType)
+	w.writeInt(fieldProcesses, s.Processes)
+	w.writeInt(fieldThreadPoolSize, s.ThreadPoolSize)
+	w.writeString(fieldEngine, s.Engine)
+	runs := make([]int, 0, 2*len(s.Cells.Runs))
+	for _, run := range s.Cells.Runs {
+		runs = append(runs, run[0], run[1])
+	}
+	w.writePackedInts(fieldRuns, runs)
+	return w.buffer.Bytes(), nil
+}
+
+// UnmarshalBinary : initialize the game of life instance from
+// its binary representation (see MarshalBinary). Unknown fields
+// are skipped.
+func (g *Gol) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return fmt.Errorf("%w: the binary representation does not start with %q",
+			ErrInvalidSerialization, binaryMagic)
+	}
+	s := &snapshot{Version: int(data[len(binaryMagic)])}
+	if s.Version != serializationVersion {
+		return g.restore(s)
+	}
+
+	r := binaryReader{data: data[len(binaryMagic)+1:]}
+	for !r.done() {
+		field, wireType, keyError := r.readKey()
+		if keyError != nil {
+			return keyError
+		}
+		var fieldError error
+		switch field {
+		case fieldName:
+			s.Name, fieldError = r.readString(wireType)
+		case fieldDescription:
+			s.Description, fieldError = r.readString(wireType)
+		case fieldMetadata:
+			var entry []byte
+			if entry, fieldError = r.readBytes(wireType); fieldError == nil {
+				fieldError = s.readMetadataEntry(entry)
+			}
+		case fieldRules:
+			s.Rules, fieldError = r.readString(wireType)
+		case fieldGeneration:
+			s.Generation, fieldError = r.readInt(wireType)
+		case fieldNeighborhoodType:
+			s.NeighborhoodType, fieldError = r.readString(wireType)
+		case fieldRows:
+			s.Rows, fieldError = r.readInt(wireType)
+		case fieldCols:
+			s.Cols, fieldError = r.readInt(wireType)
+		case fieldRowLimitation:
+			s.RowLimitation, fieldError = r.readString(wireType)
+		case fieldColLimitation:
+			s.ColLimitation, fieldError = r.readString(wireType)
+		case fieldOrigin:
+			s.Origin, fieldError = r.readPackedInts(wireType)
+		case fieldGridType:
+			s.GridType, fieldError = r.readString(wireType)
+		case fieldProcesses:
+			s.Processes, fieldError = r.readInt(wireType)
+		case fieldThreadPoolSize:
+			s.ThreadPoolSize, fieldError = r.readInt(wireType)
+		case fieldEngine:
+			s.Engine, fieldError = r.readString(wireType)
+		case fieldRuns:
+			var runs []int
+			if runs, fieldError = r.readPackedInts(wireType); fieldError == nil {
+				if len(runs)%2 != 0 {
+					return fmt.Errorf("%w: the runs of the cells must be [state, length] pairs",
+						ErrInvalidSerialization)
+				}
+				for run := 0; run < len(runs); run += 2 {
+					s.Cells.Runs = append(s.Cells.Runs, [2]int{runs[run], runs[run+1]})
+				}
+			}
+		default:
+			fieldError = r.skip(wireType)
+		}
+		if fieldError != nil {
+			return fieldError
+		}
+	}
+	// An empty grid has no runs
+	if s.Rows*s.Cols > 0 && len(s.Cells.Runs) == 0 {
+		return fmt.Errorf("%w: the cells of the grid are missing", ErrInvalidSerialization)
+	}
+	return g.restore(s)
+}
+
+// readMetadataEntry : read a key-value pair of the metadata
+func (s *snapshot) readMetadataEntry(entry []byte) error {
+	var key, value string
+	r := binaryReader{data: entry}
+	for !r.done() {
+		field, wireType, keyError := r.readKey()
+		if keyError != nil {
+			return keyError
+		}
+		var fieldError error
+		switch field {
+		case fieldMetadataKey:
+			key, fieldError = r.readString(wireType)
+		case fieldMetadataValue:
+			value, fieldError = r.readString(wireType)
+		default:
+			fieldError = r.skip(wireType)
+		}
+		if fieldError != nil {
+			return fieldError
+		}
+	}
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	s.Metadata[key] = value
+	return nil
+}
+
+// binaryWriter : writer of protobuf-style tagged fields
+type binaryWriter struct {
+	buffer bytes.Buffer
+}
+
+// writeUvarint : write an unsigned varint
+func (w *binaryWriter) writeUvarint(value uint64) {
+	var varint [binary.MaxVarintLen64]byte
+	w.buffer.Write(varint[:binary.PutUvarint(varint[:], value)])
+}
+
+// writeKey : write the field number and the wire type of a field
+func (w *binaryWriter) writeKey(field, wireType int) {
+	w.writeUvarint(uint64(field<<3 | wireType))
+}
+
+// writeInt : write a zigzag-encoded integer field, unless it is zero
+func (w *binaryWriter) writeInt(field, value int) {
+	if value == 0 {
+		return
+	}
+	w.writeKey(field, wireVarint)
+	w.writeUvarint(zigzag(value))
+}
+
+// writeBytes : write a length-delimited field
+func (w *binaryWriter) writeBytes(field int, value []byte) {
+	w.writeKey(field, wireLengthDelimited)
+	w.writeUvarint(uint64(len(value)))
+	w.buffer.Write(value)
+}
+
+// writeString : write a string field, unless it is empty
+func (w *binaryWriter) writeString(field int, value string) {
+	if value == "" {
+		return
+	}
+	w.writeBytes(field, []byte(value))
+}
+
+// writePackedInts : write zigzag-encoded integers
+// in a length-delimited field, unless there are none
+func (w *binaryWriter) writePackedInts(field int, values []int) {
+	if len(values) == 0 {
+		return
+	}
+	var packed binaryWriter
+	for _, value := range values {
+		packed.writeUvarint(zigzag(value))
+	}
+	w.writeBytes(field, packed.buffer.Bytes())
+}
+
+// binaryReader : reader of protobuf-style tagged fields
+type binaryReader struct {
+	data []byte
+	pos  int
+}
+
+// done : inform if all the data has been read
+func (r *binaryReader) done() bool {
+	return r.pos >= len(r.data)
+}
+
+// readUvarint : read an unsigned varint
+func (r *binaryReader) readUvarint() (uint64, error) {
+	value, length := binary.Uvarint(r.data[r.pos:])
+	if length <= 0 {
+		return 0, fmt.Errorf("%w: invalid varint at the byte %d", ErrInvalidSerialization, r.pos)
+	}
+	r.pos += length
+	return value, nil
+}
+
+// readKey : read the field number and the wire type of a field
+func (r *binaryReader) readKey() (int, int, error) {
+	key, keyError := r.readUvarint()
+	if keyError != nil {
+		return 0, 0, keyError
+	}
+	return int(key >> 3), int(key & 7), nil
+}
+
+// checkWireType : return an error if the wire type of a field is not the expected one
+func (r *binaryReader) checkWireType(wireType, expectedWireType int) error {
+	if wireType != expectedWireType {
+		return fmt.Errorf("%w: wire type %d found at the byte %d, expected %d",
+			ErrInvalidSerialization, wireType, r.pos, expectedWireType)
+	}
+	return nil
+}
+
+// readInt : read a zigzag-encoded integer field
+func (r *binaryReader) readInt(wireType int) (int, error) {
+	if wireTypeError := r.checkWireType(wireType, wireVarint); wireTypeError != nil {
+		return 0, wireTypeError
+	}
+	value, valueError := r.readUvarint()
+	return unzigzag(value), valueError
+}
+
+// readBytes : read a length-delimited field
+func (r *binaryReader) readBytes(wireType int) ([]byte, error) {
+	if wireTypeError := r.checkWireType(wireType, wireLengthDelimited); wireTypeError != nil {
+		return nil, wireTypeError
+	}
+	length, lengthError := r.readUvarint()
+	if lengthError != nil {
+		return nil, lengthError
+	}
+	if length > uint64(len(r.data)-r.pos) {
+		return nil, fmt.Errorf("%w: field of %d bytes at the byte %d exceeds the data",
+			ErrInvalidSerialization, length, r.pos)
+	}
+	value := r.data[r.pos : r.pos+int(length)]
+	r.pos += int(length)
+	return value, nil
+}
+
+// readString : read a string field
+func (r *binaryReader) readString(wireType int) (string, error) {
+	value, valueError := r.readBytes(wireType)
+	return string(value), valueError
+}
+
+// readPackedInts : read zigzag-encoded integers of a length-delimited field
+func (r *binaryReader) readPackedInts(wireType int) ([]int, error) {
+	packed, packedError := r.readBytes(wireType)
+	if packedError != nil {
+		return nil, packedError
+	}
+	var values []int
+	packedReader := binaryReader{data: packed}
+	for !packedReader.done() {
+		value, valueError := packedReader.readUvarint()
+		if valueError != nil {
+			return nil, valueError
+		}
+		values = append(values, unzigzag(value))
+	}
+	return values, nil
+}
+
+// skip : skip a field whose number is unknown
+func (r *binaryReader) skip(wireType int) error {
+	switch wireType {
+	case wireVarint:
+		_, skipError := r.readUvarint()
+		return skipError
+	case wireLengthDelimited:
+		_, skipError := r.readBytes(wireType)
+		return skipError
+	}
+	return fmt.Errorf("%w: unsupported wire type %d at the byte %d", ErrInvalidSerialization, wireType, r.pos)
+}
+
+// zigzag : map signed integers to unsigned ones so that
+// integers with small absolute values have short varints
+func zigzag(value int) uint64 {
+	return uint64(int64(value)<<1 ^ int64(value)>>63)
+}
+
+// unzigzag : inverse of zigzag
+func unzigzag(value uint64) int {
+	return int(int64(value>>1) ^ -int64(value&1))
+}
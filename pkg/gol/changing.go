@@ -33,7 +33,9 @@ func (g *Gol) parallelChangeCells(changes [][]int) base.GolInterface {
 	var wg sync.WaitGroup
 	wg.Add(len(changes))
 
-	gCopy := g.Clone()
+	gCopy := g.Clone().(*Gol)
+	// The cells are set concurrently
+	gCopy.forgetChangedCells()
 	for _, change := range changes {
 		go func(i, j, status int) {
 			gCopy.Set(i, j, status)
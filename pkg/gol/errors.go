@@ -0,0 +1,14 @@
+package gol
+
+import "errors"
+
+// ErrInvalidEngine : the engine is not GridEngine nor HashLifeEngine
+var ErrInvalidEngine = errors.New("Invalid engine")
+
+// ErrInvalidStatus : the status of a cell is not one
+// of the states of the rules
+var ErrInvalidStatus = errors.New("Invalid status")
+
+// ErrInvalidSerialization : the JSON or binary representation
+// of a game of life instance is not valid
+var ErrInvalidSerialization = errors.New("Invalid serialization")
@@ -2,12 +2,14 @@ package gol
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"reflect"
+	"sort"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/grid"
 	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 // Gol : game of life
@@ -18,48 +20,93 @@ type Gol struct {
 	generation       int
 	neighborhoodType int
 	neighborhoodFunc neighborhood.Func
-	rules            string
-	survivalRule     map[int]bool // Poor's man set
-	birthRule        map[int]bool // Poor's man set
-	processes        int
-	threadPoolSize   int
+	// Weights of the neighbors of custom neighborhoods
+	// (nil if every neighbor weighs 1)
+	neighborhoodWeights []int
+	rule                *rules.Rule
+	survivalRule        map[int]bool // Poor's man set
+	birthRule           map[int]bool // Poor's man set
+	processes           int
+	threadPoolSize      int
+	engine              string
+	// Custom metadata (e.g. author or tags). The map is replaced
+	// instead of modified, so it can be shared by the generations.
+	metadata map[string]string
+	// Cells whose state changed in the last generation
+	// (see ChangedCells), if changedCellsKnown is true
+	changedCells      [][2]int
+	changedCellsKnown bool
+	// Grid of the previous generation, where Step computes the next one
+	// (nil if the instance has not been stepped). It is not shared by clones.
+	backGrid *grid.Grid
 }
 
-// NewGol : creates a game of life
-func NewGol(name, description, rules, gridType, rowsLimitation, colsLimitation string, rows, cols, generation int) *Gol {
+// NewGol : creates a game of life, or returns an error
+// if the rules or the grid type are not valid
+func NewGol(name, description, rules, gridType, rowsLimitation, colsLimitation string, rows, cols, generation int) (*Gol, error) {
+	gr, gridError := grid.NewGrid(rows, cols, rowsLimitation, colsLimitation, gridType)
+	if gridError != nil {
+		return nil, gridError
+	}
 	g := new(Gol)
-	gr := grid.NewGrid(rows, cols, rowsLimitation, colsLimitation, gridType)
-	g.InitWithGrid(name, description, rules, generation, neighborhood.MOORE, gr)
-	return g
+	if initError := g.InitWithGrid(name, description, rules, generation, neighborhood.MOORE, gr); initError != nil {
+		return nil, initError
+	}
+	return g, nil
 }
 
-// NewRandomGol : creates a new random game of life
-func NewRandomGol(name, description, rules, gridType, rowsLimitation, colsLimitation string, rows, cols int, randomSeed int64) *Gol {
+// NewRandomGol : creates a new random game of life, or returns an error
+// if the rules or the grid type are not valid
+func NewRandomGol(name, description, rules, gridType, rowsLimitation, colsLimitation string, rows, cols int, randomSeed int64) (*Gol, error) {
+	gr, gridError := grid.NewRandomGrid(rows, cols, rowsLimitation, colsLimitation, gridType, randomSeed)
+	if gridError != nil {
+		return nil, gridError
+	}
 	g := new(Gol)
-	gr := grid.NewRandomGrid(rows, cols, rowsLimitation, colsLimitation, gridType, randomSeed)
-	g.InitWithGrid(name, description, rules, 0, neighborhood.MOORE, gr)
-	return g
+	if initError := g.InitWithGrid(name, description, rules, 0, neighborhood.MOORE, gr); initError != nil {
+		return nil, initError
+	}
+	return g, nil
 }
 
 // InitFromConf : initialize a Game of Life instance
-func (g *Gol) InitFromConf(name, description string, rows, cols int, gconf *base.GolConf) {
-	g.init(name, description,
+func (g *Gol) InitFromConf(name, description string, rows, cols int, gconf *base.GolConf) error {
+	initError := g.init(name, description,
 		gconf.Rules(), gconf.GridType(),
 		gconf.RowLimitation(), gconf.ColLimitation(),
 		rows, cols, gconf.Generation(), gconf.NeighborhoodType())
+	if initError != nil {
+		return initError
+	}
+	return g.SetEngine(gconf.Engine())
 }
 
 // InitWithGrid : initialize a Game of Life instance
-func (g *Gol) InitWithGrid(name, description, rules string, generation, neighborhoodType int, gr *grid.Grid) {
+func (g *Gol) InitWithGrid(name, description, rules string, generation, neighborhoodType int, gr *grid.Grid) error {
 	g.name = name
 	g.description = description
+	g.metadata = nil
 	g.generation = generation
-	g.SetRules(rules)
-	g.neighborhoodType = neighborhoodType
-	g.neighborhoodFunc = neighborhood.GetFunc(g.neighborhoodType)
 	g.grid = gr
 	g.processes = CPUS
 	g.threadPoolSize = DefaultThreadPoolSize
+	g.engine = GridEngine
+	g.forgetChangedCells()
+	g.backGrid = nil
+	return g.initRules(rules, neighborhoodType)
+}
+
+// initRules : set the neighborhood type and the rules
+// of a Game of Life instance that is being initialized
+func (g *Gol) initRules(rulestring string, neighborhoodType int) error {
+	neighborhoodFunc, neighborhoodError := neighborhood.GetFunc(neighborhoodType)
+	if neighborhoodError != nil {
+		return neighborhoodError
+	}
+	g.neighborhoodType = neighborhoodType
+	g.neighborhoodFunc = neighborhoodFunc
+	g.neighborhoodWeights = neighborhood.GetWeights(g.neighborhoodType)
+	return g.SetRules(rulestring)
 }
 
 // Name : return the name of this Game of life instance
@@ -72,38 +119,83 @@ func (g *Gol) Description() string {
 	return g.description
 }
 
+// Metadata : return the value of a custom metadata
+// key, or an empty string if it is not set
+func (g *Gol) Metadata(key string) string {
+	return g.metadata[key]
+}
+
+// MetadataKeys : return the custom metadata keys in alphabetical order
+func (g *Gol) MetadataKeys() []string {
+	keys := make([]string, 0, len(g.metadata))
+	for key := range g.metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SetMetadata : set the value of a custom metadata key
+// (e.g. author, tags or source), removing it if it is empty
+func (g *Gol) SetMetadata(key, value string) {
+	metadata := make(map[string]string, len(g.metadata)+1)
+	for metadataKey, metadataValue := range g.metadata {
+		metadata[metadataKey] = metadataValue
+	}
+	if value == "" {
+		delete(metadata, key)
+	} else {
+		metadata[key] = value
+	}
+	g.metadata = metadata
+}
+
 // Rules : return the rules of the game of life
-// as a string with the survival/birth format.
-// See https://www.conwaylife.com/wiki/Life_1.05
+// as a canonical string in B/S notation (e.g. "B3/S23"),
+// with a suffix for non-Moore neighborhoods (e.g. "B3/S23V").
+// See https://www.conwaylife.com/wiki/Rulestring
 func (g *Gol) Rules() string {
-	return g.rules
-}
-
-// SetRules : set rules according with the
-// survival/birth format.
-// See https://www.conwaylife.com/wiki/Life_1.05
-func (g *Gol) SetRules(rules string) {
-	g.rules = rules
-	rulesParts := strings.Split(rules, "/")
-	// TODO: put common code in function
-	g.survivalRule = make(map[int]bool)
-	survivalRule := rulesParts[0]
-	for i := 0; i < len(survivalRule); i++ {
-		sr, srErr := strconv.Atoi(survivalRule[i : i+1])
-		if srErr != nil {
-			panic(srErr.Error())
-		}
-		g.survivalRule[sr] = true
+	return g.rule.WithNeighborhood(g.neighborhoodType).String()
+}
+
+// SetRules : set rules according with any of the notations accepted
+// by rules.Parse (e.g. "B3/S23", "S23/B3", "23/3" or "B2-a/S12"). If the
+// rules have a neighborhood suffix, the neighborhood type is changed too.
+func (g *Gol) SetRules(rulestring string) error {
+	rule, ruleError := rules.ParseForNeighborhood(rulestring, g.neighborhoodType)
+	if ruleError != nil {
+		return ruleError
 	}
-	g.birthRule = make(map[int]bool)
-	birthRule := rulesParts[1]
-	for i := 0; i < len(birthRule); i++ {
-		br, brErr := strconv.Atoi(birthRule[i : i+1])
-		if brErr != nil {
-			panic(brErr.Error())
-		}
-		g.birthRule[br] = true
+	ruleNeighborhood := rule.Neighborhood()
+	if !rule.IsTotalistic() && ruleNeighborhood == neighborhood.NONE && g.neighborhoodType != neighborhood.MOORE {
+		return fmt.Errorf(
+			"%w \"%s\": non-totalistic rules are only allowed for the Moore neighborhood",
+			rules.ErrInvalidRule, rulestring,
+		)
+	}
+	neighborhoodType := g.neighborhoodType
+	if ruleNeighborhood != neighborhood.NONE {
+		neighborhoodType = ruleNeighborhood
 	}
+	neighborhoodFunc, neighborhoodError := neighborhood.GetRangeFunc(neighborhoodType, rule.Radius())
+	if neighborhoodError != nil {
+		return fmt.Errorf("%w \"%s\": %s", rules.ErrInvalidRule, rulestring, neighborhoodError)
+	}
+	g.forgetChangedCells()
+	g.rule = rule
+	g.survivalRule = rule.Survival()
+	g.birthRule = rule.Birth()
+	g.neighborhoodType = neighborhoodType
+	g.neighborhoodFunc = neighborhoodFunc
+	g.neighborhoodWeights = neighborhood.GetWeights(g.neighborhoodType)
+	return nil
+}
+
+// States : return the number of states the cells can have according
+// with the rules. Generations rules have dying states from statuses.DYING
+// to States()-1, other rules only have ALIVE and DEAD states.
+func (g *Gol) States() int {
+	return g.rule.States()
 }
 
 // Generation : return the number of generations passed
@@ -123,18 +215,32 @@ func (g *Gol) NeighborhoodType() int {
 
 // NeighborhoodTypeString : return the neighborhood type (as string)
 func (g *Gol) NeighborhoodTypeString() string {
-	return neighborhood.StringFromType(g.neighborhoodType)
+	// The neighborhood type is always a valid one
+	neighborhoodTypeString, _ := neighborhood.StringFromType(g.neighborhoodType)
+	return neighborhoodTypeString
 }
 
-// SetNeighborhoodType : return the neighborhood type
-func (g *Gol) SetNeighborhoodType(neighborhoodType int) {
-	neighborhood.AssertType(neighborhoodType)
+// SetNeighborhoodType : set the neighborhood type, or return an error
+// if it is not known or it cannot have the radius of the rules
+func (g *Gol) SetNeighborhoodType(neighborhoodType int) error {
+	neighborhoodFunc, neighborhoodError := neighborhood.GetRangeFunc(neighborhoodType, g.rule.Radius())
+	if neighborhoodError != nil {
+		return neighborhoodError
+	}
+	g.forgetChangedCells()
 	g.neighborhoodType = neighborhoodType
+	g.neighborhoodFunc = neighborhoodFunc
+	g.neighborhoodWeights = neighborhood.GetWeights(g.neighborhoodType)
+	return nil
 }
 
-// SetNeighborhoodTypeString : return the neighborhood type (as string)
-func (g *Gol) SetNeighborhoodTypeString(neighborhoodType string) {
-	g.neighborhoodType = neighborhood.TypeFromString(neighborhoodType)
+// SetNeighborhoodTypeString : set the neighborhood type (as string)
+func (g *Gol) SetNeighborhoodTypeString(neighborhoodType string) error {
+	neighborhoodTypeCode, neighborhoodError := neighborhood.TypeFromString(neighborhoodType)
+	if neighborhoodError != nil {
+		return neighborhoodError
+	}
+	return g.SetNeighborhoodType(neighborhoodTypeCode)
 }
 
 // Rows : return the number of rows of the grid
@@ -154,6 +260,8 @@ func (g *Gol) LimitRows() bool {
 
 // SetLimitRows : set if rows are limited or isn't
 func (g *Gol) SetLimitRows(limitRows bool) {
+	g.forgetChangedCells()
+	g.backGrid = nil
 	g.grid.SetLimitRows(limitRows)
 }
 
@@ -164,23 +272,84 @@ func (g *Gol) LimitCols() bool {
 
 // SetLimitCols : set if cols are limited or isn't
 func (g *Gol) SetLimitCols(limitRows bool) {
+	g.forgetChangedCells()
+	g.backGrid = nil
 	g.grid.SetLimitCols(limitRows)
 }
 
+// GridType : return the type of the storage of the cells ("dense",
+// "dok" or "bitpacked"), or "unbounded" for unbounded grids
+func (g *Gol) GridType() string {
+	return g.grid.CellsStorerType()
+}
+
+// Unbounded : inform if the grid is unbounded, i.e. it grows
+// as the alive cells move outwards
+func (g *Gol) Unbounded() bool {
+	return g.grid.Unbounded()
+}
+
+// Origin : return the absolute coordinates of the top-left cell
+// of the grid. Bounded grids have always their origin in 0, 0.
+func (g *Gol) Origin() (int, int) {
+	return g.grid.Origin()
+}
+
+// SetOrigin : move an unbounded grid so its top-left
+// cell is in the absolute coordinates i, j
+func (g *Gol) SetOrigin(i, j int) error {
+	return g.grid.SetOrigin(i, j)
+}
+
 // Get : get the value of the cell (ALICE, DEAD)
 // in the i, j coordinates
 func (g *Gol) Get(i int, j int) int {
 	return g.grid.Get(i, j)
 }
 
-// Set : set the value of the cell in the i, j coordinates
-func (g *Gol) Set(i int, j int, value int) {
-	g.grid.Set(i, j, value)
+// Set : set the value of the cell in the i, j coordinates, or return
+// an error if the cell is out of the grid or the value is not one
+// of the states of the rules
+func (g *Gol) Set(i int, j int, value int) error {
+	if statusError := g.checkStatus(value); statusError != nil {
+		return statusError
+	}
+	g.forgetChangedCells()
+	return g.grid.Set(i, j, value)
 }
 
 // SetAll : set the value to all cells
-func (g *Gol) SetAll(value int) {
-	g.grid.SetAll(value)
+func (g *Gol) SetAll(value int) error {
+	if statusError := g.checkStatus(value); statusError != nil {
+		return statusError
+	}
+	g.forgetChangedCells()
+	return g.grid.SetAll(value)
+}
+
+// Population : return the number of alive cells
+func (g *Gol) Population() int {
+	population := 0
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			if g.Get(i, j) == statuses.ALIVE {
+				population++
+			}
+		}
+	}
+	return population
+}
+
+// checkStatus : return an error if the status is not one
+// of the states of the rules
+func (g *Gol) checkStatus(status int) error {
+	if status < statuses.DEAD || status >= g.States() {
+		return fmt.Errorf(
+			"%w: %d, expected a number between %d and %d",
+			ErrInvalidStatus, status, statuses.DEAD, g.States()-1,
+		)
+	}
+	return nil
 }
 
 // Equals : inform if two game of life instances have the same data
@@ -188,8 +357,9 @@ func (g *Gol) Equals(o base.GolInterface) bool {
 	other := o.(*Gol)
 	simpleAttributesAreEqual := g.name == other.name &&
 		g.description == other.description &&
+		reflect.DeepEqual(g.metadata, other.metadata) &&
 		g.generation == other.generation &&
-		g.rules == other.rules &&
+		g.Rules() == other.Rules() &&
 		g.neighborhoodType == other.neighborhoodType &&
 		g.processes == other.processes &&
 		g.threadPoolSize == other.threadPoolSize
@@ -217,8 +387,17 @@ func (g *Gol) EqualsError(o base.GolInterface) error {
 		return fmt.Errorf("Descriptions are different: \"%s\" vs \"%s\"", g.description, other.description)
 	}
 
-	if g.rules != other.rules {
-		return fmt.Errorf("Rules are different: %s vs %s", g.rules, other.rules)
+	if !reflect.DeepEqual(g.MetadataKeys(), other.MetadataKeys()) {
+		return fmt.Errorf("Metadata keys are different: %v vs %v", g.MetadataKeys(), other.MetadataKeys())
+	}
+	for _, key := range g.MetadataKeys() {
+		if g.Metadata(key) != other.Metadata(key) {
+			return fmt.Errorf("Metadata \"%s\" are different: \"%s\" vs \"%s\"", key, g.Metadata(key), other.Metadata(key))
+		}
+	}
+
+	if g.Rules() != other.Rules() {
+		return fmt.Errorf("Rules are different: %s vs %s", g.Rules(), other.Rules())
 	}
 
 	if g.generation != other.generation {
@@ -242,11 +421,10 @@ func (g *Gol) EqualsError(o base.GolInterface) error {
 
 // Clone : clone a game of life instance
 func (g *Gol) Clone() base.GolInterface {
-	clone := new(Gol)
-	clone.InitWithGrid(g.name, g.description, g.rules, g.generation, g.neighborhoodType, g.grid.Clone())
-	clone.SetProcesses(g.processes)
-	clone.SetThreadPoolSize(g.threadPoolSize)
-	return clone
+	clone := *g
+	clone.grid = g.grid.Clone()
+	clone.backGrid = nil
+	return &clone
 }
 
 // DbgStdout : show a matrix to ease debugging
@@ -261,14 +439,10 @@ func (g *Gol) DbgStdout() {
 	}
 }
 
-func (g *Gol) init(name, description, rules, gridType, rowsLimitation, colsLimitation string, rows, cols, generation, neighborhoodType int) {
-	g.name = name
-	g.description = description
-	g.grid = grid.NewGrid(rows, cols, rowsLimitation, colsLimitation, gridType)
-	g.SetRules(rules)
-	g.generation = generation
-	g.neighborhoodType = neighborhoodType
-	g.neighborhoodFunc = neighborhood.GetFunc(g.neighborhoodType)
-	g.processes = CPUS
-	g.threadPoolSize = DefaultThreadPoolSize
+func (g *Gol) init(name, description, rules, gridType, rowsLimitation, colsLimitation string, rows, cols, generation, neighborhoodType int) error {
+	gr, gridError := grid.NewGrid(rows, cols, rowsLimitation, colsLimitation, gridType)
+	if gridError != nil {
+		return gridError
+	}
+	return g.InitWithGrid(name, description, rules, generation, neighborhoodType, gr)
 }
@@ -1,18 +1,22 @@
 package gol
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"path/filepath"
 	"testing"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/grid"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 func TestNewGol(t *testing.T) {
-	g := NewGol("TestGol", "", "23/3", "dense", "limited", "limited", 0, 5, 5)
+	g, _ := NewGol("TestGol", "", "23/3", "dense", "limited", "limited", 0, 5, 5)
 	for i := 0; i < g.Rows(); i++ {
 		for j := 0; j < g.Cols(); j++ {
 			if g.Get(i, j) != statuses.DEAD {
@@ -22,6 +26,40 @@ func TestNewGol(t *testing.T) {
 	}
 }
 
+func TestNewGolErrors(t *testing.T) {
+	if _, ruleError := NewGol("TestGol", "", "B3/S2x", "dense", "limited", "limited", 5, 5, 0); !errors.Is(ruleError, rules.ErrInvalidRule) {
+		t.Errorf("Expecting an invalid rule error, found %v", ruleError)
+	}
+	if _, gridError := NewGol("TestGol", "", "23/3", "whatever", "limited", "limited", 5, 5, 0); !errors.Is(gridError, grid.ErrInvalidGridType) {
+		t.Errorf("Expecting an invalid grid type error, found %v", gridError)
+	}
+	if _, gridError := NewRandomGol("TestGol", "", "23/3", "whatever", "limited", "limited", 5, 5, int64(1)); !errors.Is(gridError, grid.ErrInvalidGridType) {
+		t.Errorf("Expecting an invalid grid type error, found %v", gridError)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	g, _ := NewGol("TestGol", "", "B2/S/C3", "dense", "limited", "limited", 5, 5, 0)
+	if setError := g.Set(5, 0, statuses.ALIVE); !errors.Is(setError, grid.ErrIndexOutOfRange) {
+		t.Errorf("Expecting an index out of range error, found %v", setError)
+	}
+	if setError := g.Set(0, 0, 3); !errors.Is(setError, ErrInvalidStatus) {
+		t.Errorf("Expecting an invalid status error, found %v", setError)
+	}
+	if setError := g.SetAll(statuses.VOID); !errors.Is(setError, ErrInvalidStatus) {
+		t.Errorf("Expecting an invalid status error, found %v", setError)
+	}
+	if setError := g.Set(0, 0, 2); setError != nil {
+		t.Errorf("Dying cells should be accepted, found %v", setError)
+	}
+	if neighborhoodError := g.SetNeighborhoodType(neighborhood.NONE); !errors.Is(neighborhoodError, neighborhood.ErrInvalidNeighborhood) {
+		t.Errorf("Expecting an invalid neighborhood error, found %v", neighborhoodError)
+	}
+	if neighborhoodError := g.SetNeighborhoodTypeString("Square"); !errors.Is(neighborhoodError, neighborhood.ErrInvalidNeighborhood) {
+		t.Errorf("Expecting an invalid neighborhood error, found %v", neighborhoodError)
+	}
+}
+
 func TestEquals(t *testing.T) {
 	g1, g1ReadError := readCongolwayFile("10x10.txt")
 	if g1ReadError != nil {
@@ -77,3 +115,201 @@ func readCongolwayFile(filename string) (base.GolInterface, error) {
 	}
 	return g, nil
 }
+
+func TestMetadata(t *testing.T) {
+	g, _ := NewGol("Glider", "", "B3/S23", "dok", "limited", "limited", 3, 3, 0)
+	g.SetMetadata("tags", "spaceship")
+	g.SetMetadata("author", "Richard K. Guy")
+	clone := g.Clone()
+	g.SetMetadata("tags", "")
+	if keys := g.MetadataKeys(); len(keys) != 1 || keys[0] != "author" || g.Metadata("tags") != "" {
+		t.Errorf("Empty metadata should be removed, found %v", keys)
+	}
+	if clone.Metadata("tags") != "spaceship" {
+		t.Errorf("The metadata of the clone should not change, found \"%s\"", clone.Metadata("tags"))
+	}
+	if g.Equals(clone) || g.EqualsError(clone) == nil {
+		t.Errorf("Instances with different metadata should not be equal")
+	}
+	nextG := g.NextGeneration()
+	if nextG.Metadata("author") != "Richard K. Guy" {
+		t.Errorf("The metadata should be kept by the next generation")
+	}
+}
+
+func TestSetRules(t *testing.T) {
+	g, _ := NewGol("TestGol", "", "S23/B36", "dense", "limited", "limited", 5, 5, 0)
+	if g.Rules() != "B36/S23" {
+		t.Errorf("Rules should be B36/S23, but they are %s", g.Rules())
+	}
+
+	if err := g.SetRules("b1/s4v"); err != nil {
+		t.Error(err)
+		return
+	}
+	if g.Rules() != "B1/S4V" {
+		t.Errorf("Rules should be B1/S4V, but they are %s", g.Rules())
+	}
+	if g.NeighborhoodType() != neighborhood.VONNEUMANN {
+		t.Errorf("The V suffix should set the Von Neumann neighborhood")
+	}
+
+	if err := g.SetRules("23/3"); err != nil {
+		t.Error(err)
+		return
+	}
+	if g.Rules() != "B3/S23V" {
+		t.Errorf("Rules without suffix should keep the neighborhood, but they are %s", g.Rules())
+	}
+
+	for _, invalidRules := range []string{"23-3", "B3/S2x", "B7/S23H"} {
+		if err := g.SetRules(invalidRules); err == nil {
+			t.Errorf("Rules %s should have been rejected", invalidRules)
+		}
+	}
+	if g.Rules() != "B3/S23V" {
+		t.Errorf("Invalid rules should not change the rules, but they are %s", g.Rules())
+	}
+}
+
+func TestNonTotalisticRules(t *testing.T) {
+	for _, engine := range []string{GridEngine, HashLifeEngine} {
+		// Only the opposite corners configuration (2n) makes the center cell be born
+		g, _ := NewGol("TestGol", "", "B2n/S", "dok", "limited", "limited", 5, 5, 0)
+		g.SetEngine(engine)
+		g.Set(1, 1, statuses.ALIVE)
+		g.Set(3, 3, statuses.ALIVE)
+		next := g.NextGeneration()
+		for i := 0; i < next.Rows(); i++ {
+			for j := 0; j < next.Cols(); j++ {
+				expected := statuses.DEAD
+				if i == 2 && j == 2 {
+					expected = statuses.ALIVE
+				}
+				if next.Get(i, j) != expected {
+					t.Errorf("Cell %d,%d should be %d with %s engine, but it is %d", i, j, expected, engine, next.Get(i, j))
+				}
+			}
+		}
+
+		if err := g.SetRules("B2a/S"); err != nil {
+			t.Error(err)
+			return
+		}
+		next = g.NextGeneration()
+		if next.Get(2, 2) != statuses.DEAD {
+			t.Errorf("The 2n configuration should not make the cell be born with B2a/S rule")
+		}
+	}
+
+	g, _ := NewGol("TestGol", "", "B3/S23V", "dok", "limited", "limited", 5, 5, 0)
+	if err := g.SetRules("B2a/S"); err == nil {
+		t.Errorf("Non-totalistic rules should not be allowed for Von Neumann neighborhood")
+	}
+}
+
+func TestGenerationsRules(t *testing.T) {
+	// Brian's Brain: alive cells never survive and are dying for one generation
+	g, _ := NewGol("TestGol", "", "B2/S/C3", "dense", "limited", "limited", 3, 6, 0)
+	if g.States() != 3 {
+		t.Errorf("Brian's Brain cells should have 3 states, found %d", g.States())
+	}
+	g.Set(1, 2, statuses.ALIVE)
+	g.Set(1, 3, statuses.ALIVE)
+
+	expectedGenerations := [][][]int{
+		{
+			{0, 0, 1, 1, 0, 0},
+			{0, 0, 2, 2, 0, 0},
+			{0, 0, 1, 1, 0, 0},
+		},
+		{
+			{0, 0, 2, 2, 0, 0},
+			{0, 1, 0, 0, 1, 0},
+			{0, 0, 2, 2, 0, 0},
+		},
+	}
+	var next base.GolInterface = g
+	for generation, expectedCells := range expectedGenerations {
+		next = next.NextGeneration()
+		for i := 0; i < next.Rows(); i++ {
+			for j := 0; j < next.Cols(); j++ {
+				if next.Get(i, j) != expectedCells[i][j] {
+					t.Errorf("Cell %d,%d of generation %d should be %d, but it is %d",
+						i, j, generation+1, expectedCells[i][j], next.Get(i, j))
+				}
+			}
+		}
+	}
+}
+
+func TestHexagonalRules(t *testing.T) {
+	g, _ := NewGol("TestGol", "", "B2/S34H", "dense", "limited", "limited", 5, 5, 0)
+	if g.NeighborhoodType() != neighborhood.HEXAGONAL {
+		t.Errorf("The H suffix should set the hexagonal neighborhood, found %s", g.NeighborhoodTypeString())
+	}
+	g.Set(2, 2, statuses.ALIVE)
+	g.Set(2, 3, statuses.ALIVE)
+
+	// Only the two hexagons that touch both alive cells are born
+	next := g.NextGeneration()
+	for i := 0; i < next.Rows(); i++ {
+		for j := 0; j < next.Cols(); j++ {
+			expected := statuses.DEAD
+			if (i == 1 || i == 3) && j == 2 {
+				expected = statuses.ALIVE
+			}
+			if next.Get(i, j) != expected {
+				t.Errorf("Cell %d,%d should be %d, but it is %d", i, j, expected, next.Get(i, j))
+			}
+		}
+	}
+}
+
+func TestTriangularRules(t *testing.T) {
+	g, _ := NewGol("TestGol", "", "B3/S23", "dense", "limited", "limited", 5, 5, 0)
+	g.SetNeighborhoodType(neighborhood.TRIANGULAR)
+	if rulesError := g.SetRules("B9/S23"); rulesError != nil {
+		t.Errorf("Triangular cells can have up to 12 neighbors: %s", rulesError)
+	}
+	if g.Rules() != "B9/S23" {
+		t.Errorf("The rules should be B9/S23, but they are %s", g.Rules())
+	}
+	moore, _ := NewGol("TestGol", "", "B3/S23", "dense", "limited", "limited", 5, 5, 0)
+	if moore.SetRules("B9/S23") == nil {
+		t.Errorf("Moore cells cannot have 9 neighbors")
+	}
+}
+
+func TestCustomNeighborhood(t *testing.T) {
+	mask, _ := neighborhood.ParseMask("1,2,1;2,0,2;1,2,1")
+	neighborhoodType := neighborhood.RegisterMask(mask)
+	g, _ := NewGol("TestGol", "", "B4/S", "dense", "limited", "limited", 5, 5, 0)
+	g.SetNeighborhoodType(neighborhoodType)
+	g.Set(1, 2, statuses.ALIVE)
+	g.Set(3, 2, statuses.ALIVE)
+
+	clone := g.Clone().(*Gol)
+	if clone.NeighborhoodType() != neighborhoodType || !clone.Equals(g) {
+		t.Errorf("The clone should have the custom neighborhood, found %s", clone.NeighborhoodTypeString())
+	}
+	moore := g.Clone().(*Gol)
+	moore.SetNeighborhoodType(neighborhood.MOORE)
+	if moore.Equals(g) {
+		t.Errorf("Game of life instances with different neighborhoods should not be equal")
+	}
+
+	// Only the cell between both alive ones has a weighted count of 4
+	next := g.NextGeneration()
+	for i := 0; i < next.Rows(); i++ {
+		for j := 0; j < next.Cols(); j++ {
+			expected := statuses.DEAD
+			if i == 2 && j == 2 {
+				expected = statuses.ALIVE
+			}
+			if next.Get(i, j) != expected {
+				t.Errorf("Cell %d,%d should be %d, but it is %d", i, j, expected, next.Get(i, j))
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package gol
+
+import (
+	"fmt"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/hashlife"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// GridEngine : the next generations are computed by visiting
+// every cell of the grid.
+const GridEngine = "grid"
+
+// HashLifeEngine : the next generations are computed by using
+// the HashLife algorithm, that allows jumping over an exponential
+// number of generations. HashLife simulates an unbounded plane, so it is
+// only used in unbounded grids (that grow to contain every alive cell).
+// Only totalistic rules with two states and Moore neighborhood (of
+// radius 1) are supported. Other rules, and limited or toroidal grids,
+// fall back to the grid engine.
+// See https://www.conwaylife.com/wiki/HashLife
+const HashLifeEngine = "hashlife"
+
+// Engine : return the engine used to compute the next generations
+func (g *Gol) Engine() string {
+	return g.engine
+}
+
+// SetEngine : set the engine used to compute the next generations.
+// Take account the constants GridEngine and HashLifeEngine of this package.
+func (g *Gol) SetEngine(engine string) error {
+	if engine != GridEngine && engine != HashLifeEngine {
+		return fmt.Errorf(
+			"%w %s, expected \"%s\" or \"%s\"",
+			ErrInvalidEngine, engine, GridEngine, HashLifeEngine,
+		)
+	}
+	g.engine = engine
+	return nil
+}
+
+// UsesHashLife : inform if the next generations are computed by the
+// HashLife engine (see HashLifeEngine). It is only used in unbounded grids
+// of two-state totalistic rules with the Moore neighborhood, so the grid
+// engine is used in the rest of them even if the HashLife engine is set.
+func (g *Gol) UsesHashLife() bool {
+	return g.engine == HashLifeEngine && g.grid.Unbounded() && g.neighborhoodType == neighborhood.MOORE &&
+		g.rule.IsTotalistic() && g.rule.States() == 2 && !g.rule.IsLargerThanLife()
+}
+
+// hashLifeFastForward : move forward a number of generations
+// by using the HashLife algorithm
+func hashLifeFastForward(g *Gol, generations int) base.GolInterface {
+	universe := g.hashLifeUniverse()
+	universe.Advance(generations)
+	return g.hashLifeGeneration(universe, generations)
+}
+
+// hashLifeUniverse : return a HashLife universe with the alive cells
+func (g *Gol) hashLifeUniverse() *hashlife.Universe {
+	rows := g.Rows()
+	cols := g.Cols()
+	originI, originJ := g.grid.Origin()
+
+	universe := hashlife.NewUniverse(g.survivalRule, g.birthRule)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if g.Get(i, j) == statuses.ALIVE {
+				universe.Set(originI+i, originJ+j, statuses.ALIVE)
+			}
+		}
+	}
+	return universe
+}
+
+// hashLifeGeneration : return the generation that is a number of
+// generations after the current one, with the alive cells of the
+// universe (created by hashLifeUniverse and advanced that many generations)
+func (g *Gol) hashLifeGeneration(universe *hashlife.Universe, generations int) *Gol {
+	rows := g.Rows()
+	cols := g.Cols()
+	originI, originJ := g.grid.Origin()
+
+	// Grow the grid so every alive cell of the universe fits in it
+	nextG := g.copyWithEmptyGrid().(*Gol)
+	minI, minJ := originI, originJ
+	maxI, maxJ := originI+rows-1, originJ+cols-1
+	universe.Each(func(i, j int) {
+		minI = utils.MinInt(minI, i)
+		minJ = utils.MinInt(minJ, j)
+		maxI = utils.MaxInt(maxI, i)
+		maxJ = utils.MaxInt(maxJ, j)
+	})
+	nextG.grid.Grow(originI-minI, originJ-minJ, maxI-(originI+rows-1), maxJ-(originJ+cols-1))
+	universe.Each(func(i, j int) {
+		nextG.Set(i-minI, j-minJ, statuses.ALIVE)
+	})
+	nextG.grid.Fit()
+	nextG.generation += generations
+	return nextG
+}
@@ -0,0 +1,126 @@
+package gol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestHashLifeFastForward(t *testing.T) {
+	g, _ := NewGol("Glider", "", "23/3", "dok", "unbounded", "unbounded", 64, 64, 0)
+	glider := [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}}
+	for _, cell := range glider {
+		g.Set(cell[0]+10, cell[1]+10, statuses.ALIVE)
+	}
+
+	for _, generations := range []int{1, 2, 7, 40, 123} {
+		expectedG := g.FastForward(generations)
+
+		hlg := g.Clone().(*Gol)
+		hlg.SetEngine(HashLifeEngine)
+		actualG := hlg.FastForward(generations)
+
+		if actualG.Generation() != expectedG.Generation() {
+			t.Errorf("Generations are different: %d vs %d", actualG.Generation(), expectedG.Generation())
+		}
+		if !actualG.GridEquals(expectedG, "values") {
+			t.Errorf("HashLife and grid engines differ after %d generations", generations)
+		}
+		if actualG.Engine() != HashLifeEngine {
+			t.Errorf("Engine should be kept, found %s", actualG.Engine())
+		}
+	}
+}
+
+func TestHashLifeNextGeneration(t *testing.T) {
+	for _, oscilator := range []string{"blinker", "beacon", "toad"} {
+		g0, g0ReadError := readCongolwayFile("oscilators/" + oscilator + "/gen_0.txt")
+		if g0ReadError != nil {
+			t.Error(g0ReadError)
+			return
+		}
+		g1, g1ReadError := readCongolwayFile("oscilators/" + oscilator + "/gen_1.txt")
+		if g1ReadError != nil {
+			t.Error(g1ReadError)
+			return
+		}
+		// HashLife is only used in unbounded grids
+		unboundedG0 := unboundedCopy(g0.(*Gol))
+		expectedG := unboundedCopy(g1.(*Gol))
+		unboundedG0.SetEngine(HashLifeEngine)
+		if !unboundedG0.NextGeneration().GridEquals(expectedG, "values") {
+			t.Errorf("%s next generation computed with HashLife is wrong", oscilator)
+		}
+	}
+}
+
+func TestHashLifeBoundedGrids(t *testing.T) {
+	// The glider reaches the edges of the grid, where it wraps
+	// around in toroidal grids and dies in limited ones
+	for _, limitation := range []string{"unlimited", "limited"} {
+		g, _ := NewGol("Glider", "", "23/3", "dok", limitation, limitation, 8, 8, 0)
+		for _, cell := range [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}} {
+			g.Set(cell[0], cell[1], statuses.ALIVE)
+		}
+		hlg := g.Clone().(*Gol)
+		hlg.SetEngine(HashLifeEngine)
+		for _, generations := range []int{1, 20, 33, 64} {
+			expectedG := g.FastForward(generations)
+			actualG := hlg.FastForward(generations)
+			if !actualG.GridEquals(expectedG, "values") {
+				t.Errorf("%s: HashLife and grid engines differ after %d generations", limitation, generations)
+			}
+			steppedG := hlg.Clone().(*Gol)
+			steppedG.StepN(generations)
+			if !steppedG.GridEquals(expectedG, "values") {
+				t.Errorf("%s: HashLife and grid engines differ after %d steps", limitation, generations)
+			}
+		}
+	}
+}
+
+// unboundedCopy : return a copy of a game of life instance in an unbounded grid
+func unboundedCopy(g *Gol) *Gol {
+	unboundedG, _ := NewGol(g.Name(), g.Description(), g.Rules(), "dok", "unbounded", "unbounded", g.Rows(), g.Cols(), g.Generation())
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			unboundedG.Set(i, j, g.Get(i, j))
+		}
+	}
+	unboundedG.grid.Fit()
+	return unboundedG
+}
+
+func TestSetEngine(t *testing.T) {
+	g, _ := NewGol("TestGol", "", "23/3", "dense", "limited", "limited", 5, 5, 0)
+	if g.Engine() != GridEngine {
+		t.Errorf("Default engine should be %s, found %s", GridEngine, g.Engine())
+	}
+	engineError := g.SetEngine("whatever")
+	if !errors.Is(engineError, ErrInvalidEngine) {
+		t.Errorf("Expecting an invalid engine error, found %v", engineError)
+		return
+	}
+	if engineError.Error() != "Invalid engine whatever, expected \"grid\" or \"hashlife\"" {
+		t.Errorf("Wrong error message: %s", engineError)
+	}
+	if g.Engine() != GridEngine {
+		t.Errorf("Invalid engines should not change the engine, found %s", g.Engine())
+	}
+
+	// The HashLife engine is only used in unbounded grids
+	g.SetEngine(HashLifeEngine)
+	if g.UsesHashLife() {
+		t.Errorf("The HashLife engine should not be used in limited grids")
+	}
+	unboundedG, _ := NewGol("TestGol", "", "23/3", "dok", "unbounded", "unbounded", 5, 5, 0)
+	unboundedG.SetEngine(HashLifeEngine)
+	if !unboundedG.UsesHashLife() {
+		t.Errorf("The HashLife engine should be used in unbounded grids")
+	}
+	unboundedG.SetEngine(GridEngine)
+	if unboundedG.UsesHashLife() {
+		t.Errorf("The HashLife engine should not be used once the grid engine is set")
+	}
+}
@@ -0,0 +1,44 @@
+package gol
+
+import (
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// largerThanLifeNextGeneration : compute the next generation of a
+// Larger than Life rule. The alive neighbors of every cell are counted
+// at once by a neighborhood.RangeCounter, as visiting each one of the
+// neighbors of a large radius would be too slow.
+func largerThanLifeNextGeneration(g *Gol) base.GolInterface {
+	nextG, padding := g.emptyNextGeneration()
+
+	rows := nextG.Rows()
+	cols := nextG.Cols()
+	// The radius of the rules was checked when they were set
+	counter, _ := neighborhood.NewRangeCounter(
+		g, -padding, -padding, rows, cols, g.neighborhoodType, g.rule.Radius(), statuses.ALIVE,
+	)
+
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			cellValue := g.nextLargerThanLifeCell(i-padding, j-padding, counter)
+			nextG.Set(i, j, cellValue)
+		}
+	}
+	nextG.grid.Fit()
+	nextG.generation++
+	return nextG
+}
+
+func (g *Gol) nextLargerThanLifeCell(i int, j int, counter *neighborhood.RangeCounter) int {
+	status := g.Get(i, j)
+	if status != statuses.ALIVE && status != statuses.DEAD {
+		return g.nextDyingStatus(status)
+	}
+	aliveCount := counter.Count(i, j)
+	if status == statuses.ALIVE && g.rule.IncludesMiddle() {
+		aliveCount++
+	}
+	return g.nextStatus(status, g.survivalRule[aliveCount], g.birthRule[aliveCount])
+}
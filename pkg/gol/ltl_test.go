@@ -0,0 +1,82 @@
+package gol
+
+import (
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestLargerThanLifeOfRadius1(t *testing.T) {
+	// Larger than Life rules of radius 1 are the classic ones
+	equivalentRules := map[string]string{
+		"B3/S23":    "R1,C0,M0,S2..3,B3..3,NM",
+		"B2/S34V":   "R1,C0,M0,S3..4,B2..2,NN",
+		"B3/S23/C3": "R1,C3,M1,S3..4,B3..3,NM",
+	}
+	for rules, largerThanLifeRules := range equivalentRules {
+		for _, limitation := range []string{"limited", "unlimited"} {
+			g, _ := NewRandomGol("TestGol", "", rules, "dense", limitation, limitation, 20, 30, int64(1))
+			ltlG := g.Clone().(*Gol)
+			if rulesError := ltlG.SetRules(largerThanLifeRules); rulesError != nil {
+				t.Error(rulesError)
+				return
+			}
+			for generation := 1; generation <= 5; generation++ {
+				g = g.NextGeneration().(*Gol)
+				ltlG = ltlG.NextGeneration().(*Gol)
+				if !g.GridEquals(ltlG, "values") {
+					t.Errorf("Rules %s and %s should be equivalent in %s grids, generation %d differs",
+						rules, largerThanLifeRules, limitation, generation)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestLargerThanLife(t *testing.T) {
+	g, _ := NewRandomGol("Bosco", "", "R5,C0,M1,S34..58,B34..45,NM", "dense", "unlimited", "unlimited", 30, 30, int64(1))
+	if g.Rules() != "R5,C0,M1,S34..58,B34..45,NM" {
+		t.Errorf("The rules should be R5,C0,M1,S34..58,B34..45,NM, found %s", g.Rules())
+	}
+
+	// Compare with the count of every neighbor of each cell
+	neighborhoodFunc, _ := neighborhood.GetRangeFunc(neighborhood.MOORE, 5)
+	next := g.NextGeneration().(*Gol)
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			count := neighborhood.NeighborsCount(g, i, j, statuses.ALIVE, neighborhoodFunc, nil)
+			expected := statuses.DEAD
+			if g.Get(i, j) == statuses.ALIVE && count+1 >= 34 && count+1 <= 58 {
+				expected = statuses.ALIVE
+			} else if g.Get(i, j) == statuses.DEAD && count >= 34 && count <= 45 {
+				expected = statuses.ALIVE
+			}
+			if next.Get(i, j) != expected {
+				t.Errorf("Cell %d,%d should be %d, but it is %d", i, j, expected, next.Get(i, j))
+			}
+		}
+	}
+}
+
+func TestUnboundedLargerThanLife(t *testing.T) {
+	g, _ := NewGol("Block", "", "R2,C0,M0,S0..0,B1..20,NC", "dok", "unbounded", "unbounded", 3, 3, 0)
+	g.SetAll(statuses.ALIVE)
+	if g.NeighborhoodType() != neighborhood.CIRCULAR {
+		t.Errorf("The neighborhood should be circular, found %s", g.NeighborhoodTypeString())
+	}
+
+	// The cells at distance 2 are born, the block dies
+	next := g.NextGeneration().(*Gol)
+	if next.Rows() != 7 || next.Cols() != 7 {
+		t.Errorf("Grid should have grown to 7x7, found %dx%d", next.Rows(), next.Cols())
+	}
+	originI, originJ := next.Origin()
+	if originI != -2 || originJ != -2 {
+		t.Errorf("Origin should be -2,-2, found %d,%d", originI, originJ)
+	}
+	if next.Get(3, 3) != statuses.DEAD || next.Get(0, 3) != statuses.ALIVE || next.Get(0, 0) != statuses.DEAD {
+		t.Errorf("The block should have become a circle")
+	}
+}
@@ -0,0 +1,142 @@
+package gol
+
+import (
+	"context"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// RunOptions : options of a run of generations (see Run)
+type RunOptions struct {
+	// Start : number of generations computed before
+	// the first yielded one (0 to yield the current one first)
+	Start int
+	// Stride : number of generations between two yielded
+	// generations (every generation is yielded if it is less than 2)
+	Stride int
+	// MaxGenerations : maximum number of yielded generations (no maximum if 0)
+	MaxGenerations int
+	// StopWhenStable : stop after yielding a generation
+	// that is equal to the previous one
+	StopWhenStable bool
+	// StopWhenExtinct : stop after yielding a generation without alive cells
+	StopWhenExtinct bool
+	// Progress : function called (if not nil) after each computed generation
+	// (or jump of the HashLife engine). The total of the run is the number of
+	// generations until the last yielded one, or 0 if there is no maximum.
+	Progress base.ProgressFunc
+}
+
+// Run : call the yield function with successive generations of the game of
+// life instance, until it returns false, the maximum number of generations is
+// yielded or a stop condition holds. The generations are computed in place
+// (see StepN) in a clone of the instance, so the same instance is yielded
+// every time (clone it to keep a generation) and its changed cells are the
+// ones of the last computed generation (see ChangedCells). If the context is
+// done, the run stops, leaving the yielded instance in the last computed
+// generation, and the error of the context is returned.
+func (g *Gol) Run(ctx context.Context, options RunOptions, yield func(g *Gol) bool) error {
+	runG := g.Clone().(*Gol)
+	stride := utils.MaxInt(1, options.Stride)
+	computed := 0
+	var tracker *base.ProgressTracker
+	var report func(stepsComputed int)
+	if options.Progress != nil {
+		total := 0
+		if options.MaxGenerations > 0 {
+			total = options.Start + (options.MaxGenerations-1)*stride
+		}
+		tracker = base.NewProgressTracker(total, options.Progress)
+		report = func(stepsComputed int) {
+			tracker.Report(runG, computed+stepsComputed)
+		}
+	}
+	steps, release := runG.runStepsFunc(ctx, report)
+	defer release()
+	advance := func(generations int) error {
+		if stepsError := steps(generations); stepsError != nil {
+			return stepsError
+		}
+		computed += generations
+		return nil
+	}
+
+	if startError := advance(options.Start); startError != nil {
+		return startError
+	}
+	stable := false
+	for yielded := 1; ; yielded++ {
+		if ctxError := ctx.Err(); ctxError != nil {
+			return ctxError
+		}
+		if !yield(runG) || yielded == options.MaxGenerations {
+			return nil
+		}
+		if (options.StopWhenStable && stable) || (options.StopWhenExtinct && runG.Population() == 0) {
+			if tracker != nil {
+				tracker.End(runG, computed)
+			}
+			return nil
+		}
+		if !options.StopWhenStable {
+			if stepsError := advance(stride); stepsError != nil {
+				return stepsError
+			}
+			continue
+		}
+		// The last generation is compared with the previous one
+		if stepsError := advance(stride - 1); stepsError != nil {
+			return stepsError
+		}
+		var previousG *Gol
+		if !runG.computesChangedCells() {
+			previousG = runG.Clone().(*Gol)
+		}
+		if stepsError := advance(1); stepsError != nil {
+			return stepsError
+		}
+		if previousG != nil {
+			stable = runG.GridEquals(previousG, "values")
+		} else {
+			changedCells, _ := runG.ChangedCells()
+			stable = len(changedCells) == 0
+		}
+	}
+}
+
+// Generations : return a channel where successive generations of the game
+// of life instance are sent (see Run). Each one of them is a clone, so the
+// receiver can keep it. The channel is closed when the run stops, so
+// cancel the context to stop receiving generations before that.
+func (g *Gol) Generations(ctx context.Context, options RunOptions) <-chan *Gol {
+	generations := make(chan *Gol)
+	go func() {
+		defer close(generations)
+		g.Run(ctx, options, func(runG *Gol) bool {
+			select {
+			case generations <- runG.Clone().(*Gol):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return generations
+}
+
+// runStepsFunc : return the function that computes a number of generations
+// in place (reusing the workers of the parallel engine in all its calls),
+// calling the report function as stepN does, and the one that releases
+// its resources
+func (g *Gol) runStepsFunc(ctx context.Context, report func(computed int)) (func(generations int) error, func()) {
+	if g.UsesHashLife() {
+		return func(generations int) error {
+			return g.stepN(ctx, generations, report)
+		}, func() {}
+	}
+	stepFunc, release := g.stepFunc()
+	return func(generations int) error {
+		return stepGenerations(ctx, stepFunc, generations, report)
+	}, release
+}
@@ -0,0 +1,163 @@
+package gol
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestRun(t *testing.T) {
+	grids := map[string]*Gol{}
+	grids["dense"], _ = NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 30, 40, int64(42))
+	grids["bitpacked"], _ = NewRandomGol("Random", "", "B3/S23", "bitpacked", "unlimited", "unlimited", 20, 70, int64(5))
+	grids["unbounded"], _ = NewGol("Glider", "", "B3/S23", "dok", "unbounded", "unbounded", 3, 3, 0)
+	for _, cell := range [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}} {
+		grids["unbounded"].Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	grids["hashlife"] = grids["unbounded"].Clone().(*Gol)
+	grids["hashlife"].SetEngine(HashLifeEngine)
+
+	for name, g := range grids {
+		for _, processes := range []int{SERIAL, 3} {
+			g.SetProcesses(processes)
+			initialG := g.Clone()
+			expectedG := g.FastForward(5)
+			yielded := 0
+			var progresses []base.Progress
+			runError := g.Run(context.Background(), RunOptions{
+				Start: 5, Stride: 3, MaxGenerations: 4,
+				Progress: func(progress base.Progress) { progresses = append(progresses, progress) },
+			}, func(runG *Gol) bool {
+				if !runG.GridEquals(expectedG, "values") || runG.Generation() != expectedG.Generation() {
+					t.Errorf("%s: the generation %d should be the one that is %d generations after the start",
+						name, runG.Generation(), 5+3*yielded)
+				}
+				expectedG = expectedG.FastForward(3)
+				yielded++
+				return true
+			})
+			if runError != nil {
+				t.Errorf("%s: %s", name, runError)
+			}
+			if yielded != 4 {
+				t.Errorf("%s: 4 generations should have been yielded, found %d", name, yielded)
+			}
+			if len(progresses) == 0 || progresses[len(progresses)-1].Computed != 14 || progresses[len(progresses)-1].Total != 14 {
+				t.Errorf("%s: the last progress should be the one of the 14 computed generations, found %v", name, progresses)
+			}
+			if !g.Equals(initialG) {
+				t.Errorf("%s: the run should not change the game of life instance", name)
+			}
+		}
+	}
+}
+
+func TestRunStop(t *testing.T) {
+	// A block is stable from the start, and so it is the generation after it
+	block, _ := NewGol("Block", "", "B3/S23", "dense", "limited", "limited", 4, 4, 0)
+	block.Set(1, 1, statuses.ALIVE)
+	block.Set(1, 2, statuses.ALIVE)
+	block.Set(2, 1, statuses.ALIVE)
+	block.Set(2, 2, statuses.ALIVE)
+	// A domino is extinct after two generations
+	domino, _ := NewGol("Domino", "", "B3/S23", "dense", "limited", "limited", 4, 4, 0)
+	domino.Set(1, 1, statuses.ALIVE)
+	domino.Set(1, 2, statuses.ALIVE)
+	blinker, _ := NewGol("Blinker", "", "B3/S23", "dense", "limited", "limited", 5, 5, 0)
+	blinker.Set(2, 1, statuses.ALIVE)
+	blinker.Set(2, 2, statuses.ALIVE)
+	blinker.Set(2, 3, statuses.ALIVE)
+
+	bitpackedBlock, _ := NewGol("Block", "", "B3/S23", "bitpacked", "limited", "limited", 4, 4, 0)
+	for i := 1; i <= 2; i++ {
+		for j := 1; j <= 2; j++ {
+			bitpackedBlock.Set(i, j, statuses.ALIVE)
+		}
+	}
+	cases := []struct {
+		name                string
+		g                   *Gol
+		options             RunOptions
+		expectedGenerations []int
+	}{
+		{"stable block", block, RunOptions{StopWhenStable: true, MaxGenerations: 10}, []int{0, 1}},
+		{"stable bitpacked block", bitpackedBlock, RunOptions{StopWhenStable: true, MaxGenerations: 10}, []int{0, 1}},
+		{"extinct block", block, RunOptions{StopWhenExtinct: true, MaxGenerations: 3}, []int{0, 1, 2}},
+		{"extinct domino", domino, RunOptions{StopWhenExtinct: true, StopWhenStable: true}, []int{0, 1}},
+		{"stable blinker", blinker, RunOptions{StopWhenStable: true, Start: 1, Stride: 2, MaxGenerations: 4}, []int{1, 3, 5, 7}},
+	}
+	for _, c := range cases {
+		var generations []int
+		c.g.Run(context.Background(), c.options, func(runG *Gol) bool {
+			generations = append(generations, runG.Generation())
+			return true
+		})
+		if !reflect.DeepEqual(generations, c.expectedGenerations) {
+			t.Errorf("%s: the generations %v should have been yielded, found %v", c.name, c.expectedGenerations, generations)
+		}
+	}
+
+	// The yield function stops the run
+	var generations []int
+	blinker.Run(context.Background(), RunOptions{}, func(runG *Gol) bool {
+		generations = append(generations, runG.Generation())
+		return runG.Generation() < 3
+	})
+	if !reflect.DeepEqual(generations, []int{0, 1, 2, 3}) {
+		t.Errorf("The run should stop when the yield function returns false, found %v", generations)
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 20, 20, int64(42))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lastG *Gol
+	runError := g.Run(ctx, RunOptions{}, func(runG *Gol) bool {
+		lastG = runG
+		if runG.Generation() == 7 {
+			cancel()
+		}
+		return true
+	})
+	if !errors.Is(runError, context.Canceled) {
+		t.Errorf("The run should return the error of the context, found %v", runError)
+	}
+	if lastG == nil || lastG.Generation() < 7 || !lastG.GridEquals(g.FastForward(lastG.Generation()), "values") {
+		t.Errorf("The yielded instance should be the last computed generation")
+	}
+}
+
+func TestGenerations(t *testing.T) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 20, 20, int64(42))
+	var generations []*Gol
+	for generation := range g.Generations(context.Background(), RunOptions{Stride: 2, MaxGenerations: 5}) {
+		generations = append(generations, generation)
+	}
+	if len(generations) != 5 {
+		t.Errorf("5 generations should have been sent, found %d", len(generations))
+	}
+	for i, generation := range generations {
+		if generation.Generation() != 2*i || !generation.GridEquals(g.FastForward(2*i), "values") {
+			t.Errorf("The generation %d should have been sent, found %d", 2*i, generation.Generation())
+		}
+	}
+
+	// The generations are not sent once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := 0
+	for generation := range g.Generations(ctx, RunOptions{}) {
+		received++
+		if generation.Generation() == 3 {
+			cancel()
+		}
+	}
+	if received < 4 {
+		t.Errorf("At least 4 generations should have been received, found %d", received)
+	}
+}
@@ -0,0 +1,227 @@
+package gol
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/grid"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// serializationVersion : version of the JSON and binary serializations
+const serializationVersion = 1
+
+// maxSerializationCells : maximum number of cells of the
+// grids of the JSON and binary serializations
+const maxSerializationCells = 1 << 26
+
+// snapshot : state of a game of life instance, shared
+// by its JSON and binary serializations
+type snapshot struct {
+	Version          int               `json:"version"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+	Rules            string            `json:"rules"`
+	Generation       int               `json:"generation"`
+	NeighborhoodType string            `json:"neighborhood_type"`
+	Rows             int               `json:"rows"`
+	Cols             int               `json:"cols"`
+	RowLimitation    string            `json:"row_limitation"`
+	ColLimitation    string            `json:"col_limitation"`
+	// Origin of unbounded grids
+	Origin         []int         `json:"origin,omitempty"`
+	GridType       string        `json:"grid_type"`
+	Processes      int           `json:"processes"`
+	ThreadPoolSize int           `json:"thread_pool_size"`
+	Engine         string        `json:"engine"`
+	Cells          snapshotCells `json:"cells"`
+}
+
+// snapshotCells : cells of the grid, as runs of cells with the same state
+// in row-major order ([state, length] pairs) or as the coordinates of the
+// cells that are not dead ([i, j, state] triples)
+type snapshotCells struct {
+	Runs        [][2]int `json:"runs,omitempty"`
+	Coordinates [][3]int `json:"coordinates,omitempty"`
+}
+
+// MarshalJSON : return the JSON representation of the game of life
+// instance. The cells are encoded as runs or as coordinates,
+// depending on which one is shorter.
+func (g *Gol) MarshalJSON() ([]byte, error) {
+	s := g.snapshot()
+	if 3*len(s.Cells.Coordinates) < 2*len(s.Cells.Runs) {
+		s.Cells.Runs = nil
+	} else {
+		s.Cells.Coordinates = nil
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON : initialize the game of life instance from its JSON
+// representation (see MarshalJSON)
+func (g *Gol) UnmarshalJSON(data []byte) error {
+	var s snapshot
+	if jsonError := json.Unmarshal(data, &s); jsonError != nil {
+		return jsonError
+	}
+	return g.restore(&s)
+}
+
+// snapshot : return the state of the game of life instance,
+// with its cells encoded both as runs and as coordinates
+func (g *Gol) snapshot() *snapshot {
+	s := &snapshot{
+		Version:          serializationVersion,
+		Name:             g.name,
+		Description:      g.description,
+		Metadata:         g.metadata,
+		Rules:            g.Rules(),
+		Generation:       g.generation,
+		NeighborhoodType: g.NeighborhoodTypeString(),
+		Rows:             g.Rows(),
+		Cols:             g.Cols(),
+		RowLimitation:    g.grid.LimitRowsString(),
+		ColLimitation:    g.grid.LimitColsString(),
+		GridType:         g.GridType(),
+		Processes:        g.processes,
+		ThreadPoolSize:   g.threadPoolSize,
+		Engine:           g.engine,
+	}
+	if g.Unbounded() {
+		originI, originJ := g.Origin()
+		s.Origin = []int{originI, originJ}
+	}
+	for i := 0; i < s.Rows; i++ {
+		for j := 0; j < s.Cols; j++ {
+			state := g.Get(i, j)
+			if state != statuses.DEAD {
+				s.Cells.Coordinates = append(s.Cells.Coordinates, [3]int{i, j, state})
+			}
+			if runsCount := len(s.Cells.Runs); runsCount > 0 && s.Cells.Runs[runsCount-1][0] == state {
+				s.Cells.Runs[runsCount-1][1]++
+			} else {
+				s.Cells.Runs = append(s.Cells.Runs, [2]int{state, 1})
+			}
+		}
+	}
+	return s
+}
+
+// restore : initialize the game of life instance from a state
+func (g *Gol) restore(s *snapshot) error {
+	if s.Version != serializationVersion {
+		return fmt.Errorf("%w: version %d is not supported, only %d is",
+			ErrInvalidSerialization, s.Version, serializationVersion)
+	}
+	if s.Rows < 0 || s.Cols < 0 {
+		return fmt.Errorf("%w: the size of the grid must be positive, found %dx%d",
+			ErrInvalidSerialization, s.Rows, s.Cols)
+	}
+	// Checked before the grid is allocated (and so that rows*cols does not overflow)
+	if s.Rows > 0 && s.Cols > maxSerializationCells/s.Rows {
+		return fmt.Errorf("%w: the grid can have up to %d cells, found %dx%d",
+			ErrInvalidSerialization, maxSerializationCells, s.Rows, s.Cols)
+	}
+	if len(s.Cells.Runs) > 0 {
+		if runsError := checkRuns(s.Cells.Runs, s.Rows*s.Cols); runsError != nil {
+			return runsError
+		}
+	}
+	neighborhoodType, neighborhoodError := neighborhood.TypeFromString(s.NeighborhoodType)
+	if neighborhoodError != nil {
+		return neighborhoodError
+	}
+	// The type of the storage is ignored by unbounded grids
+	gridType := s.GridType
+	if gridType == "" || gridType == "unbounded" {
+		gridType = base.DefaultGridType
+	}
+	gr, gridError := grid.NewGrid(s.Rows, s.Cols, s.RowLimitation, s.ColLimitation, gridType)
+	if gridError != nil {
+		return gridError
+	}
+	if initError := g.InitWithGrid(s.Name, s.Description, s.Rules, s.Generation, neighborhoodType, gr); initError != nil {
+		return initError
+	}
+	for key, value := range s.Metadata {
+		g.SetMetadata(key, value)
+	}
+	if len(s.Origin) > 0 {
+		if len(s.Origin) != 2 {
+			return fmt.Errorf("%w: the origin must have 2 coordinates, found %v",
+				ErrInvalidSerialization, s.Origin)
+		}
+		if originError := g.SetOrigin(s.Origin[0], s.Origin[1]); originError != nil {
+			return originError
+		}
+	}
+	// The default concurrency settings are kept if the serialization
+	// has none (zero values are omitted in the binary representation)
+	if s.Processes > 0 {
+		g.SetProcesses(s.Processes)
+	} else {
+		g.SetProcesses(CPUS)
+	}
+	if s.ThreadPoolSize > 0 || s.ThreadPoolSize == ExplosiveThreadPoolSize {
+		g.SetThreadPoolSize(s.ThreadPoolSize)
+	} else {
+		g.SetThreadPoolSize(DefaultThreadPoolSize)
+	}
+	// The default engine is kept if the serialization has none
+	if s.Engine != "" {
+		if engineError := g.SetEngine(s.Engine); engineError != nil {
+			return engineError
+		}
+	}
+
+	if len(s.Cells.Runs) > 0 {
+		return g.restoreRuns(s.Cells.Runs)
+	}
+	for _, coordinates := range s.Cells.Coordinates {
+		if setError := g.Set(coordinates[0], coordinates[1], coordinates[2]); setError != nil {
+			return setError
+		}
+	}
+	return nil
+}
+
+// checkRuns : return an error if the lengths of the runs
+// of cells do not add up to the number of cells of the grid
+func checkRuns(runs [][2]int, cells int) error {
+	runsCells := 0
+	for _, run := range runs {
+		if run[1] < 0 || run[1] > cells-runsCells {
+			return fmt.Errorf("%w: the runs of the cells exceed the %d cells of the grid",
+				ErrInvalidSerialization, cells)
+		}
+		runsCells += run[1]
+	}
+	if runsCells != cells {
+		return fmt.Errorf("%w: the runs of the cells have %d cells, expected %d",
+			ErrInvalidSerialization, runsCells, cells)
+	}
+	return nil
+}
+
+// restoreRuns : set the cells of the grid from runs of cells with
+// the same state in row-major order (see checkRuns)
+func (g *Gol) restoreRuns(runs [][2]int) error {
+	cols := g.Cols()
+	cell := 0
+	for _, run := range runs {
+		state, length := run[0], run[1]
+		for ; length > 0; length-- {
+			if state != statuses.DEAD {
+				if setError := g.Set(cell/cols, cell%cols, state); setError != nil {
+					return setError
+				}
+			}
+			cell++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package gol
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// serializationTestGols : return game of life instances
+// with different rules, neighborhoods and grids
+func serializationTestGols() map[string]*Gol {
+	gols := make(map[string]*Gol)
+
+	random, _ := NewRandomGol("Random", "Random grid", "B3/S23", "dense", "limited", "unlimited", 17, 23, 42)
+	random.SetMetadata("author", "John Conway")
+	random.SetMetadata("year", "1970")
+	random.SetGeneration(7)
+	random.SetProcesses(CPUS)
+	random.SetThreadPoolSize(4)
+	gols["random"] = random
+
+	bitpacked, _ := NewRandomGol("Bitpacked", "", "B36/S23", "bitpacked", "unlimited", "unlimited", 9, 70, 7)
+	gols["bitpacked"] = bitpacked
+
+	unbounded, _ := NewGol("Glider", "", "23/3", "dok", "unbounded", "unbounded", 3, 3, 0)
+	for _, cell := range [][]int{{0, 1}, {1, 0}, {2, 0}, {2, 1}, {2, 2}} {
+		unbounded.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	unbounded = unbounded.FastForward(12).(*Gol)
+	unbounded.SetEngine(HashLifeEngine)
+	gols["unbounded"] = unbounded
+
+	brain, _ := NewGol("Brian's Brain", "", "B2/S/C3", "dense", "limited", "limited", 3, 6, 0)
+	brain.Set(1, 2, statuses.ALIVE)
+	brain.Set(1, 3, statuses.ALIVE)
+	gols["generations"] = brain.NextGeneration().(*Gol)
+
+	hexagonal, _ := NewGol("Hexagonal", "", "B2/S34H", "dok", "limited", "limited", 6, 6, 0)
+	hexagonal.Set(2, 2, statuses.ALIVE)
+	gols["hexagonal"] = hexagonal
+
+	mask, _ := neighborhood.ParseMask("1,2,1;2,0,2;1,2,1")
+	custom, _ := NewGol("Custom", "", "B4/S", "dense", "limited", "limited", 5, 5, 0)
+	custom.SetNeighborhoodType(neighborhood.RegisterMask(mask))
+	custom.Set(1, 2, statuses.ALIVE)
+	custom.Set(3, 2, statuses.ALIVE)
+	gols["custom"] = custom
+
+	empty, _ := NewGol("Empty", "", "B3/S23", "dok", "limited", "limited", 4, 4, 0)
+	gols["empty"] = empty
+	return gols
+}
+
+// checkSerializationRoundTrip : check that the deserialized
+// instance is equal to the serialized one
+func checkSerializationRoundTrip(t *testing.T, name, encoding string, g, deserializedG *Gol) {
+	if equalsError := g.EqualsError(deserializedG); equalsError != nil {
+		t.Errorf("%s (%s): %s", name, encoding, equalsError)
+	}
+	if g.GridType() != deserializedG.GridType() {
+		t.Errorf("%s (%s): grid types are different: %s vs %s", name, encoding, g.GridType(), deserializedG.GridType())
+	}
+	if g.Engine() != deserializedG.Engine() {
+		t.Errorf("%s (%s): engines are different: %s vs %s", name, encoding, g.Engine(), deserializedG.Engine())
+	}
+	originI, originJ := g.Origin()
+	deserializedOriginI, deserializedOriginJ := deserializedG.Origin()
+	if originI != deserializedOriginI || originJ != deserializedOriginJ {
+		t.Errorf("%s (%s): origins are different: %d,%d vs %d,%d",
+			name, encoding, originI, originJ, deserializedOriginI, deserializedOriginJ)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for name, g := range serializationTestGols() {
+		data, marshalError := json.Marshal(g)
+		if marshalError != nil {
+			t.Errorf("%s: %s", name, marshalError)
+			continue
+		}
+		deserializedG := new(Gol)
+		if unmarshalError := json.Unmarshal(data, deserializedG); unmarshalError != nil {
+			t.Errorf("%s: %s", name, unmarshalError)
+			continue
+		}
+		checkSerializationRoundTrip(t, name, "JSON", g, deserializedG)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for name, g := range serializationTestGols() {
+		data, marshalError := g.MarshalBinary()
+		if marshalError != nil {
+			t.Errorf("%s: %s", name, marshalError)
+			continue
+		}
+		deserializedG := new(Gol)
+		if unmarshalError := deserializedG.UnmarshalBinary(data); unmarshalError != nil {
+			t.Errorf("%s: %s", name, unmarshalError)
+			continue
+		}
+		checkSerializationRoundTrip(t, name, "binary", g, deserializedG)
+	}
+}
+
+func TestJSONCells(t *testing.T) {
+	// A sparse grid is encoded as coordinates and a dense one as runs
+	g, _ := NewGol("Sparse", "", "B3/S23", "dok", "limited", "limited", 20, 20, 0)
+	g.Set(3, 4, statuses.ALIVE)
+	data, _ := json.Marshal(g)
+	var s snapshot
+	json.Unmarshal(data, &s)
+	if len(s.Cells.Coordinates) != 1 || s.Cells.Runs != nil {
+		t.Errorf("The cells of a sparse grid should be encoded as coordinates, found %s", data)
+	}
+	g.SetAll(statuses.ALIVE)
+	data, _ = json.Marshal(g)
+	s = snapshot{}
+	json.Unmarshal(data, &s)
+	if len(s.Cells.Runs) != 1 || s.Cells.Coordinates != nil {
+		t.Errorf("The cells of a dense grid should be encoded as runs, found %s", data)
+	}
+
+	coordinatesJSON := `{"version": 1, "name": "Blinker", "rules": "B3/S23", "neighborhood_type": "Moore",
+		"rows": 3, "cols": 3, "row_limitation": "limited", "col_limitation": "limited",
+		"grid_type": "dok", "processes": 1, "cells": {"coordinates": [[1, 0, 1], [1, 1, 1], [1, 2, 1]]}}`
+	blinker := new(Gol)
+	if unmarshalError := json.Unmarshal([]byte(coordinatesJSON), blinker); unmarshalError != nil {
+		t.Error(unmarshalError)
+		return
+	}
+	for j := 0; j < 3; j++ {
+		if blinker.Get(1, j) != statuses.ALIVE || blinker.Get(0, j) != statuses.DEAD {
+			t.Errorf("The cells of the blinker were not read from the coordinates")
+		}
+	}
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	// JSON without the concurrency settings
+	blinkerJSON := `{"version": 1, "name": "Blinker", "rules": "B3/S23", "neighborhood_type": "Moore",
+		"rows": 3, "cols": 3, "row_limitation": "limited", "col_limitation": "limited",
+		"cells": {"coordinates": [[1, 0, 1], [1, 1, 1], [1, 2, 1]]}}`
+	blinker := new(Gol)
+	if unmarshalError := json.Unmarshal([]byte(blinkerJSON), blinker); unmarshalError != nil {
+		t.Error(unmarshalError)
+		return
+	}
+	if blinker.Processes() != CPUS || blinker.ThreadPoolSize() != DefaultThreadPoolSize {
+		t.Errorf("The default processes and thread pool size should be used, found %d and %d",
+			blinker.Processes(), blinker.ThreadPoolSize())
+	}
+	if blinker.Engine() != GridEngine || blinker.GridType() != base.DefaultGridType {
+		t.Errorf("The default engine and grid type should be used, found %s and %s",
+			blinker.Engine(), blinker.GridType())
+	}
+
+	// Zero values are omitted in the binary representation
+	g, _ := NewGol("Zeros", "", "B3/S23", "dense", "limited", "limited", 3, 3, 0)
+	g.SetProcesses(0)
+	g.SetThreadPoolSize(0)
+	data, _ := g.MarshalBinary()
+	deserializedG := new(Gol)
+	if unmarshalError := deserializedG.UnmarshalBinary(data); unmarshalError != nil {
+		t.Error(unmarshalError)
+		return
+	}
+	if deserializedG.Processes() != CPUS || deserializedG.ThreadPoolSize() != DefaultThreadPoolSize {
+		t.Errorf("The default processes and thread pool size should be used, found %d and %d",
+			deserializedG.Processes(), deserializedG.ThreadPoolSize())
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	invalidJSONs := []string{
+		`{"version": 2, "rules": "B3/S23", "neighborhood_type": "Moore", "rows": 1, "cols": 1}`,
+		`{"version": 1, "rules": "B3/S23", "neighborhood_type": "Moore", "rows": -1, "cols": 1}`,
+		`{"version": 1, "rules": "B3/S23", "neighborhood_type": "Moore", "rows": 2, "cols": 2,
+			"row_limitation": "limited", "col_limitation": "limited", "cells": {"runs": [[0, 3]]}}`,
+		`{"version": 1, "rules": "B3/S23", "neighborhood_type": "Moore", "rows": 2, "cols": 2,
+			"row_limitation": "limited", "col_limitation": "limited", "cells": {"runs": [[0, 5]]}}`,
+		// Too many cells, or so many that rows*cols overflows
+		`{"version": 1, "rules": "B3/S23", "neighborhood_type": "Moore", "rows": 1048576, "cols": 1048576,
+			"row_limitation": "limited", "col_limitation": "limited", "grid_type": "dense"}`,
+		`{"version": 1, "rules": "B3/S23", "neighborhood_type": "Moore", "rows": 1099511627776, "cols": 1099511627776,
+			"row_limitation": "limited", "col_limitation": "limited", "cells": {"runs": [[0, 1]]}}`,
+	}
+	for _, invalidJSON := range invalidJSONs {
+		if unmarshalError := json.Unmarshal([]byte(invalidJSON), new(Gol)); !errors.Is(unmarshalError, ErrInvalidSerialization) {
+			t.Errorf("%s should not be unmarshalled, found %v", invalidJSON, unmarshalError)
+		}
+	}
+
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 5, 5, 1)
+	data, _ := g.MarshalBinary()
+	versionData := append([]byte{}, data...)
+	versionData[len(binaryMagic)]++
+	// Too many cells, or so many that rows*cols overflows
+	hugeData := map[string]int{"huge": 1 << 20, "overflow": 1 << 40}
+	invalidData := map[string][]byte{
+		"empty":     {},
+		"magic":     append([]byte("GLWC"), data[len(binaryMagic):]...),
+		"version":   versionData,
+		"truncated": data[:len(data)-1],
+	}
+	for name, size := range hugeData {
+		var huge binaryWriter
+		huge.buffer.WriteString(binaryMagic)
+		huge.buffer.WriteByte(serializationVersion)
+		huge.writeString(fieldRules, "23/3")
+		huge.writeString(fieldNeighborhoodType, "Moore")
+		huge.writeInt(fieldRows, size)
+		huge.writeInt(fieldCols, size)
+		huge.writeString(fieldRowLimitation, "limited")
+		huge.writeString(fieldColLimitation, "limited")
+		huge.writeString(fieldGridType, "dense")
+		huge.writePackedInts(fieldRuns, []int{0, 1})
+		invalidData[name] = huge.buffer.Bytes()
+	}
+	for name, invalidBinary := range invalidData {
+		if unmarshalError := new(Gol).UnmarshalBinary(invalidBinary); !errors.Is(unmarshalError, ErrInvalidSerialization) {
+			t.Errorf("The %s binary representation should not be unmarshalled, found %v", name, unmarshalError)
+		}
+	}
+
+	// Unknown fields are skipped
+	var unknown binaryWriter
+	unknown.buffer.Write(data)
+	unknown.writeInt(100, 5)
+	unknown.writeString(101, "unknown")
+	deserializedG := new(Gol)
+	if unmarshalError := deserializedG.UnmarshalBinary(unknown.buffer.Bytes()); unmarshalError != nil {
+		t.Error(unmarshalError)
+	} else if equalsError := g.EqualsError(deserializedG); equalsError != nil {
+		t.Error(equalsError)
+	}
+}
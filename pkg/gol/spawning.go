@@ -1,13 +1,13 @@
 package gol
 
 import (
-	"fmt"
+	"context"
 	"runtime"
-	"sync"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
 
 // SERIAL : if assigned to Gol.Processes, a serial algorithm (i.e. no concurrency)
@@ -21,14 +21,16 @@ const CPUS = -1
 
 // DefaultThreadPoolSize : default number of threads used in the thread pool
 // to compute next generation of a game of life instance.
+// Deprecated: the parallel engine uses a worker for each process.
 const DefaultThreadPoolSize = 10
 
-// ExplosiveThreadPoolSize : the thread pool will use a thread for each cell
-// do not use it unless you want to experiment with your memory limits.
+// ExplosiveThreadPoolSize : the thread pool will use a thread for each cell.
+// Deprecated: the parallel engine uses a worker for each process.
 const ExplosiveThreadPoolSize = -1
 
 // Processes : return the number of GO processes used in
-// the computing of the next generation.
+// the computing of the next generation. The parallel engine splits
+// the grid in as many bands of rows as processes.
 // Take account the constants SERIAL and CPUS of this package.
 func (g *Gol) Processes() int {
 	return g.processes
@@ -43,6 +45,8 @@ func (g *Gol) SetProcesses(processes int) {
 
 // ThreadPoolSize : get the number of threads that will be
 // used when the parallel next generation algorithm is used.
+// Deprecated: the parallel engine uses a worker for each process
+// (see Processes), so the size of the thread pool is ignored.
 func (g *Gol) ThreadPoolSize() int {
 	return g.threadPoolSize
 }
@@ -50,154 +54,170 @@ func (g *Gol) ThreadPoolSize() int {
 // SetThreadPoolSize : set the number of threads
 // that will be used when the parallel next generation algorithm
 // is used.
+// Deprecated: the parallel engine uses a worker for each process
+// (see SetProcesses), so the size of the thread pool is ignored.
 func (g *Gol) SetThreadPoolSize(threadPoolSize int) {
 	g.threadPoolSize = threadPoolSize
 }
 
-// FastForward : move forward a number of generations
+// FastForward : move forward a number of generations. The generations are
+// computed in place (see StepN) in a clone of the game of life instance.
 func (g *Gol) FastForward(generations int) base.GolInterface {
 	ffg := g.Clone().(*Gol)
-	nextGenFunc := g.nextGenerationFunc()
-	for generation := 0; generation < generations; generation++ {
-		ffg = nextGenFunc(ffg).(*Gol)
-	}
+	ffg.StepN(generations)
 	return ffg
 }
 
+// FastForwardContext : move forward a number of generations (see
+// FastForward), calling the progress function (if not nil) after each
+// generation. If the context is done, the last computed generation is
+// returned along with the error of the context.
+func (g *Gol) FastForwardContext(ctx context.Context, generations int, progress base.ProgressFunc) (base.GolInterface, error) {
+	ffg := g.Clone().(*Gol)
+	ctxError := ffg.StepNContext(ctx, generations, progress)
+	return ffg, ctxError
+}
+
 // NextGeneration : compute the next generation
 // If no prior change to the generation of the next game of life
 // instance, pass a nil in the place of changes parameter.
 func (g *Gol) NextGeneration() base.GolInterface {
-	nextGenFunc := g.nextGenerationFunc()
+	nextGenFunc, release := g.nextGenerationFunc()
+	defer release()
 	return nextGenFunc(g)
 }
 
 // serialNextGeneration : compute the next generation without running threads
 func serialNextGeneration(g *Gol) base.GolInterface {
-	rows := g.Rows()
-	cols := g.Cols()
-
-	nextG := g.copyWithEmptyGrid().(*Gol)
-
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			cellValue := g.nextCell(i, j)
-			nextG.Set(i, j, cellValue)
-		}
-	}
-	nextG.generation++
-	return nextG
+	nextG, bands := g.nextGenerationBands(1)
+	computeBands(bands)
+	return nextG.endNextGeneration(bands)
 }
 
-// explosiveParallelNextGeneration : compute the next generation using threads
-// creating a thread for each cell. Do not use unless you want to test
-// your system's memory limits
-func explosiveParallelNextGeneration(g *Gol) base.GolInterface {
-	setRuntimeProcs(g)
-
+// bitpackedNextGeneration : compute the next generation word by word
+// when the cells are stored in a bit-packed grid
+func bitpackedNextGeneration(g *Gol) base.GolInterface {
 	nextG := g.copyWithEmptyGrid().(*Gol)
-
-	rows := g.Rows()
-	cols := g.Cols()
-
-	var wg sync.WaitGroup
-	wg.Add(rows * cols)
-
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			go func(iIndex int, jIndex int) {
-				nextValue := g.nextCell(iIndex, jIndex)
-				nextG.Set(iIndex, jIndex, nextValue)
-				wg.Done()
-			}(i, j)
-		}
-	}
-	wg.Wait()
+	nextG.grid = g.grid.BitpackedNextGeneration(g.survivalRule, g.birthRule)
 	nextG.generation++
 	return nextG
 }
 
-// parallelNextGeneration : compute the next generation using a thread pool,
-func parallelNextGeneration(g *Gol, poolSize int) base.GolInterface {
-	setRuntimeProcs(g)
-
-	nextG := g.copyWithEmptyGrid().(*Gol)
-
-	rows := g.Rows()
-	cols := g.Cols()
-
-	type pos struct {
-		I int
-		J int
+// nextGenerationFunc : return the function that computes the next
+// generation and the one that releases its resources (i.e. the workers
+// of the parallel engine) when no more generations are going to be computed
+func (g *Gol) nextGenerationFunc() (func(gx *Gol) base.GolInterface, func()) {
+	release := func() {}
+	if g.rule.IsLargerThanLife() {
+		return largerThanLifeNextGeneration, release
 	}
-
-	jobs := make(chan pos, poolSize)
-
-	var wg sync.WaitGroup
-
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			wg.Add(1)
-			go func(jobs <-chan pos) {
-				for pos := range jobs {
-					nextValue := g.nextCell(pos.I, pos.J)
-					nextG.Set(pos.I, pos.J, nextValue)
-				}
-				wg.Done()
-			}(jobs)
-		}
+	if g.UsesHashLife() {
+		return func(gx *Gol) base.GolInterface {
+			return hashLifeFastForward(gx, 1)
+		}, release
 	}
-
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			jobs <- pos{i, j}
-		}
+	if g.usesBitpacked() {
+		return bitpackedNextGeneration, release
+	}
+	if g.processes == SERIAL {
+		return serialNextGeneration, release
 	}
-	close(jobs)
+	setRuntimeProcs(g)
+	workers := newBandWorkers(parallelWorkers(g))
+	return workers.nextGeneration, workers.stop
+}
 
-	wg.Wait()
-	nextG.generation++
-	return nextG
+func (g *Gol) usesBitpacked() bool {
+	return g.grid.IsBitpacked() && g.neighborhoodType == neighborhood.MOORE &&
+		g.rule.IsTotalistic() && g.rule.States() == 2 && !g.rule.IsLargerThanLife()
 }
 
-func (g *Gol) nextGenerationFunc() func(gx *Gol) base.GolInterface {
-	if g.processes == SERIAL {
-		return serialNextGeneration
+func (g *Gol) nextCell(i int, j int) int {
+	status := g.Get(i, j)
+	if status != statuses.ALIVE && status != statuses.DEAD {
+		return g.nextDyingStatus(status)
 	}
-	if g.threadPoolSize == ExplosiveThreadPoolSize {
-		return explosiveParallelNextGeneration
+
+	var survives, isBorn bool
+	if !g.rule.IsTotalistic() && g.neighborhoodType == neighborhood.MOORE {
+		// Isotropic non-totalistic rules depend on the configuration of the neighbors
+		configuration := g.aliveNeighborsConfiguration(i, j)
+		survives = g.rule.Survives(configuration)
+		isBorn = g.rule.IsBorn(configuration)
+	} else {
+		aliveNeighborsCount := neighborhood.NeighborsCount(
+			g, i, j, statuses.ALIVE, g.neighborhoodFunc, g.neighborhoodWeights,
+		)
+		survives = g.survivalRule[aliveNeighborsCount]
+		isBorn = g.birthRule[aliveNeighborsCount]
 	}
-	return func(gx *Gol) base.GolInterface {
-		return parallelNextGeneration(gx, gx.threadPoolSize)
+	return g.nextStatus(status, survives, isBorn)
+}
+
+// nextDyingStatus : return the next status of a dying cell, that
+// does not depend on its neighbors. Cells whose status is not one
+// of the states of the rules (e.g. VOID) become DEAD.
+func (g *Gol) nextDyingStatus(status int) int {
+	if status < statuses.DYING || status >= g.rule.States() {
+		return statuses.DEAD
 	}
+	return g.rule.NextDyingState(status)
 }
 
-func (g *Gol) nextCell(i int, j int) int {
-	aliveNeighborsCount := neighborhood.NeighborsCount(g, i, j, statuses.ALIVE, g.neighborhoodFunc)
+// nextStatus : return the next status of an alive or dead cell
+func (g *Gol) nextStatus(status int, survives, isBorn bool) int {
 	// Text from Wikipedia: https://en.wikipedia.org/wiki/Conway%27s_Game_of_Life
 	// Any live cell with two or three live neighbors survives.
 	// Any dead cell with three live neighbors becomes a live cell.
 	// All other live cells die in the next generation. Similarly, all other dead cells stay dead.
-	switch g.Get(i, j) {
-	case statuses.ALIVE:
-		if g.survivalRule[aliveNeighborsCount] {
+	if status == statuses.ALIVE {
+		if survives {
 			return statuses.ALIVE
 		}
-		return statuses.DEAD
-	case statuses.DEAD:
-		if g.birthRule[aliveNeighborsCount] {
-			return statuses.ALIVE
+		return g.rule.NextDyingState(statuses.ALIVE)
+	}
+	if isBorn {
+		return statuses.ALIVE
+	}
+	return statuses.DEAD
+}
+
+// aliveNeighborsConfiguration : return the configuration of the alive Moore
+// neighbors of a cell as a bit mask (see rules.ConfigurationLetter)
+func (g *Gol) aliveNeighborsConfiguration(i int, j int) int {
+	configuration := 0
+	for neighborI, neighbor := range g.neighborhoodFunc(g, i, j) {
+		if neighbor == statuses.ALIVE {
+			configuration |= 1 << uint(neighborI)
 		}
-		return statuses.DEAD
-	default:
-		panic(fmt.Sprintf("Invalid cell %d,%d status", i, j))
 	}
+	return configuration
 }
 
 func (g *Gol) copyWithEmptyGrid() base.GolInterface {
-	ngGol := new(Gol)
-	ngGol.InitWithGrid(g.name, g.description, g.rules, g.generation, g.neighborhoodType, g.grid.CloneEmpty())
-	return ngGol
+	ngGol := *g
+	ngGol.grid = g.grid.CloneEmpty()
+	ngGol.processes = CPUS
+	ngGol.threadPoolSize = DefaultThreadPoolSize
+	ngGol.changedCells = nil
+	ngGol.changedCellsKnown = false
+	ngGol.backGrid = nil
+	return &ngGol
+}
+
+// emptyNextGeneration : return an empty copy of the game of life where
+// the next generation will be stored, and the padding between its cells
+// and the ones of g. Unbounded grids get as many extra rows and columns on
+// each side as the radius of the neighborhood (or of the rules) so the
+// pattern can grow.
+func (g *Gol) emptyNextGeneration() (*Gol, int) {
+	nextG := g.copyWithEmptyGrid().(*Gol)
+	if !g.grid.Unbounded() {
+		return nextG, 0
+	}
+	padding := utils.MaxInt(g.rule.Radius(), neighborhood.Radius(g.neighborhoodType))
+	nextG.grid.Grow(padding, padding, padding, padding)
+	return nextG, padding
 }
 
 func setRuntimeProcs(g base.GolInterface) {
@@ -33,11 +33,33 @@ func TestBigGridsNextGeneration(t *testing.T) {
 	testStandardGridNextGeneration(t, "grid1024x1024.txt", "grid1024x1024_gen1.txt", CPUS, ExplosiveThreadPoolSize)
 }
 
+func TestBitpackedNextGeneration(t *testing.T) {
+	g0, g0ReadError := readCongolwayFile("grid1024x1024.txt")
+	if g0ReadError != nil {
+		t.Error(g0ReadError)
+		return
+	}
+	g1, g1ReadError := readCongolwayFile("grid1024x1024_gen1.txt")
+	if g1ReadError != nil {
+		t.Error(g1ReadError)
+		return
+	}
+
+	bg0 := toGridType(g0.(*Gol), "bitpacked")
+	bg1 := bg0.NextGeneration()
+	if bg1.Generation() != g1.Generation() {
+		t.Errorf("Generations are different: %d vs %d", bg1.Generation(), g1.Generation())
+	}
+	if !bg1.GridEquals(g1, "values") {
+		t.Errorf("Bit-packed next generation should be equal than the expected gol")
+	}
+}
+
 func TestFastForward(t *testing.T) {
 	rows := 100
 	cols := 100
 	randomSeed := int64(42)
-	g := NewRandomGol("Random", "", "23/3", "dok", "limited", "limited",
+	g, _ := NewRandomGol("Random", "", "23/3", "dok", "limited", "limited",
 		rows, cols, randomSeed)
 	g3 := g.NextGeneration().NextGeneration().NextGeneration().(*Gol)
 	ffg3 := g.FastForward(3)
@@ -48,6 +70,15 @@ func TestFastForward(t *testing.T) {
 	}
 }
 
+func TestBitpackedFastForward(t *testing.T) {
+	g, _ := NewRandomGol("Random", "", "23/3", "dok", "unlimited", "limited", 100, 100, int64(42))
+	ffg := g.FastForward(10)
+	bffg := toGridType(g, "bitpacked").FastForward(10)
+	if !bffg.GridEquals(ffg, "values") {
+		t.Errorf("Bit-packed fast forward should be equal than the dok one")
+	}
+}
+
 func TestPriorChanges(t *testing.T) {
 	g, gError := readCongolwayFile("still/boat.txt")
 	if gError != nil {
@@ -77,6 +108,25 @@ func TestPriorChanges(t *testing.T) {
 	}
 }
 
+func toGridType(g *Gol, gridType string) *Gol {
+	rowLimitation := "unlimited"
+	if g.LimitRows() {
+		rowLimitation = "limited"
+	}
+	colLimitation := "unlimited"
+	if g.LimitCols() {
+		colLimitation = "limited"
+	}
+	converted, _ := NewGol(g.Name(), g.Description(), g.Rules(), gridType,
+		rowLimitation, colLimitation, g.Rows(), g.Cols(), g.Generation())
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			converted.Set(i, j, g.Get(i, j))
+		}
+	}
+	return converted
+}
+
 func testStandardGridNextGeneration(t *testing.T, gen0FilePath string, gen1FilePath string, goProcesses int, threadPoolSize int) {
 	g0, g0ReadError := readCongolwayFile(gen0FilePath)
 	if g0ReadError != nil {
@@ -135,3 +185,68 @@ func testOscilatorNextGeneration(t *testing.T, gen0FilePath string, gen1FilePath
 		t.Errorf("Odd oscilator game-of-life generation is wrong. They should be equal (even generation)")
 	}
 }
+
+func TestParallelNextGeneration(t *testing.T) {
+	grids := map[string]*Gol{}
+	grids["random"], _ = NewRandomGol("Random", "", "B3/S23", "dense", "unlimited", "limited", 61, 47, int64(42))
+	grids["generations"], _ = NewRandomGol("Random", "", "B2/S/C3", "dok", "limited", "limited", 33, 20, int64(7))
+	// Fewer rows than workers
+	grids["thin"], _ = NewRandomGol("Random", "", "B3/S23", "dok", "unlimited", "unlimited", 2, 50, int64(1))
+	grids["unbounded"], _ = NewGol("Glider", "", "B3/S23", "dok", "unbounded", "unbounded", 3, 3, 0)
+	for _, cell := range [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}} {
+		grids["unbounded"].Set(cell[0], cell[1], statuses.ALIVE)
+	}
+
+	for name, g := range grids {
+		g.SetProcesses(SERIAL)
+		expectedG := g.FastForward(20)
+		for _, processes := range []int{CPUS, 2, 3, 16} {
+			g.SetProcesses(processes)
+			if actualG := g.FastForward(20); !actualG.GridEquals(expectedG, "values") {
+				t.Errorf("%s: the parallel fast forward with %d processes should be equal than the serial one",
+					name, processes)
+			}
+			if actualG := g.NextGeneration(); !actualG.GridEquals(serialNextGeneration(g), "values") {
+				t.Errorf("%s: the parallel next generation with %d processes should be equal than the serial one",
+					name, processes)
+			}
+		}
+	}
+}
+
+func BenchmarkSerialNextGeneration(b *testing.B) {
+	benchmarkNextGeneration(b, SERIAL)
+}
+
+func BenchmarkParallelNextGeneration(b *testing.B) {
+	benchmarkNextGeneration(b, CPUS)
+}
+
+func BenchmarkSerialFastForward(b *testing.B) {
+	benchmarkFastForward(b, SERIAL)
+}
+
+func BenchmarkParallelFastForward(b *testing.B) {
+	benchmarkFastForward(b, CPUS)
+}
+
+func benchmarkNextGeneration(b *testing.B, processes int) {
+	g, gError := readCongolwayFile("grid1024x1024.txt")
+	if gError != nil {
+		b.Fatal(gError)
+	}
+	g.SetProcesses(processes)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.NextGeneration()
+	}
+}
+
+func benchmarkFastForward(b *testing.B, processes int) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "unlimited", "unlimited", 256, 256, int64(42))
+	g.SetProcesses(processes)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.FastForward(10)
+	}
+}
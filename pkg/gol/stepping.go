@@ -0,0 +1,155 @@
+package gol
+
+import (
+	"context"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// hashLifeProgressSteps : maximum number of jumps of the HashLife
+// engine when the progress of a run is tracked or it can be cancelled
+const hashLifeProgressSteps = 100
+
+// Step : compute the next generation in place. The next generation is
+// computed in a second grid (the one of the previous generation), that is
+// swapped with the grid of the instance, so no new instance nor grid is
+// allocated. Use NextGeneration to keep the current generation unchanged.
+func (g *Gol) Step() {
+	g.StepN(1)
+}
+
+// StepN : compute a number of generations in place (see Step).
+// The parallel engine reuses the same workers for all the generations.
+func (g *Gol) StepN(generations int) {
+	// The background context is never done
+	g.StepNContext(context.Background(), generations, nil)
+}
+
+// StepNContext : compute a number of generations in place (see StepN),
+// calling the progress function (if not nil) after each generation. If the
+// context is done, the generations that have not been computed yet are not
+// computed and the error of the context is returned. The HashLife engine
+// computes the generations in up to hashLifeProgressSteps jumps, unless
+// the context is never done and there is no progress function.
+func (g *Gol) StepNContext(ctx context.Context, generations int, progress base.ProgressFunc) error {
+	var report func(computed int)
+	if progress != nil {
+		tracker := base.NewProgressTracker(generations, progress)
+		report = func(computed int) {
+			tracker.Report(g, computed)
+		}
+	}
+	return g.stepN(ctx, generations, report)
+}
+
+// stepN : compute a number of generations in place (see StepNContext),
+// calling the report function (if not nil) with the number of generations
+// computed so far after each generation (or jump of the HashLife engine)
+func (g *Gol) stepN(ctx context.Context, generations int, report func(computed int)) error {
+	if g.UsesHashLife() {
+		jump := generations
+		if ctx.Done() != nil || report != nil {
+			jump = utils.MaxInt(1, generations/hashLifeProgressSteps)
+		}
+		// The same universe (and the generations it has memoized)
+		// is advanced in every jump
+		startG := g.Clone().(*Gol)
+		universe := startG.hashLifeUniverse()
+		for computed := 0; computed < generations; {
+			if ctxError := ctx.Err(); ctxError != nil {
+				return ctxError
+			}
+			jump = utils.MinInt(jump, generations-computed)
+			universe.Advance(jump)
+			computed += jump
+			g.adoptGeneration(startG.hashLifeGeneration(universe, computed))
+			if report != nil {
+				report(computed)
+			}
+		}
+		return nil
+	}
+	if generations <= 0 {
+		return nil
+	}
+	stepFunc, release := g.stepFunc()
+	defer release()
+	return stepGenerations(ctx, stepFunc, generations, report)
+}
+
+// stepGenerations : compute a number of generations with the step function
+// (see stepFunc), calling the report function (if not nil) after each one
+func stepGenerations(ctx context.Context, stepFunc func(), generations int, report func(computed int)) error {
+	for computed := 1; computed <= generations; computed++ {
+		if ctxError := ctx.Err(); ctxError != nil {
+			return ctxError
+		}
+		stepFunc()
+		if report != nil {
+			report(computed)
+		}
+	}
+	return nil
+}
+
+// stepFunc : return the function that computes the next generation in place
+// and the one that releases its resources (see nextGenerationFunc)
+func (g *Gol) stepFunc() (func(), func()) {
+	release := func() {}
+	// The engines that do not compute the cells in bands (and unbounded
+	// grids, that can change their size) compute a new instance
+	if g.rule.IsLargerThanLife() || g.usesBitpacked() || g.grid.Unbounded() {
+		nextGenFunc, release := g.nextGenerationFunc()
+		return func() {
+			g.adoptGeneration(nextGenFunc(g).(*Gol))
+		}, release
+	}
+	if g.processes == SERIAL {
+		return func() {
+			g.step(1, computeBands)
+		}, release
+	}
+	setRuntimeProcs(g)
+	workers := newBandWorkers(parallelWorkers(g))
+	return func() {
+		g.step(workers.workers, workers.compute)
+	}, workers.stop
+}
+
+// step : compute the next generation in at most n bands in the back grid,
+// and swap it with the grid of the instance
+func (g *Gol) step(n int, computeBands func(bands []*band)) {
+	var bands []*band
+	if g.tracksChangedCells() {
+		if g.backGrid == nil {
+			g.backGrid = g.grid.Clone()
+		} else {
+			// The back grid has the previous generation,
+			// that only differs in the changed cells
+			for _, cell := range g.changedCells {
+				g.backGrid.Set(cell[0], cell[1], g.grid.Get(cell[0], cell[1]))
+			}
+		}
+		bands = g.activeBands(g.backGrid, n)
+	} else {
+		if g.backGrid == nil {
+			g.backGrid = g.grid.CloneEmpty()
+		}
+		bands = g.rowBands(g.backGrid, 0, n)
+	}
+	computeBands(bands)
+	g.grid, g.backGrid = g.backGrid, g.grid
+	g.generation++
+	g.setChangedCells(bands)
+}
+
+// adoptGeneration : replace the cells of the instance (and
+// its generation) by the ones of a later generation
+func (g *Gol) adoptGeneration(nextG *Gol) {
+	g.grid = nextG.grid
+	g.backGrid = nil
+	g.generation = nextG.generation
+	g.changedCells = nextG.changedCells
+	g.changedCellsKnown = nextG.changedCellsKnown
+}
@@ -0,0 +1,162 @@
+package gol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestStep(t *testing.T) {
+	grids := map[string]*Gol{}
+	grids["dense"], _ = NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 30, 40, int64(42))
+	grids["dok"], _ = NewRandomGol("Random", "", "B36/S23", "dok", "unlimited", "unlimited", 25, 31, int64(7))
+	grids["generations"], _ = NewRandomGol("Random", "", "B2/S/C4", "dense", "unlimited", "limited", 20, 20, int64(3))
+	grids["bitpacked"], _ = NewRandomGol("Random", "", "B3/S23", "bitpacked", "unlimited", "unlimited", 20, 70, int64(5))
+	grids["ltl"], _ = NewRandomGol("Bosco", "", "R5,C0,M1,S34..58,B34..45,NM", "dense", "unlimited", "unlimited", 30, 30, int64(1))
+	grids["unbounded"], _ = NewGol("Glider", "", "B3/S23", "dok", "unbounded", "unbounded", 3, 3, 0)
+	for _, cell := range [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}} {
+		grids["unbounded"].Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	grids["hashlife"] = grids["unbounded"].Clone().(*Gol)
+	grids["hashlife"].SetEngine(HashLifeEngine)
+
+	for name, g := range grids {
+		for _, processes := range []int{SERIAL, 3} {
+			g.SetProcesses(processes)
+			expectedG := g.Clone()
+			steppedG := g.Clone().(*Gol)
+			for generation := 1; generation <= 20; generation++ {
+				expectedG = expectedG.NextGeneration()
+				steppedG.Step()
+				if !steppedG.GridEquals(expectedG, "values") || steppedG.Generation() != expectedG.Generation() {
+					t.Errorf("%s: the stepped generation %d should be equal than the next one", name, generation)
+					break
+				}
+				// Changes between the steps are taken into account
+				if generation == 10 && steppedG.Rows() > 2 && steppedG.Cols() > 2 {
+					expectedG.Set(1, 1, statuses.ALIVE)
+					steppedG.Set(1, 1, statuses.ALIVE)
+				}
+			}
+			steppedG.StepN(15)
+			if !steppedG.GridEquals(expectedG.FastForward(15), "values") {
+				t.Errorf("%s: 15 steps should be equal than fast forwarding 15 generations", name)
+			}
+		}
+	}
+}
+
+func TestStepClone(t *testing.T) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "limited", "limited", 20, 20, int64(42))
+	g.StepN(3)
+	clone := g.Clone().(*Gol)
+	expectedG := g.NextGeneration()
+
+	// Stepping an instance does not change its clones
+	g.StepN(5)
+	clone.Step()
+	if !clone.GridEquals(expectedG, "values") {
+		t.Errorf("The clone should not share the grids of the stepped instance")
+	}
+	generation := g.Generation()
+	g.StepN(0)
+	if g.Generation() != generation {
+		t.Errorf("0 steps should not change the generation")
+	}
+}
+
+func BenchmarkNextGenerationAllocs(b *testing.B) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "unlimited", "unlimited", 256, 256, int64(42))
+	g.SetProcesses(SERIAL)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g = g.NextGeneration().(*Gol)
+	}
+}
+
+func BenchmarkStepAllocs(b *testing.B) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dense", "unlimited", "unlimited", 256, 256, int64(42))
+	g.SetProcesses(SERIAL)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.Step()
+	}
+}
+
+func BenchmarkDokNextGenerationAllocs(b *testing.B) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dok", "unlimited", "unlimited", 256, 256, int64(42))
+	g.SetProcesses(SERIAL)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g = g.NextGeneration().(*Gol)
+	}
+}
+
+func BenchmarkDokStepAllocs(b *testing.B) {
+	g, _ := NewRandomGol("Random", "", "B3/S23", "dok", "unlimited", "unlimited", 256, 256, int64(42))
+	g.SetProcesses(SERIAL)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		g.Step()
+	}
+}
+
+func TestStepNContext(t *testing.T) {
+	// HashLife is only used in unbounded grids
+	g, _ := NewGol("Glider", "", "B3/S23", "dok", "unbounded", "unbounded", 3, 3, 0)
+	for _, cell := range [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}} {
+		g.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	for _, engine := range []string{GridEngine, HashLifeEngine} {
+		g.SetEngine(engine)
+		expectedG := g.FastForward(250)
+
+		var progresses []base.Progress
+		ffg, ffError := g.FastForwardContext(context.Background(), 250, func(progress base.Progress) {
+			progresses = append(progresses, progress)
+		})
+		if ffError != nil {
+			t.Errorf("%s: %s", engine, ffError)
+			continue
+		}
+		if !ffg.GridEquals(expectedG, "values") {
+			t.Errorf("%s: the fast forward with a context should be equal than the one without it", engine)
+		}
+		// HashLife jumps over the generations
+		expectedProgresses := 250
+		if engine == HashLifeEngine {
+			expectedProgresses = 125
+		}
+		lastProgress := progresses[len(progresses)-1]
+		if len(progresses) != expectedProgresses || lastProgress.Computed != 250 || lastProgress.Total != 250 ||
+			lastProgress.Generation != 250 || lastProgress.Population != ffg.Population() {
+			t.Errorf("%s: %d progresses should have been reported, found %d (last one: %+v)",
+				engine, expectedProgresses, len(progresses), lastProgress)
+		}
+
+		// The run stops when the context is cancelled
+		ctx, cancel := context.WithCancel(context.Background())
+		steppedG := g.Clone().(*Gol)
+		stepError := steppedG.StepNContext(ctx, 250, func(progress base.Progress) {
+			if progress.Computed >= 10 {
+				cancel()
+			}
+		})
+		if !errors.Is(stepError, context.Canceled) {
+			t.Errorf("%s: the run should have been cancelled, found %v", engine, stepError)
+		}
+		if steppedG.Generation() < 10 || steppedG.Generation() >= 250 {
+			t.Errorf("%s: the run should have stopped after the generation 10, found %d", engine, steppedG.Generation())
+		}
+		if !steppedG.GridEquals(g.FastForward(steppedG.Generation()), "values") {
+			t.Errorf("%s: the cells of the cancelled run should be the ones of its last generation", engine)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package gol
+
+import (
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestUnboundedGlider(t *testing.T) {
+	g, _ := NewGol("Glider", "", "23/3", "dok", "unbounded", "unbounded", 3, 3, 0)
+	glider := [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}}
+	for _, cell := range glider {
+		g.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+
+	// A glider moves one cell diagonally every 4 generations
+	generations := 4 * 10
+	for _, processes := range []int{SERIAL, CPUS} {
+		g.SetProcesses(processes)
+		ffg := g.FastForward(generations).(*Gol)
+		if ffg.Rows() != 13 || ffg.Cols() != 13 {
+			t.Errorf("Grid should have grown to 13x13, found %dx%d", ffg.Rows(), ffg.Cols())
+		}
+		originI, originJ := ffg.Origin()
+		if originI != 0 || originJ != 0 {
+			t.Errorf("Origin should be 0,0, found %d,%d", originI, originJ)
+		}
+		for _, cell := range glider {
+			if ffg.Get(cell[0]+10, cell[1]+10) != statuses.ALIVE {
+				t.Errorf("Cell %d,%d should be alive", cell[0]+10, cell[1]+10)
+			}
+		}
+
+		// Flying to the north-west moves the origin
+		nwg := g.Clone().(*Gol)
+		nwg.SetAll(statuses.DEAD)
+		for _, cell := range glider {
+			nwg.Set(2-cell[0], 2-cell[1], statuses.ALIVE)
+		}
+		nwffg := nwg.FastForward(generations).(*Gol)
+		originI, originJ = nwffg.Origin()
+		if originI != -10 || originJ != -10 {
+			t.Errorf("Origin should be -10,-10, found %d,%d", originI, originJ)
+		}
+		if nwffg.Rows() != 13 || nwffg.Cols() != 13 {
+			t.Errorf("Grid should have grown to 13x13, found %dx%d", nwffg.Rows(), nwffg.Cols())
+		}
+	}
+}
+
+func TestUnboundedHashLife(t *testing.T) {
+	g, _ := NewGol("Glider", "", "23/3", "dok", "unbounded", "unbounded", 3, 3, 0)
+	glider := [][]int{{0, 1}, {1, 0}, {2, 0}, {2, 1}, {2, 2}}
+	for _, cell := range glider {
+		g.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	expectedG := g.FastForward(100)
+
+	g.SetEngine(HashLifeEngine)
+	actualG := g.FastForward(100)
+	equalsError := actualG.EqualsError(expectedG)
+	if equalsError != nil {
+		t.Error(equalsError)
+	}
+}
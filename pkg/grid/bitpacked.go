@@ -0,0 +1,266 @@
+package grid
+
+import (
+	"fmt"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+const wordSize = 64
+
+// Bitpacked : a cell grid implemented as a bit matrix where each row
+// is stored in words of 64 cells. Only ALIVE and DEAD values are allowed.
+type Bitpacked struct {
+	words        []uint64
+	rows         int
+	cols         int
+	wordsPerRow  int
+	lastWordMask uint64
+}
+
+// NewBitpacked : creates a bit-packed grid
+func NewBitpacked(rows int, cols int) *Bitpacked {
+	b := new(Bitpacked)
+	b.rows = rows
+	b.cols = cols
+	b.wordsPerRow = (cols + wordSize - 1) / wordSize
+	b.words = make([]uint64, rows*b.wordsPerRow)
+	b.lastWordMask = ^uint64(0)
+	if cols%wordSize != 0 {
+		b.lastWordMask = (uint64(1) << uint(cols%wordSize)) - 1
+	}
+	return b
+}
+
+// Rows : return the number of rows of the grid
+func (b *Bitpacked) Rows() int {
+	return b.rows
+}
+
+// Cols : return the number of columns of the grid
+func (b *Bitpacked) Cols() int {
+	return b.cols
+}
+
+// Get : get the value of the cell (ALIVE, DEAD)
+//	in the i, j coordinates, or VOID if they are out of the grid
+func (b *Bitpacked) Get(i int, j int) int {
+	if checkIndexes(i, j, b.rows, b.cols) != nil {
+		return statuses.VOID
+	}
+	word := b.words[b.wordPos(i, j)]
+	if word&(uint64(1)<<uint(j%wordSize)) != 0 {
+		return statuses.ALIVE
+	}
+	return statuses.DEAD
+}
+
+// Set : set the value of the cell in the i, j coordinates
+func (b *Bitpacked) Set(i int, j int, value int) error {
+	if indexesError := checkIndexes(i, j, b.rows, b.cols); indexesError != nil {
+		return indexesError
+	}
+	if valueError := checkValue(value); valueError != nil {
+		return valueError
+	}
+	pos := b.wordPos(i, j)
+	bit := uint64(1) << uint(j%wordSize)
+	if value == statuses.ALIVE {
+		b.words[pos] |= bit
+	} else {
+		b.words[pos] &^= bit
+	}
+	return nil
+}
+
+// SetAll : set a value to all cells
+func (b *Bitpacked) SetAll(value int) error {
+	if valueError := checkValue(value); valueError != nil {
+		return valueError
+	}
+	var word uint64
+	if value == statuses.ALIVE {
+		word = ^uint64(0)
+	}
+	for i := 0; i < b.rows; i++ {
+		for w := 0; w < b.wordsPerRow; w++ {
+			b.words[i*b.wordsPerRow+w] = word
+		}
+		if b.wordsPerRow > 0 {
+			b.words[(i+1)*b.wordsPerRow-1] &= b.lastWordMask
+		}
+	}
+	return nil
+}
+
+// Equals : inform if two grids have the same cell value
+// for each position.
+func (b *Bitpacked) Equals(other CellsStorer) bool {
+	return b.EqualsError(other) == nil
+}
+
+// EqualsError : inform if two grids have the same dimensions and
+// the same cell values for each position.
+func (b *Bitpacked) EqualsError(o CellsStorer) error {
+	return EqualsError(b, o)
+}
+
+// EqualValues : check value by value if both
+// cell storers have the same values
+func (b *Bitpacked) EqualValues(o CellsStorer) bool {
+	return b.Equals(o)
+}
+
+// EqualValuesError : check value by value if both
+// cell storers have the same values. Return an error
+// if that's not the case
+func (b *Bitpacked) EqualValuesError(o CellsStorer) error {
+	return b.EqualsError(o)
+}
+
+// Clone : clone the grid in a new grid
+func (b *Bitpacked) Clone() CellsStorer {
+	clone := NewBitpacked(b.rows, b.cols)
+	copy(clone.words, b.words)
+	return clone
+}
+
+// CloneEmpty : create a new grid with the same size but empty
+func (b *Bitpacked) CloneEmpty() CellsStorer {
+	return NewBitpacked(b.rows, b.cols)
+}
+
+// nextGeneration : compute the next generation of a totalistic rule with
+// Moore neighborhood. The neighbors of 64 cells are counted at once by
+// adding the eight shifted neighbor words with bit-sliced adders.
+func (b *Bitpacked) nextGeneration(limitRows, limitCols bool, survivalRule, birthRule map[int]bool) *Bitpacked {
+	next := NewBitpacked(b.rows, b.cols)
+	if b.rows == 0 || b.cols == 0 {
+		return next
+	}
+
+	// Masks that select which counts make a cell alive
+	var survivalCounts, birthCounts [9]bool
+	for count := 0; count <= 8; count++ {
+		survivalCounts[count] = survivalRule[count]
+		birthCounts[count] = birthRule[count]
+	}
+
+	emptyRow := make([]uint64, b.wordsPerRow)
+	west := make([][]uint64, 3)
+	east := make([][]uint64, 3)
+	for r := 0; r < 3; r++ {
+		west[r] = make([]uint64, b.wordsPerRow)
+		east[r] = make([]uint64, b.wordsPerRow)
+	}
+	rowsAround := make([][]uint64, 3)
+
+	for i := 0; i < b.rows; i++ {
+		rowsAround[0] = b.neighborRow(i-1, limitRows, emptyRow)
+		rowsAround[1] = b.row(i)
+		rowsAround[2] = b.neighborRow(i+1, limitRows, emptyRow)
+		for r := 0; r < 3; r++ {
+			b.shiftRow(rowsAround[r], limitCols, west[r], east[r])
+		}
+		nextRow := next.row(i)
+		for w := 0; w < b.wordsPerRow; w++ {
+			neighbors := [8]uint64{
+				west[0][w], rowsAround[0][w], east[0][w],
+				west[1][w], east[1][w],
+				west[2][w], rowsAround[2][w], east[2][w],
+			}
+			// Bit-sliced counter: bit k of the count of each cell is in sK
+			var s0, s1, s2, s3 uint64
+			for _, neighbor := range neighbors {
+				c0 := s0 & neighbor
+				s0 ^= neighbor
+				c1 := s1 & c0
+				s1 ^= c0
+				c2 := s2 & c1
+				s2 ^= c1
+				s3 |= c2
+			}
+			alive := rowsAround[1][w]
+			var nextWord uint64
+			for count := 0; count <= 8; count++ {
+				if !survivalCounts[count] && !birthCounts[count] {
+					continue
+				}
+				countMask := bitplaneMatch(s0, count&1) & bitplaneMatch(s1, count&2) &
+					bitplaneMatch(s2, count&4) & bitplaneMatch(s3, count&8)
+				if survivalCounts[count] {
+					nextWord |= countMask & alive
+				}
+				if birthCounts[count] {
+					nextWord |= countMask &^ alive
+				}
+			}
+			nextRow[w] = nextWord
+		}
+		nextRow[b.wordsPerRow-1] &= b.lastWordMask
+	}
+	return next
+}
+
+// shiftRow : fill west (resp. east) with the words that contain, at the
+// position of each cell, the value of its west (resp. east) neighbor.
+func (b *Bitpacked) shiftRow(row []uint64, limitCols bool, west, east []uint64) {
+	last := b.wordsPerRow - 1
+	for w := 0; w <= last; w++ {
+		west[w] = row[w] << 1
+		if w > 0 {
+			west[w] |= row[w-1] >> (wordSize - 1)
+		}
+		east[w] = row[w] >> 1
+		if w < last {
+			east[w] |= row[w+1] << (wordSize - 1)
+		}
+	}
+	if !limitCols {
+		// Circular columns: first and last columns are neighbors
+		lastColBit := uint(b.cols-1) % wordSize
+		firstCol := row[0] & 1
+		lastCol := (row[last] >> lastColBit) & 1
+		west[0] |= lastCol
+		east[last] = (east[last] &^ (uint64(1) << lastColBit)) | (firstCol << lastColBit)
+	}
+}
+
+func (b *Bitpacked) neighborRow(i int, limitRows bool, emptyRow []uint64) []uint64 {
+	if i < 0 || i >= b.rows {
+		if limitRows {
+			return emptyRow
+		}
+		i = ((i % b.rows) + b.rows) % b.rows
+	}
+	return b.row(i)
+}
+
+func (b *Bitpacked) row(i int) []uint64 {
+	return b.words[i*b.wordsPerRow : (i+1)*b.wordsPerRow]
+}
+
+// checkValue : return an error if the value cannot be stored in one bit
+func checkValue(value int) error {
+	if value != statuses.ALIVE && value != statuses.DEAD {
+		return fmt.Errorf(
+			"%w: %d. Only %d (ALIVE) or %d (DEAD) are accepted",
+			ErrInvalidValue, value, statuses.ALIVE, statuses.DEAD,
+		)
+	}
+	return nil
+}
+
+// wordPos : get the position in the words array of the i, j coordinates
+func (b *Bitpacked) wordPos(i int, j int) int {
+	return i*b.wordsPerRow + j/wordSize
+}
+
+// bitplaneMatch : return the cells whose bit-plane value is equal
+// to the one expected (set if expectedBit is not 0)
+func bitplaneMatch(plane uint64, expectedBit int) uint64 {
+	if expectedBit != 0 {
+		return plane
+	}
+	return ^plane
+}
@@ -0,0 +1,141 @@
+package grid
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestNewBitpacked(t *testing.T) {
+	s := NewBitpacked(5, 70)
+	if s.Rows() != 5 {
+		t.Errorf("Invalid rows. Should be %d, found %d", 5, s.Rows())
+	}
+	if s.Cols() != 70 {
+		t.Errorf("Invalid cols. Should be %d, found %d", 70, s.Cols())
+	}
+}
+
+func TestBitpackedGetSet(t *testing.T) {
+	s := NewBitpacked(5, 70)
+	s.Set(1, 2, statuses.ALIVE)
+	s.Set(3, 65, statuses.ALIVE)
+	if s.Get(1, 2) != statuses.ALIVE {
+		t.Errorf("Invalid value. Should be %d, found %d", statuses.ALIVE, s.Get(1, 2))
+	}
+	if s.Get(3, 65) != statuses.ALIVE {
+		t.Errorf("Invalid value. Should be %d, found %d", statuses.ALIVE, s.Get(3, 65))
+	}
+	if s.Get(3, 64) != statuses.DEAD {
+		t.Errorf("Invalid value. Should be %d, found %d", statuses.DEAD, s.Get(3, 64))
+	}
+	s.Set(3, 65, statuses.DEAD)
+	if s.Get(3, 65) != statuses.DEAD {
+		t.Errorf("Invalid value. Should be %d, found %d", statuses.DEAD, s.Get(3, 65))
+	}
+}
+
+func TestBitpackedSetAll(t *testing.T) {
+	s := NewBitpacked(5, 70)
+	s.SetAll(statuses.ALIVE)
+	for i := 0; i < s.Rows(); i++ {
+		for j := 0; j < s.Cols(); j++ {
+			if s.Get(i, j) != statuses.ALIVE {
+				t.Errorf("Invalid value found at %d,%d. Should be %d, found %d", i, j, statuses.ALIVE, s.Get(i, j))
+			}
+		}
+	}
+	o := NewDense(5, 70)
+	o.SetAll(statuses.ALIVE)
+	if !s.Equals(o) {
+		t.Errorf("Should be equal")
+	}
+}
+
+func TestBitpackedSetInvalidValue(t *testing.T) {
+	s := NewBitpacked(5, 7)
+	setError := s.Set(1, 2, 8)
+	if !errors.Is(setError, ErrInvalidValue) {
+		t.Errorf("Expecting an invalid value error, found %v", setError)
+		return
+	}
+	if setError.Error() != "Invalid value: 8. Only 1 (ALIVE) or 0 (DEAD) are accepted" {
+		t.Errorf("Wrong error message: %s", setError)
+	}
+}
+
+func TestBitpackedSetOutOfRange(t *testing.T) {
+	s := NewBitpacked(5, 7)
+	setError := s.Set(5, 2, statuses.ALIVE)
+	if !errors.Is(setError, ErrIndexOutOfRange) {
+		t.Errorf("Expecting an index out of range error, found %v", setError)
+	}
+	if s.Get(5, 2) != statuses.VOID {
+		t.Errorf("Cells out of the grid should be VOID, found %d", s.Get(5, 2))
+	}
+}
+
+func TestBitpackedClone(t *testing.T) {
+	s := NewBitpacked(5, 7)
+	for i := 0; i < 5; i++ {
+		s.Set(i, i, statuses.ALIVE)
+	}
+	o := s.Clone()
+	equalsError := s.EqualsError(o)
+	if equalsError != nil {
+		t.Error(equalsError)
+		return
+	}
+	e := s.CloneEmpty()
+	if s.Equals(e) {
+		t.Errorf("Should be different")
+	}
+}
+
+func TestBitpackedNextGeneration(t *testing.T) {
+	survivalRule := map[int]bool{2: true, 3: true}
+	birthRule := map[int]bool{3: true}
+	for _, cols := range []int{1, 5, 63, 64, 65, 130} {
+		for _, limitation := range []string{"limited", "unlimited"} {
+			testBitpackedNextGeneration(t, 9, cols, limitation, survivalRule, birthRule)
+		}
+	}
+	// HighLife
+	testBitpackedNextGeneration(t, 20, 100, "limited", survivalRule, map[int]bool{3: true, 6: true})
+}
+
+func testBitpackedNextGeneration(t *testing.T, rows, cols int, limitation string, survivalRule, birthRule map[int]bool) {
+	random := rand.New(rand.NewSource(int64(rows * cols)))
+	g, _ := NewGrid(rows, cols, limitation, limitation, "bitpacked")
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			g.Set(i, j, random.Intn(2))
+		}
+	}
+
+	expected, _ := NewGrid(rows, cols, limitation, limitation, "dense")
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			aliveNeighborsCount := 0
+			for di := -1; di <= 1; di++ {
+				for dj := -1; dj <= 1; dj++ {
+					if (di != 0 || dj != 0) && g.Get(i+di, j+dj) == statuses.ALIVE {
+						aliveNeighborsCount++
+					}
+				}
+			}
+			if (g.Get(i, j) == statuses.ALIVE && survivalRule[aliveNeighborsCount]) ||
+				(g.Get(i, j) == statuses.DEAD && birthRule[aliveNeighborsCount]) {
+				expected.Set(i, j, statuses.ALIVE)
+			}
+		}
+	}
+
+	next := g.BitpackedNextGeneration(survivalRule, birthRule)
+	equalsError := expected.EqualsError(next, "values")
+	if equalsError != nil {
+		t.Errorf("%dx%d %s grid: %s", rows, cols, limitation, equalsError)
+	}
+}
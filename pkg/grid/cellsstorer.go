@@ -12,8 +12,8 @@ type CellsStorer interface {
 	Rows() int
 	Cols() int
 	Get(i int, j int) int
-	Set(i int, j int, value int)
-	SetAll(value int)
+	Set(i int, j int, value int) error
+	SetAll(value int) error
 	Equals(other CellsStorer) bool
 	EqualsError(other CellsStorer) error
 	EqualValues(other CellsStorer) bool
@@ -23,14 +23,32 @@ type CellsStorer interface {
 }
 
 // CellsStorerFactory : creates a new grid from type string
-func CellsStorerFactory(rows, cols int, gridType string) CellsStorer {
+func CellsStorerFactory(rows, cols int, gridType string) (CellsStorer, error) {
 	if strings.ToLower(gridType) == "dense" {
-		return NewDense(rows, cols)
+		return NewDense(rows, cols), nil
 	}
 	if strings.ToLower(gridType) == "dok" {
-		return NewDok(rows, cols, statuses.DEAD)
+		return NewDok(rows, cols, statuses.DEAD), nil
 	}
-	panic(fmt.Sprintf("Invalid grid type: %s. Only \"dense\" or \"dok\" are accepted as gridType values", gridType))
+	if strings.ToLower(gridType) == "bitpacked" {
+		return NewBitpacked(rows, cols), nil
+	}
+	return nil, fmt.Errorf(
+		"%w: %s. Only \"dense\", \"dok\" or \"bitpacked\" are accepted as gridType values",
+		ErrInvalidGridType, gridType,
+	)
+}
+
+// checkIndexes : return an error if the position is not legal
+// in a cell storage of rows x cols cells
+func checkIndexes(i, j, rows, cols int) error {
+	if i < 0 || i >= rows {
+		return fmt.Errorf("%w: row %d not in [0, %d]", ErrIndexOutOfRange, i, rows-1)
+	}
+	if j < 0 || j >= cols {
+		return fmt.Errorf("%w: col %d not in [0, %d]", ErrIndexOutOfRange, j, cols-1)
+	}
+	return nil
 }
 
 // EqualsError : inform if two grids have the same dimensions and
@@ -1,30 +1,36 @@
 package grid
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
 
 func TestCellsStorerFactory(t *testing.T) {
-	defer func() {
-		errorString := recover().(string)
-
-		if errorString != "Invalid grid type: whatever. Only \"dense\" or \"dok\" are accepted as gridType values" {
-			t.Errorf("Wrong panic message: %s", errorString)
-		}
-	}()
-
-	denseGrid := CellsStorerFactory(10, 20, "dense")
+	denseGrid, _ := CellsStorerFactory(10, 20, "dense")
 	denseGridType := reflect.TypeOf(denseGrid)
 	if denseGridType.String() != "*grid.Dense" {
 		t.Errorf("Expecting *grid.Dense struct, found %s", denseGridType.String())
 	}
 
-	dokGrid := CellsStorerFactory(100, 200, "dok")
+	dokGrid, _ := CellsStorerFactory(100, 200, "dok")
 	dokGridType := reflect.TypeOf(dokGrid)
 	if dokGridType.String() != "*grid.Dok" {
 		t.Errorf("Expecting *grid.Dok struct, found %s", dokGridType.String())
 	}
 
-	CellsStorerFactory(100, 200, "whatever")
+	bitpackedGrid, _ := CellsStorerFactory(100, 200, "bitpacked")
+	bitpackedGridType := reflect.TypeOf(bitpackedGrid)
+	if bitpackedGridType.String() != "*grid.Bitpacked" {
+		t.Errorf("Expecting *grid.Bitpacked struct, found %s", bitpackedGridType.String())
+	}
+
+	_, factoryError := CellsStorerFactory(100, 200, "whatever")
+	if !errors.Is(factoryError, ErrInvalidGridType) {
+		t.Errorf("Expecting an invalid grid type error, found %v", factoryError)
+		return
+	}
+	if factoryError.Error() != "Invalid grid type: whatever. Only \"dense\", \"dok\" or \"bitpacked\" are accepted as gridType values" {
+		t.Errorf("Wrong error message: %s", factoryError)
+	}
 }
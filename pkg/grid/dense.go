@@ -1,7 +1,7 @@
 package grid
 
 import (
-	"fmt"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 // Dense : a cell grid implemented as a dense matrix
@@ -31,25 +31,31 @@ func (d *Dense) Cols() int {
 }
 
 // Get : get the value of the cell (ALICE, DEAD)
-//	in the i, j coordinates
+//	in the i, j coordinates, or VOID if they are out of the grid
 func (d *Dense) Get(i int, j int) int {
-	d.assertIndexes(i, j)
+	if checkIndexes(i, j, d.rows, d.cols) != nil {
+		return statuses.VOID
+	}
 	pos := d.pos(i, j)
 	return d.cells[pos]
 }
 
 // Set : set the value of the cell in the i, j coordinates
-func (d *Dense) Set(i int, j int, value int) {
-	d.assertIndexes(i, j)
+func (d *Dense) Set(i int, j int, value int) error {
+	if indexesError := checkIndexes(i, j, d.rows, d.cols); indexesError != nil {
+		return indexesError
+	}
 	pos := d.pos(i, j)
 	d.cells[pos] = value
+	return nil
 }
 
 // SetAll : set a value to all ceels
-func (d *Dense) SetAll(value int) {
+func (d *Dense) SetAll(value int) error {
 	for i := 0; i < d.rows*d.cols; i++ {
 		d.cells[i] = value
 	}
+	return nil
 }
 
 // Equals : inform if two grids have the same cell value
@@ -91,16 +97,6 @@ func (d *Dense) CloneEmpty() CellsStorer {
 	return NewDense(d.rows, d.cols)
 }
 
-// assertIndexes : assert the position is legal in the cell storage
-func (d *Dense) assertIndexes(i, j int) {
-	if i < 0 || i >= d.rows {
-		panic(fmt.Sprintf("Invalid row index: %d not in [0, %d]", i, d.rows-1))
-	}
-	if j < 0 || j >= d.cols {
-		panic(fmt.Sprintf("Invalid col index: %d not in [0, %d]", j, d.cols-1))
-	}
-}
-
 // pos : get the position in the 1-D array of the i, j coordinates
 func (d *Dense) pos(i int, j int) int {
 	return i*d.cols + j
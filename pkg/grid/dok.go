@@ -3,6 +3,8 @@ package grid
 import (
 	"fmt"
 	"sync"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 type _Key struct {
@@ -45,9 +47,11 @@ func (dok *Dok) DefaultValue() int {
 }
 
 // Get : get the value of the cell (ALICE, DEAD)
-//	in the i, j coordinates
+//	in the i, j coordinates, or VOID if they are out of the grid
 func (dok *Dok) Get(i, j int) int {
-	dok.assertIndexes(i, j)
+	if checkIndexes(i, j, dok.rows, dok.cols) != nil {
+		return statuses.VOID
+	}
 	value, valueExists := dok.cells.Load(_Key{i, j})
 	if valueExists {
 		return value.(int)
@@ -56,19 +60,23 @@ func (dok *Dok) Get(i, j int) int {
 }
 
 // Set : set the value of the cell in the i, j coordinates
-func (dok *Dok) Set(i, j, value int) {
-	dok.assertIndexes(i, j)
+func (dok *Dok) Set(i, j, value int) error {
+	if indexesError := checkIndexes(i, j, dok.rows, dok.cols); indexesError != nil {
+		return indexesError
+	}
 	if value == dok.defaultValue {
 		dok.cells.Delete(_Key{i, j})
 	} else {
 		dok.cells.Store(_Key{i, j}, value)
 	}
+	return nil
 }
 
 // SetAll : set a value to all cells
-func (dok *Dok) SetAll(value int) {
+func (dok *Dok) SetAll(value int) error {
 	dok.defaultValue = value
 	dok.cells = new(sync.Map)
+	return nil
 }
 
 // Equals : inform if two grids have the same cell value
@@ -176,15 +184,6 @@ func (dok *Dok) CloneEmpty() CellsStorer {
 	return NewDok(dok.rows, dok.cols, dok.defaultValue)
 }
 
-func (dok *Dok) assertIndexes(i, j int) {
-	if i < 0 || i >= dok.rows {
-		panic(fmt.Sprintf("Invalid row index: %d not in [0, %d]", i, dok.rows-1))
-	}
-	if j < 0 || j >= dok.cols {
-		panic(fmt.Sprintf("Invalid col index: %d not in [0, %d]", j, dok.cols-1))
-	}
-}
-
 func (dok *Dok) equalDimensionsError(o CellsStorer) error {
 	oRows := o.Rows()
 	if dok.rows != oRows {
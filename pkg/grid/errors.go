@@ -0,0 +1,12 @@
+package grid
+
+import "errors"
+
+// ErrInvalidGridType : the type of cells storer is not one of the known ones
+var ErrInvalidGridType = errors.New("Invalid grid type")
+
+// ErrIndexOutOfRange : the position of a cell is outside of the grid
+var ErrIndexOutOfRange = errors.New("Index out of range")
+
+// ErrInvalidValue : the value cannot be stored in a cell of the grid
+var ErrInvalidValue = errors.New("Invalid value")
@@ -16,20 +16,28 @@ type Grid struct {
 	jIsOut    func(int) bool
 	limitRows bool
 	limitCols bool
+	unbounded bool
 }
 
-// NewGrid : creates a grid
-func NewGrid(rows, cols int, rowLimitation, colLimitation, cellsStorerType string) *Grid {
-	cs := CellsStorerFactory(rows, cols, cellsStorerType)
-	return newGridFromCellsStorer(rowLimitation, colLimitation, cs)
+// NewGrid : creates a grid.
+// If rowLimitation or colLimitation is "unbounded", the grid will be
+// an unbounded one (see Unbounded) and cellsStorerType will be ignored.
+func NewGrid(rows, cols int, rowLimitation, colLimitation, cellsStorerType string) (*Grid, error) {
+	cs, csError := newCellsStorer(rows, cols, rowLimitation, colLimitation, cellsStorerType)
+	if csError != nil {
+		return nil, csError
+	}
+	return newGridWithCellsStorer(rowLimitation, colLimitation, cs), nil
 }
 
 // NewRandomGrid : creates a grid
-func NewRandomGrid(rows, cols int, rowLimitation, colLimitation, cellsStorerType string, ramdomSeed int64) *Grid {
-	cs := CellsStorerFactory(rows, cols, cellsStorerType)
-	grid := newGridFromCellsStorer(rowLimitation, colLimitation, cs)
+func NewRandomGrid(rows, cols int, rowLimitation, colLimitation, cellsStorerType string, ramdomSeed int64) (*Grid, error) {
+	grid, gridError := NewGrid(rows, cols, rowLimitation, colLimitation, cellsStorerType)
+	if gridError != nil {
+		return nil, gridError
+	}
 	grid.Randomize(ramdomSeed)
-	return grid
+	return grid, nil
 }
 
 // Rows : return the number of rows of the grid
@@ -48,8 +56,11 @@ func (g *Grid) LimitRows() bool {
 }
 
 // LimitRowsString : inform if rows are limited or isn't
-// by returning the string "limited" or "unlimited"
+// by returning the string "limited", "unlimited" or "unbounded"
 func (g *Grid) LimitRowsString() string {
+	if g.unbounded {
+		return "unbounded"
+	}
 	if g.limitRows {
 		return "limited"
 	}
@@ -59,8 +70,12 @@ func (g *Grid) LimitRowsString() string {
 // SetLimitRows : limit or not limit rows.
 // If rows are not limited, it will be a circular-by-rows grid
 // i.e. if unlimited by rows, on reaching the rows + i column,
-// the ith row will be returned
+// the ith row will be returned.
+// Unbounded grids are not affected by this method.
 func (g *Grid) SetLimitRows(limitRows bool) {
+	if g.unbounded {
+		return
+	}
 	rows := g.Rows()
 	g.limitRows = limitRows
 	if g.limitRows {
@@ -78,8 +93,11 @@ func (g *Grid) LimitCols() bool {
 }
 
 // LimitColsString : inform if cols are limited or isn't
-// by returning the string "limited" or "unlimited"
+// by returning the string "limited", "unlimited" or "unbounded"
 func (g *Grid) LimitColsString() string {
+	if g.unbounded {
+		return "unbounded"
+	}
 	if g.limitCols {
 		return "limited"
 	}
@@ -89,8 +107,12 @@ func (g *Grid) LimitColsString() string {
 // SetLimitCols : limit or not limit cols.
 // If cols are not limited, it will be a circular-by-cols grid.
 // i.e. if unlimited by columns, on reaching the cols + i column,
-// the ith column will be returned
+// the ith column will be returned.
+// Unbounded grids are not affected by this method.
 func (g *Grid) SetLimitCols(limitCols bool) {
+	if g.unbounded {
+		return
+	}
 	cols := g.Cols()
 	g.limitCols = limitCols
 	if g.limitCols {
@@ -114,15 +136,15 @@ func (g *Grid) Get(i, j int) int {
 }
 
 // Set : set the value of the cell in the i, j coordinates
-func (g *Grid) Set(i, j, value int) {
+func (g *Grid) Set(i, j, value int) error {
 	actualI := g.i(i)
 	actualJ := g.j(j)
-	g.cells.Set(actualI, actualJ, value)
+	return g.cells.Set(actualI, actualJ, value)
 }
 
 // SetAll : set a value to all ceels
-func (g *Grid) SetAll(value int) {
-	g.cells.SetAll(value)
+func (g *Grid) SetAll(value int) error {
+	return g.cells.SetAll(value)
 }
 
 // Equals : inform if two grids have the same cell value
@@ -143,6 +165,9 @@ func (g *Grid) EqualsError(other *Grid, mode string) error {
 	if cellsEqualsError != nil {
 		return cellsEqualsError
 	}
+	if g.unbounded != other.unbounded {
+		return fmt.Errorf("Row limits are different: %s vs %s", g.LimitRowsString(), other.LimitRowsString())
+	}
 	if g.limitRows != other.limitRows {
 		return fmt.Errorf("Row limits are different: %s vs %s", g.LimitRowsString(), other.LimitRowsString())
 	}
@@ -154,16 +179,91 @@ func (g *Grid) EqualsError(other *Grid, mode string) error {
 
 // Clone : clone the grid in a new grid
 func (g *Grid) Clone() *Grid {
-	gridClone := newGridFromCellsStorer(g.LimitRowsString(), g.LimitColsString(), g.cells.Clone())
+	gridClone := newGridWithCellsStorer(g.LimitRowsString(), g.LimitColsString(), g.cells.Clone())
 	return gridClone
 }
 
 // CloneEmpty : create a new grid with the same size but empty
 func (g *Grid) CloneEmpty() *Grid {
-	gridEmptyClone := newGridFromCellsStorer(g.LimitRowsString(), g.LimitColsString(), g.cells.CloneEmpty())
+	gridEmptyClone := newGridWithCellsStorer(g.LimitRowsString(), g.LimitColsString(), g.cells.CloneEmpty())
 	return gridEmptyClone
 }
 
+// Unbounded : inform if the grid is unbounded, i.e. its coordinate space
+// is an infinite plane and it grows as the alive cells move outwards
+func (g *Grid) Unbounded() bool {
+	return g.unbounded
+}
+
+// Origin : return the absolute coordinates of the top-left cell of the grid.
+// Bounded grids have always their origin in 0, 0.
+func (g *Grid) Origin() (int, int) {
+	if g.unbounded {
+		return g.cells.(*Unbounded).Origin()
+	}
+	return 0, 0
+}
+
+// SetOrigin : move an unbounded grid so its top-left cell
+// is in the absolute coordinates i, j
+func (g *Grid) SetOrigin(i, j int) error {
+	if !g.unbounded {
+		if i == 0 && j == 0 {
+			return nil
+		}
+		return fmt.Errorf("%w: only unbounded grids can have their origin in %d,%d", ErrIndexOutOfRange, i, j)
+	}
+	g.cells.(*Unbounded).SetOrigin(i, j)
+	return nil
+}
+
+// Grow : add empty rows and columns around an unbounded grid.
+// The indexes of the existing cells are shifted by rowsBefore, colsBefore.
+// Bounded grids are not affected by this method.
+func (g *Grid) Grow(rowsBefore, colsBefore, rowsAfter, colsAfter int) {
+	if g.unbounded {
+		g.cells.(*Unbounded).Grow(rowsBefore, colsBefore, rowsAfter, colsAfter)
+	}
+}
+
+// Fit : shrink an unbounded grid to the smallest size that contains the
+// area it was created with and all its alive cells. Bounded grids are
+// not affected by this method.
+func (g *Grid) Fit() {
+	if g.unbounded {
+		g.cells.(*Unbounded).Fit()
+	}
+}
+
+// CellsStorerType : return the type of the cells storer of the grid
+// ("dense", "dok" or "bitpacked"), or "unbounded" for unbounded grids
+func (g *Grid) CellsStorerType() string {
+	switch g.cells.(type) {
+	case *Dense:
+		return "dense"
+	case *Bitpacked:
+		return "bitpacked"
+	case *Unbounded:
+		return "unbounded"
+	}
+	return "dok"
+}
+
+// IsBitpacked : inform if the cells of the grid are stored
+// in a bit-packed cells storer
+func (g *Grid) IsBitpacked() bool {
+	_, isBitpacked := g.cells.(*Bitpacked)
+	return isBitpacked
+}
+
+// BitpackedNextGeneration : compute the next generation of a bit-packed
+// grid for a totalistic rule with Moore neighborhood, processing
+// 64 cells at once. Survival and birth rules are sets of neighbors counts.
+func (g *Grid) BitpackedNextGeneration(survivalRule, birthRule map[int]bool) *Grid {
+	cells := g.cells.(*Bitpacked).nextGeneration(g.limitRows, g.limitCols, survivalRule, birthRule)
+	return newGridWithCellsStorer(g.LimitRowsString(), g.LimitColsString(), cells)
+}
+
 // Randomize : set each cell of the grid to a random (uniform) function
 //	according to randomSeed
 func (g *Grid) Randomize(randomSeed int64) {
@@ -178,21 +278,43 @@ func (g *Grid) Randomize(randomSeed int64) {
 	}
 }
 
-// NewGridFromCellsStorer : creates a grid
+// newGridFromCellsStorer : creates a grid with a copy of the cells storer
 func newGridFromCellsStorer(rowLimitation, colLimitation string, cells CellsStorer) *Grid {
+	return newGridWithCellsStorer(rowLimitation, colLimitation, cells.Clone())
+}
+
+// newGridWithCellsStorer : creates a grid that stores its cells in the
+// cells storer, that must not be shared (e.g. because it was just created)
+func newGridWithCellsStorer(rowLimitation, colLimitation string, cells CellsStorer) *Grid {
 	g := new(Grid)
-	if cells == nil {
-		panic(fmt.Sprintf("cells argument cannot be nil"))
+	g.cells = cells
+	if _, cellsAreUnbounded := g.cells.(*Unbounded); cellsAreUnbounded {
+		g.unbounded = true
+		g.i = func(i int) int { return i }
+		g.iIsOut = func(_ int) bool { return false }
+		g.j = func(j int) int { return j }
+		g.jIsOut = func(_ int) bool { return false }
+		return g
 	}
-	g.cells = cells.Clone()
 	g.SetLimitRows(rowLimitation == "limited")
 	g.SetLimitCols(colLimitation == "limited")
 	return g
 }
 
+// newCellsStorer : creates the cells storer of a grid
+func newCellsStorer(rows, cols int, rowLimitation, colLimitation, cellsStorerType string) (CellsStorer, error) {
+	if rowLimitation == "unbounded" || colLimitation == "unbounded" {
+		return NewUnbounded(rows, cols), nil
+	}
+	return CellsStorerFactory(rows, cols, cellsStorerType)
+}
+
 // NewRandomGridFromCellsStorer : creates a randomized grid
-func NewRandomGridFromCellsStorer(rowLimitation, colLimitation string, cells CellsStorer, ramdomSeed int64) *Grid {
+func NewRandomGridFromCellsStorer(rowLimitation, colLimitation string, cells CellsStorer, ramdomSeed int64) (*Grid, error) {
+	if cells == nil {
+		return nil, fmt.Errorf("%w: cells argument cannot be nil", ErrInvalidGridType)
+	}
 	grid := newGridFromCellsStorer(rowLimitation, colLimitation, cells)
 	grid.Randomize(ramdomSeed)
-	return grid
+	return grid, nil
 }
@@ -0,0 +1,206 @@
+package grid
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// Unbounded : a sparse cell grid over an infinite plane with signed coordinates.
+// Its rows and columns are those of the bounding box of the area the grid was
+// created with and all its alive cells, and cells are indexed relative to the
+// top-left corner of that bounding box (its origin).
+type Unbounded struct {
+	cells *sync.Map
+	// Absolute coordinates of the top-left cell of the bounding box
+	top  int
+	left int
+	rows int
+	cols int
+	// Area the grid was created with (absolute coordinates)
+	baseTop  int
+	baseLeft int
+	baseRows int
+	baseCols int
+}
+
+// NewUnbounded : create a new unbounded grid whose initial
+// bounding box has its top-left cell in the origin of coordinates
+func NewUnbounded(rows int, cols int) *Unbounded {
+	u := new(Unbounded)
+	u.cells = new(sync.Map)
+	u.rows = rows
+	u.cols = cols
+	u.baseRows = rows
+	u.baseCols = cols
+	return u
+}
+
+// Rows : return the number of rows of the bounding box
+func (u *Unbounded) Rows() int {
+	return u.rows
+}
+
+// Cols : return the number of columns of the bounding box
+func (u *Unbounded) Cols() int {
+	return u.cols
+}
+
+// Origin : return the absolute coordinates of the
+// top-left cell of the bounding box
+func (u *Unbounded) Origin() (int, int) {
+	return u.top, u.left
+}
+
+// SetOrigin : move the grid so its top-left cell is
+// in the absolute coordinates i, j
+func (u *Unbounded) SetOrigin(i, j int) {
+	di := i - u.top
+	dj := j - u.left
+	if di == 0 && dj == 0 {
+		return
+	}
+	moved := new(sync.Map)
+	u.cells.Range(func(key, value interface{}) bool {
+		k := key.(_Key)
+		moved.Store(_Key{k.i + di, k.j + dj}, value)
+		return true
+	})
+	u.cells = moved
+	u.top = i
+	u.left = j
+	u.baseTop += di
+	u.baseLeft += dj
+}
+
+// Get : get the value of the cell (ALIVE, DEAD)
+//	in the i, j coordinates (relative to the origin).
+//	Any coordinate is valid.
+func (u *Unbounded) Get(i, j int) int {
+	value, valueExists := u.cells.Load(_Key{u.top + i, u.left + j})
+	if valueExists {
+		return value.(int)
+	}
+	return statuses.DEAD
+}
+
+// Set : set the value of the cell in the i, j coordinates (relative to
+// the origin). Setting a non-dead cell outside the bounding box makes it
+// grow, and if that cell is above or at the left of the bounding box,
+// the origin is moved to it.
+func (u *Unbounded) Set(i, j, value int) error {
+	key := _Key{u.top + i, u.left + j}
+	if value == statuses.DEAD {
+		u.cells.Delete(key)
+		return nil
+	}
+	u.cells.Store(key, value)
+	u.include(key.i, key.j)
+	return nil
+}
+
+// SetAll : set a value to all cells of the bounding box
+func (u *Unbounded) SetAll(value int) error {
+	u.cells = new(sync.Map)
+	if value == statuses.DEAD {
+		return nil
+	}
+	for i := 0; i < u.rows; i++ {
+		for j := 0; j < u.cols; j++ {
+			u.cells.Store(_Key{u.top + i, u.left + j}, value)
+		}
+	}
+	return nil
+}
+
+// Grow : add empty rows and columns around the bounding box.
+// The origin is moved to the new top-left cell.
+func (u *Unbounded) Grow(rowsBefore, colsBefore, rowsAfter, colsAfter int) {
+	u.top -= rowsBefore
+	u.left -= colsBefore
+	u.rows += rowsBefore + rowsAfter
+	u.cols += colsBefore + colsAfter
+}
+
+// Fit : shrink the bounding box to the smallest one that contains
+// the area the grid was created with and all its alive cells.
+func (u *Unbounded) Fit() {
+	u.top = u.baseTop
+	u.left = u.baseLeft
+	u.rows = u.baseRows
+	u.cols = u.baseCols
+	u.cells.Range(func(key, value interface{}) bool {
+		k := key.(_Key)
+		u.include(k.i, k.j)
+		return true
+	})
+}
+
+// Equals : inform if two grids have the same cell value
+// for each position.
+func (u *Unbounded) Equals(o CellsStorer) bool {
+	return u.EqualsError(o) == nil
+}
+
+// EqualsError : inform if two grids have the same dimensions,
+// the same origin and the same cell values for each position.
+func (u *Unbounded) EqualsError(o CellsStorer) error {
+	other, otherIsUnbounded := o.(*Unbounded)
+	if otherIsUnbounded && (u.top != other.top || u.left != other.left) {
+		return fmt.Errorf("Origins are different: (%d,%d) vs (%d,%d)", u.top, u.left, other.top, other.left)
+	}
+	return EqualsError(u, o)
+}
+
+// EqualValues : check value by value if both
+// cell storers have the same values
+func (u *Unbounded) EqualValues(o CellsStorer) bool {
+	return u.Equals(o)
+}
+
+// EqualValuesError : check value by value if both
+// cell storers have the same values. Return an error
+// if that's not the case
+func (u *Unbounded) EqualValuesError(o CellsStorer) error {
+	return u.EqualsError(o)
+}
+
+// Clone : clone the grid in a new unbounded grid
+func (u *Unbounded) Clone() CellsStorer {
+	clone := u.cloneEmpty()
+	u.cells.Range(func(key, value interface{}) bool {
+		clone.cells.Store(key, value)
+		return true
+	})
+	return clone
+}
+
+// CloneEmpty : create a new grid with the same bounding box but empty
+func (u *Unbounded) CloneEmpty() CellsStorer {
+	return u.cloneEmpty()
+}
+
+func (u *Unbounded) cloneEmpty() *Unbounded {
+	clone := new(Unbounded)
+	*clone = *u
+	clone.cells = new(sync.Map)
+	return clone
+}
+
+// include : grow the bounding box to include the
+// cell at the absolute coordinates i, j
+func (u *Unbounded) include(i, j int) {
+	if i < u.top {
+		u.rows += u.top - i
+		u.top = i
+	} else if i >= u.top+u.rows {
+		u.rows = i - u.top + 1
+	}
+	if j < u.left {
+		u.cols += u.left - j
+		u.left = j
+	} else if j >= u.left+u.cols {
+		u.cols = j - u.left + 1
+	}
+}
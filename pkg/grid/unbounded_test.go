@@ -0,0 +1,147 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestNewUnbounded(t *testing.T) {
+	s := NewUnbounded(5, 7)
+	if s.Rows() != 5 {
+		t.Errorf("Invalid rows. Should be %d, found %d", 5, s.Rows())
+	}
+	if s.Cols() != 7 {
+		t.Errorf("Invalid cols. Should be %d, found %d", 7, s.Cols())
+	}
+	originI, originJ := s.Origin()
+	if originI != 0 || originJ != 0 {
+		t.Errorf("Invalid origin. Should be 0,0, found %d,%d", originI, originJ)
+	}
+}
+
+func TestUnboundedGrows(t *testing.T) {
+	s := NewUnbounded(5, 7)
+	s.Set(2, 2, statuses.ALIVE)
+	s.Set(10, 20, statuses.ALIVE)
+	if s.Rows() != 11 || s.Cols() != 21 {
+		t.Errorf("Invalid size. Should be 11x21, found %dx%d", s.Rows(), s.Cols())
+	}
+	// Cells above and at the left of the grid move the origin
+	s.Set(-3, -4, statuses.ALIVE)
+	originI, originJ := s.Origin()
+	if originI != -3 || originJ != -4 {
+		t.Errorf("Invalid origin. Should be -3,-4, found %d,%d", originI, originJ)
+	}
+	if s.Rows() != 14 || s.Cols() != 25 {
+		t.Errorf("Invalid size. Should be 14x25, found %dx%d", s.Rows(), s.Cols())
+	}
+	if s.Get(0, 0) != statuses.ALIVE || s.Get(5, 6) != statuses.ALIVE || s.Get(13, 24) != statuses.ALIVE {
+		t.Errorf("Cells should be alive after moving the origin")
+	}
+	if s.Get(-100, 1000) != statuses.DEAD {
+		t.Errorf("Cells outside the grid should be dead")
+	}
+
+	// Only the created area and the alive cells are kept
+	s.Set(0, 0, statuses.DEAD)
+	s.Set(13, 24, statuses.DEAD)
+	s.Fit()
+	originI, originJ = s.Origin()
+	if originI != 0 || originJ != 0 {
+		t.Errorf("Invalid origin. Should be 0,0, found %d,%d", originI, originJ)
+	}
+	if s.Rows() != 5 || s.Cols() != 7 {
+		t.Errorf("Invalid size. Should be 5x7, found %dx%d", s.Rows(), s.Cols())
+	}
+	if s.Get(2, 2) != statuses.ALIVE {
+		t.Errorf("Cell 2,2 should be alive")
+	}
+}
+
+func TestUnboundedGrow(t *testing.T) {
+	s := NewUnbounded(5, 7)
+	s.Set(0, 0, statuses.ALIVE)
+	s.Grow(1, 2, 3, 4)
+	originI, originJ := s.Origin()
+	if originI != -1 || originJ != -2 {
+		t.Errorf("Invalid origin. Should be -1,-2, found %d,%d", originI, originJ)
+	}
+	if s.Rows() != 9 || s.Cols() != 13 {
+		t.Errorf("Invalid size. Should be 9x13, found %dx%d", s.Rows(), s.Cols())
+	}
+	if s.Get(1, 2) != statuses.ALIVE {
+		t.Errorf("Cell 1,2 should be alive")
+	}
+}
+
+func TestUnboundedSetOrigin(t *testing.T) {
+	s := NewUnbounded(5, 7)
+	s.Set(1, 1, statuses.ALIVE)
+	o := s.Clone()
+	if !s.Equals(o) {
+		t.Errorf("Should be equal")
+	}
+	s.SetOrigin(-10, 10)
+	if s.Get(1, 1) != statuses.ALIVE {
+		t.Errorf("Cell 1,1 should be alive")
+	}
+	expectedErrorString := "Origins are different: (-10,10) vs (0,0)"
+	equalsError := s.EqualsError(o)
+	if equalsError == nil || equalsError.Error() != expectedErrorString {
+		t.Errorf("Expected error: \"%s\". Found: \"%v\"", expectedErrorString, equalsError)
+	}
+	// The created area moves with the grid
+	s.Fit()
+	if s.Rows() != 5 || s.Cols() != 7 {
+		t.Errorf("Invalid size. Should be 5x7, found %dx%d", s.Rows(), s.Cols())
+	}
+	originI, originJ := s.Origin()
+	if originI != -10 || originJ != 10 {
+		t.Errorf("Invalid origin. Should be -10,10, found %d,%d", originI, originJ)
+	}
+}
+
+func TestUnboundedGrid(t *testing.T) {
+	g, _ := NewGrid(5, 5, "unbounded", "unbounded", "dense")
+	if !g.Unbounded() {
+		t.Errorf("Grid should be unbounded")
+	}
+	if g.LimitRows() || g.LimitCols() {
+		t.Errorf("Unbounded grids are not limited")
+	}
+	if g.LimitRowsString() != "unbounded" || g.LimitColsString() != "unbounded" {
+		t.Errorf("Limits should be unbounded, found %s and %s", g.LimitRowsString(), g.LimitColsString())
+	}
+	if g.Get(-1, 5) != statuses.DEAD {
+		t.Errorf("Cells outside the grid should be dead, found %d", g.Get(-1, 5))
+	}
+	g.Set(7, 0, statuses.ALIVE)
+	clone := g.Clone()
+	if !clone.Unbounded() || clone.Rows() != 8 {
+		t.Errorf("Clone should be an unbounded grid with 8 rows")
+	}
+}
+
+func TestGridGrow(t *testing.T) {
+	g, _ := NewGrid(5, 5, "unbounded", "unbounded", "dense")
+	g.Set(0, 0, statuses.ALIVE)
+	g.Grow(1, 2, 3, 4)
+	if g.Rows() != 9 || g.Cols() != 11 {
+		t.Errorf("Invalid size. Should be 9x11, found %dx%d", g.Rows(), g.Cols())
+	}
+	if g.Get(1, 2) != statuses.ALIVE {
+		t.Errorf("Cell 1,2 should be alive")
+	}
+
+	// Bounded grids are not affected
+	for _, limitation := range []string{"limited", "unlimited"} {
+		g, _ := NewGrid(5, 5, limitation, limitation, "dense")
+		g.Set(0, 0, statuses.ALIVE)
+		g.Grow(1, 2, 3, 4)
+		g.Fit()
+		if g.Rows() != 5 || g.Cols() != 5 || g.Get(0, 0) != statuses.ALIVE {
+			t.Errorf("The %s grid should not grow, found %dx%d", limitation, g.Rows(), g.Cols())
+		}
+	}
+}
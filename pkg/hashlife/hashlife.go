@@ -0,0 +1,328 @@
+package hashlife
+
+import (
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// node : a square quadtree node of side 2^level.
+// Nodes are canonical (hash-consed), so two nodes with the same
+// content are the same pointer and can be used as map keys.
+type node struct {
+	nw         *node
+	ne         *node
+	sw         *node
+	se         *node
+	level      int
+	population int
+}
+
+type nodeKey struct {
+	nw *node
+	ne *node
+	sw *node
+	se *node
+}
+
+type successorKey struct {
+	n    *node
+	step int
+}
+
+// Universe : an unbounded plane of cells stored as a hash-consed
+// quadtree with memoized macro-cell successors.
+// See https://www.conwaylife.com/wiki/HashLife
+type Universe struct {
+	root         *node
+	dead         *node
+	alive        *node
+	nodes        map[nodeKey]*node
+	empties      []*node
+	successors   map[successorKey]*node
+	survivalRule map[int]bool
+	birthRule    map[int]bool
+	generation   int
+}
+
+// NewUniverse : creates an empty universe that will evolve according
+// to the survival and birth rules passed as sets of neighbors counts
+// (Moore neighborhood).
+func NewUniverse(survivalRule, birthRule map[int]bool) *Universe {
+	u := new(Universe)
+	u.dead = &node{level: 0, population: 0}
+	u.alive = &node{level: 0, population: 1}
+	u.nodes = make(map[nodeKey]*node)
+	u.empties = []*node{u.dead}
+	u.successors = make(map[successorKey]*node)
+	u.survivalRule = survivalRule
+	u.birthRule = birthRule
+	u.root = u.empty(3)
+	return u
+}
+
+// Generation : return the number of generations this universe has advanced
+func (u *Universe) Generation() int {
+	return u.generation
+}
+
+// Population : return the number of alive cells in the universe
+func (u *Universe) Population() int {
+	return u.root.population
+}
+
+// Get : get the value of the cell (ALIVE, DEAD) in the i, j coordinates.
+// Coordinates can be negative.
+func (u *Universe) Get(i, j int) int {
+	n := u.root
+	half := 1 << (n.level - 1)
+	if i < -half || i >= half || j < -half || j >= half {
+		return statuses.DEAD
+	}
+	top := -half
+	left := -half
+	for n.level > 0 {
+		if n.population == 0 {
+			return statuses.DEAD
+		}
+		half = 1 << (n.level - 1)
+		south := i >= top+half
+		east := j >= left+half
+		if south {
+			top += half
+		}
+		if east {
+			left += half
+		}
+		n = n.child(south, east)
+	}
+	if n == u.alive {
+		return statuses.ALIVE
+	}
+	return statuses.DEAD
+}
+
+// Set : set the value of the cell (ALIVE, DEAD) in the i, j coordinates.
+// The universe grows as needed to contain the cell.
+func (u *Universe) Set(i, j, value int) {
+	for {
+		half := 1 << (u.root.level - 1)
+		if i >= -half && i < half && j >= -half && j < half {
+			break
+		}
+		u.root = u.expand(u.root)
+	}
+	half := 1 << (u.root.level - 1)
+	u.root = u.set(u.root, -half, -half, i, j, value == statuses.ALIVE)
+}
+
+// Each : call f for each alive cell of the universe
+func (u *Universe) Each(f func(i, j int)) {
+	half := 1 << (u.root.level - 1)
+	u.each(u.root, -half, -half, f)
+}
+
+// Advance : move the universe forward a number of generations.
+// The number of generations is decomposed in powers of two so
+// each one of them can be jumped over in one macro-cell step.
+func (u *Universe) Advance(generations int) {
+	for step := 0; generations > 0; step++ {
+		if generations&1 == 1 {
+			u.advancePowerOfTwo(step)
+		}
+		generations >>= 1
+	}
+}
+
+// advancePowerOfTwo : move the universe forward 2^step generations
+func (u *Universe) advancePowerOfTwo(step int) {
+	if u.root.population == 0 {
+		u.generation += 1 << step
+		return
+	}
+	// The successor of a node is its centre, so the pattern must be
+	// far enough from the borders to not be affected by them
+	for u.root.level < step+3 || !u.isPadded(u.root) {
+		u.root = u.expand(u.root)
+	}
+	u.root = u.successor(u.root, step)
+	u.generation += 1 << step
+}
+
+// isPadded : inform if all alive cells of the node are in the
+// central sixteenth of it
+func (u *Universe) isPadded(n *node) bool {
+	centre := u.centre(u.centre(n))
+	return centre.population == n.population
+}
+
+func (u *Universe) centre(n *node) *node {
+	return u.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw)
+}
+
+// expand : create a node of level+1 with the node n in its centre
+func (u *Universe) expand(n *node) *node {
+	e := u.empty(n.level - 1)
+	return u.join(
+		u.join(e, e, e, n.nw),
+		u.join(e, e, n.ne, e),
+		u.join(e, n.sw, e, e),
+		u.join(n.se, e, e, e),
+	)
+}
+
+// join : return the canonical node with these four quadrants
+func (u *Universe) join(nw, ne, sw, se *node) *node {
+	key := nodeKey{nw, ne, sw, se}
+	if n, exists := u.nodes[key]; exists {
+		return n
+	}
+	n := &node{
+		nw: nw, ne: ne, sw: sw, se: se,
+		level:      nw.level + 1,
+		population: nw.population + ne.population + sw.population + se.population,
+	}
+	u.nodes[key] = n
+	return n
+}
+
+// empty : return the canonical empty node of a level
+func (u *Universe) empty(level int) *node {
+	for len(u.empties) <= level {
+		e := u.empties[len(u.empties)-1]
+		u.empties = append(u.empties, u.join(e, e, e, e))
+	}
+	return u.empties[level]
+}
+
+func (u *Universe) set(n *node, top, left, i, j int, alive bool) *node {
+	if n.level == 0 {
+		if alive {
+			return u.alive
+		}
+		return u.dead
+	}
+	half := 1 << (n.level - 1)
+	nw, ne, sw, se := n.nw, n.ne, n.sw, n.se
+	if i < top+half {
+		if j < left+half {
+			nw = u.set(nw, top, left, i, j, alive)
+		} else {
+			ne = u.set(ne, top, left+half, i, j, alive)
+		}
+	} else {
+		if j < left+half {
+			sw = u.set(sw, top+half, left, i, j, alive)
+		} else {
+			se = u.set(se, top+half, left+half, i, j, alive)
+		}
+	}
+	return u.join(nw, ne, sw, se)
+}
+
+func (u *Universe) each(n *node, top, left int, f func(i, j int)) {
+	if n.population == 0 {
+		return
+	}
+	if n.level == 0 {
+		f(top, left)
+		return
+	}
+	half := 1 << (n.level - 1)
+	u.each(n.nw, top, left, f)
+	u.each(n.ne, top, left+half, f)
+	u.each(n.sw, top+half, left, f)
+	u.each(n.se, top+half, left+half, f)
+}
+
+// successor : return the centre of the node n (i.e. a node of level-1)
+// advanced 2^step generations. step must be at most level-2.
+func (u *Universe) successor(n *node, step int) *node {
+	if n.population == 0 {
+		return n.nw
+	}
+	key := successorKey{n, step}
+	if result, exists := u.successors[key]; exists {
+		return result
+	}
+
+	var result *node
+	if n.level == 2 {
+		result = u.nextGeneration4x4(n)
+	} else {
+		// Nine overlapping sub-nodes of level-1
+		c1 := u.successor(u.join(n.nw.nw, n.nw.ne, n.nw.sw, n.nw.se), step)
+		c2 := u.successor(u.join(n.nw.ne, n.ne.nw, n.nw.se, n.ne.sw), step)
+		c3 := u.successor(u.join(n.ne.nw, n.ne.ne, n.ne.sw, n.ne.se), step)
+		c4 := u.successor(u.join(n.nw.sw, n.nw.se, n.sw.nw, n.sw.ne), step)
+		c5 := u.successor(u.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw), step)
+		c6 := u.successor(u.join(n.ne.sw, n.ne.se, n.se.nw, n.se.ne), step)
+		c7 := u.successor(u.join(n.sw.nw, n.sw.ne, n.sw.sw, n.sw.se), step)
+		c8 := u.successor(u.join(n.sw.ne, n.se.nw, n.sw.se, n.se.sw), step)
+		c9 := u.successor(u.join(n.se.nw, n.se.ne, n.se.sw, n.se.se), step)
+		if step < n.level-2 {
+			// The nine sub-nodes have already advanced 2^step generations,
+			// only their centres must be stitched together
+			result = u.join(
+				u.join(c1.se, c2.sw, c4.ne, c5.nw),
+				u.join(c2.se, c3.sw, c5.ne, c6.nw),
+				u.join(c4.se, c5.sw, c7.ne, c8.nw),
+				u.join(c5.se, c6.sw, c8.ne, c9.nw),
+			)
+		} else {
+			// Full speed: two half-steps of 2^(level-3) generations each
+			result = u.join(
+				u.successor(u.join(c1, c2, c4, c5), step),
+				u.successor(u.join(c2, c3, c5, c6), step),
+				u.successor(u.join(c4, c5, c7, c8), step),
+				u.successor(u.join(c5, c6, c8, c9), step),
+			)
+		}
+	}
+	u.successors[key] = result
+	return result
+}
+
+// nextGeneration4x4 : compute the 2x2 centre of a 4x4 node
+// after one generation
+func (u *Universe) nextGeneration4x4(n *node) *node {
+	var cells [4][4]bool
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			quadrant := n.child(i >= 2, j >= 2)
+			cells[i][j] = quadrant.child(i%2 == 1, j%2 == 1) == u.alive
+		}
+	}
+	nextCell := func(i, j int) *node {
+		aliveNeighborsCount := 0
+		for di := -1; di <= 1; di++ {
+			for dj := -1; dj <= 1; dj++ {
+				if (di != 0 || dj != 0) && cells[i+di][j+dj] {
+					aliveNeighborsCount++
+				}
+			}
+		}
+		if cells[i][j] {
+			if u.survivalRule[aliveNeighborsCount] {
+				return u.alive
+			}
+			return u.dead
+		}
+		if u.birthRule[aliveNeighborsCount] {
+			return u.alive
+		}
+		return u.dead
+	}
+	return u.join(nextCell(1, 1), nextCell(1, 2), nextCell(2, 1), nextCell(2, 2))
+}
+
+func (n *node) child(south, east bool) *node {
+	if south {
+		if east {
+			return n.se
+		}
+		return n.sw
+	}
+	if east {
+		return n.ne
+	}
+	return n.nw
+}
@@ -0,0 +1,93 @@
+package hashlife
+
+import (
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func newConwayUniverse() *Universe {
+	return NewUniverse(map[int]bool{2: true, 3: true}, map[int]bool{3: true})
+}
+
+func TestUniverseGetSet(t *testing.T) {
+	u := newConwayUniverse()
+	u.Set(-100, 250, statuses.ALIVE)
+	u.Set(3, -7, statuses.ALIVE)
+	if u.Get(-100, 250) != statuses.ALIVE {
+		t.Errorf("Cell -100,250 should be alive")
+	}
+	if u.Get(3, -7) != statuses.ALIVE {
+		t.Errorf("Cell 3,-7 should be alive")
+	}
+	if u.Get(0, 0) != statuses.DEAD {
+		t.Errorf("Cell 0,0 should be dead")
+	}
+	if u.Population() != 2 {
+		t.Errorf("Population should be 2, found %d", u.Population())
+	}
+	u.Set(3, -7, statuses.DEAD)
+	if u.Population() != 1 {
+		t.Errorf("Population should be 1, found %d", u.Population())
+	}
+}
+
+func TestUniverseStillLife(t *testing.T) {
+	u := newConwayUniverse()
+	block := [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	for _, cell := range block {
+		u.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	u.Advance(1 << 20)
+	if u.Generation() != 1<<20 {
+		t.Errorf("Generation should be %d, found %d", 1<<20, u.Generation())
+	}
+	if u.Population() != 4 {
+		t.Errorf("Population should be 4, found %d", u.Population())
+	}
+	for _, cell := range block {
+		if u.Get(cell[0], cell[1]) != statuses.ALIVE {
+			t.Errorf("Cell %d,%d should be alive", cell[0], cell[1])
+		}
+	}
+}
+
+func TestUniverseGlider(t *testing.T) {
+	u := newConwayUniverse()
+	glider := [][]int{{0, 1}, {1, 2}, {2, 0}, {2, 1}, {2, 2}}
+	for _, cell := range glider {
+		u.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	// A glider moves one cell diagonally every 4 generations
+	generations := 4 * 1001
+	u.Advance(generations)
+	if u.Population() != len(glider) {
+		t.Errorf("Population should be %d, found %d", len(glider), u.Population())
+	}
+	for _, cell := range glider {
+		i := cell[0] + generations/4
+		j := cell[1] + generations/4
+		if u.Get(i, j) != statuses.ALIVE {
+			t.Errorf("Cell %d,%d should be alive", i, j)
+		}
+	}
+}
+
+func TestUniverseEach(t *testing.T) {
+	u := newConwayUniverse()
+	blinker := [][]int{{5, 4}, {5, 5}, {5, 6}}
+	for _, cell := range blinker {
+		u.Set(cell[0], cell[1], statuses.ALIVE)
+	}
+	u.Advance(1)
+	expectedCells := map[[2]int]bool{{4, 5}: true, {5, 5}: true, {6, 5}: true}
+	u.Each(func(i, j int) {
+		if !expectedCells[[2]int{i, j}] {
+			t.Errorf("Cell %d,%d should not be alive", i, j)
+		}
+		delete(expectedCells, [2]int{i, j})
+	})
+	if len(expectedCells) > 0 {
+		t.Errorf("Cells %v should be alive", expectedCells)
+	}
+}
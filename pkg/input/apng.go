@@ -0,0 +1,57 @@
+package input
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/kettek/apng"
+)
+
+// decodeApngFrames : create a Game of life instance for each frame of an
+// APNG animation (the default image is ignored if it is not a frame). The
+// frames are drawn over the previous ones following their blend and
+// disposal operations, as they can only have the pixels that change.
+func (gr *GolReader) decodeApngFrames(animation apng.APNG, source string, gconf *base.GolConf) ([]base.GolInterface, error) {
+	frames := animation.Frames
+	if len(frames) < 1 || frames[0].Image == nil {
+		return nil, fmt.Errorf("The APNG image has no frames")
+	}
+	// The default image has the size of the whole animation
+	canvasBounds := frames[0].Image.Bounds()
+	if frames[0].IsDefault && len(frames) > 1 {
+		frames = frames[1:]
+	}
+	canvas := image.NewRGBA(canvasBounds)
+	images := make([]image.Image, 0, len(frames))
+	for _, frame := range frames {
+		if frame.Image == nil {
+			return nil, fmt.Errorf("The APNG image has frames without data")
+		}
+		frameImageBounds := frame.Image.Bounds()
+		frameBounds := frameImageBounds.Sub(frameImageBounds.Min).Add(image.Pt(frame.XOffset, frame.YOffset))
+		var previousCanvas *image.RGBA
+		if frame.DisposeOp == apng.DISPOSE_OP_PREVIOUS {
+			previousCanvas = image.NewRGBA(canvasBounds)
+			draw.Draw(previousCanvas, canvasBounds, canvas, canvasBounds.Min, draw.Src)
+		}
+		op := draw.Over
+		if frame.BlendOp == apng.BLEND_OP_SOURCE {
+			op = draw.Src
+		}
+		draw.Draw(canvas, frameBounds, frame.Image, frameImageBounds.Min, op)
+
+		frameImage := image.NewRGBA(canvasBounds)
+		draw.Draw(frameImage, canvasBounds, canvas, canvasBounds.Min, draw.Src)
+		images = append(images, frameImage)
+
+		switch frame.DisposeOp {
+		case apng.DISPOSE_OP_BACKGROUND:
+			draw.Draw(canvas, frameBounds, image.Transparent, image.Point{}, draw.Src)
+		case apng.DISPOSE_OP_PREVIOUS:
+			canvas = previousCanvas
+		}
+	}
+	return gr.decodeImages(images, source, gconf)
+}
@@ -3,12 +3,11 @@ package input
 import (
 	"bufio"
 	"io"
-	"os"
 )
 
 type fileReader struct {
 	currentLine *string
-	file        *os.File
+	file        io.ReadSeeker
 	reader      *bufio.Reader
 }
 
@@ -38,6 +37,6 @@ func (fr *fileReader) SeekStart() {
 	fr.currentLine = nil
 }
 
-func newFileReader(file *os.File) *fileReader {
+func newFileReader(file io.ReadSeeker) *fileReader {
 	return &fileReader{nil, file, bufio.NewReader(file)}
 }
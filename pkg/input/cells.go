@@ -1,9 +1,10 @@
 package input
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"os"
+	"io/ioutil"
 	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
@@ -12,14 +13,22 @@ import (
 
 // ReadCellsFile : create a new Game of life from a .cells file
 func (gr *GolReader) ReadCellsFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
-	file, fileError := os.Open(filename)
-	defer file.Close()
-
+	file, fileError := base.OpenFile(filename)
 	if fileError != nil {
 		return nil, fileError
 	}
+	defer file.Close()
+	return gr.decodeCells(file, gconf)
+}
 
-	reader := newCellsReader(file)
+// decodeCells : create a new Game of life from a .cells stream
+func (gr *GolReader) decodeCells(r io.Reader, gconf *base.GolConf) (base.GolInterface, error) {
+	// The grid is read once its dimensions are known
+	content, contentError := ioutil.ReadAll(r)
+	if contentError != nil {
+		return nil, contentError
+	}
+	reader := newCellsReader(bytes.NewReader(content))
 
 	// Name of the GOL pattern
 	name, nameError := reader.readName()
@@ -45,7 +54,9 @@ func (gr *GolReader) ReadCellsFile(filename string, gconf *base.GolConf) (base.G
 		gconf = base.NewDefaultGolConf()
 	}
 
-	g.InitFromConf(name, description, rows, cols, gconf)
+	if initError := g.InitFromConf(name, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
 
 	gridError := reader.readGrid(rows, cols, g)
 	if gridError != nil {
@@ -144,13 +155,15 @@ func (r *cellsReader) readGrid(rows, cols int, g base.GolInterface) error {
 			if !cellValueOK {
 				return fmt.Errorf("Value %s in the cell %d,%d is not a valid one. Only \".\" or \"O\" values are allowed", cellIJ, i, j)
 			}
-			g.Set(i, j, cellValue)
+			if setError := g.Set(i, j, cellValue); setError != nil {
+				return setError
+			}
 		}
 		r.readLine()
 	}
 	return nil
 }
 
-func newCellsReader(file *os.File) *cellsReader {
-	return &cellsReader{newFileReader(file)}
+func newCellsReader(reader io.ReadSeeker) *cellsReader {
+	return &cellsReader{newFileReader(reader)}
 }
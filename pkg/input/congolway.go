@@ -3,26 +3,30 @@ package input
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 // ReadCongolwayFile : create a new Game of life from a text file
 func (gr *GolReader) ReadCongolwayFile(filename string) (base.GolInterface, error) {
-	file, err := os.Open(filename)
-	defer file.Close()
-
+	file, err := base.OpenFile(filename)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
+	return gr.decodeCongolway(file)
+}
 
-	reader := bufio.NewReader(file)
+// decodeCongolway : create a new Game of life from a Congolway stream
+func (gr *GolReader) decodeCongolway(r io.Reader) (base.GolInterface, error) {
+	reader := bufio.NewReader(r)
 
 	// Read CONGOLWAY header line
 	congolwayHeaderLine, congolwayHeaderLineError := gr.readCongolwayFileLine(reader)
@@ -58,6 +62,9 @@ func (gr *GolReader) ReadCongolwayFile(filename string) (base.GolInterface, erro
 	if version == 1 {
 		return gr.readCongolwayFileV1(reader)
 	}
+	if version == 2 {
+		return gr.readCongolwayFileV2(reader)
+	}
 
 	return nil, fmt.Errorf("Unknonwn version found %d", version)
 }
@@ -98,19 +105,11 @@ func (gr *GolReader) readCongolwayFileV1(reader *bufio.Reader) (base.GolInterfac
 	if rulesLineError != nil {
 		return nil, rulesLineError
 	}
-	rulesLineMatch, rulesLineMatchError := regexp.MatchString(`rules:\s*\d+/\d+`, rulesLine)
-	if rulesLineMatchError != nil {
-		return nil, rulesLineMatchError
-	}
-	if !rulesLineMatch {
-		return nil, fmt.Errorf("rules: survival/birth, found %s", rulesLine)
+	rulesLineRegex := regexp.MustCompile(`^rules:\s*(.+)$`)
+	rulesLineMatches := rulesLineRegex.FindStringSubmatch(rulesLine)
+	if rulesLineMatches == nil {
+		return nil, fmt.Errorf("rules: B/S or S/B rulestring expected, found %s", rulesLine)
 	}
-	rulesPrefixRegex := regexp.MustCompile(`\d+/\d+`)
-	rulesMatches := rulesPrefixRegex.FindAllString(rulesLine, -1)
-	if len(rulesMatches) != 1 {
-		return nil, fmt.Errorf("rules: survival/birth invalid match. Found %s", rulesLine)
-	}
-	rules := rulesMatches[0]
 
 	// Generation
 	generationOccurences, generationError := gr.readTextFileLine(
@@ -136,9 +135,27 @@ func (gr *GolReader) readCongolwayFileV1(reader *bufio.Reader) (base.GolInterfac
 	if neighLine == "neighborhood_type: Moore" {
 		neighborhoodType = neighborhood.MOORE
 	} else if neighLine == "neighborhood type: Von Neumann" {
+		// Line expected by older versions
 		neighborhoodType = neighborhood.VONNEUMANN
+	} else if strings.HasPrefix(neighLine, "neighborhood_type: ") {
+		var neighborhoodTypeError error
+		neighborhoodType, neighborhoodTypeError = neighborhood.TypeFromString(
+			strings.TrimSpace(strings.TrimPrefix(neighLine, "neighborhood_type: ")),
+		)
+		if neighborhoodTypeError != nil {
+			return nil, neighborhoodTypeError
+		}
 	} else {
-		return nil, fmt.Errorf("\"neighborhood_type: Moore\" or \"neighborhood_type: Von Neumman\", found %s", neighLine)
+		return nil, fmt.Errorf(
+			"\"neighborhood_type: Moore\", \"Von Neumann\", \"Hexagonal\" or \"Triangular\" expected, found %s", neighLine,
+		)
+	}
+
+	// The rules are parsed once the neighborhood is known,
+	// as it sets the maximum number of neighbors of the conditions
+	rule, ruleError := rules.ParseForNeighborhood(rulesLineMatches[1], neighborhoodType)
+	if ruleError != nil {
+		return nil, ruleError
 	}
 
 	// Read dimensions of the grid
@@ -175,16 +192,22 @@ func (gr *GolReader) readCongolwayFileV1(reader *bufio.Reader) (base.GolInterfac
 	if len(colsLimitationMatches) > 0 {
 		colLimitation = "limited"
 	}
+	if strings.Contains(limits[0], "unbounded") {
+		rowLimitation = "unbounded"
+		colLimitation = "unbounded"
+	}
 
 	gconf := base.NewGolConf(
 		map[string]interface{}{
-			"rules":            rules,
+			"rules":            rule.String(),
 			"rowLimitation":    rowLimitation,
 			"colLimitation":    colLimitation,
 			"generation":       generation,
 			"neighborhoodType": neighborhoodType,
 		})
-	gr.readGol.InitFromConf(name, description, rows, cols, gconf)
+	if initError := gr.readGol.InitFromConf(name, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
 
 	// Read grid type
 	gridTypeLine, gridTypeLineError := gr.readCongolwayFileLine(reader)
@@ -214,9 +237,7 @@ func (gr *GolReader) readCongolwayFileV1(reader *bufio.Reader) (base.GolInterfac
 		return gr.readGridInDenseFormat(reader)
 	}
 	if gridType == "sparse" {
-		// TODO: read number of status
-		statusesCount := 2
-		return gr.readGridInSparseFormat(reader, statusesCount)
+		return gr.readGridInSparseFormat(reader, gr.readGol.States())
 	}
 	return nil, fmt.Errorf("Invalid grid_type. Only dense and sparse values are accepted, found %s", gridType)
 }
@@ -272,18 +293,31 @@ func (gr *GolReader) readGridInDenseFormat(reader *bufio.Reader) (base.GolInterf
 	g := gr.readGol
 	rows := g.Rows()
 	cols := g.Cols()
+	states := g.States()
 	for rowI := 0; rowI < rows; rowI++ {
 		rowString, err := gr.readCongolwayFileLine(reader)
 		if err != nil {
 			return nil, err
 		}
+		if len(rowString) < cols {
+			return nil, fmt.Errorf("Row %d should have %d cells, found %d", rowI, cols, len(rowString))
+		}
 		for colI := 0; colI < cols; colI++ {
 			colIStatus := statuses.ALIVE
 			cellValue := rowString[colI : colI+1]
 			if cellValue == " " || cellValue == "0" {
 				colIStatus = statuses.DEAD
+			} else if states > 2 {
+				// Cells with several states are written as base-36 digits
+				state, stateError := strconv.ParseInt(cellValue, 36, 0)
+				if stateError != nil || int(state) >= states {
+					return nil, fmt.Errorf("Invalid status %s in row %d, expected a number lower than %d", cellValue, rowI, states)
+				}
+				colIStatus = int(state)
+			}
+			if setError := g.Set(rowI, colI, colIStatus); setError != nil {
+				return nil, setError
 			}
-			g.Set(rowI, colI, colIStatus)
 		}
 	}
 	return g, nil
@@ -308,20 +342,23 @@ func (gr *GolReader) readGridInSparseFormat(reader *bufio.Reader, numberOfStatus
 	if defaultStatusValueError != nil {
 		return nil, fmt.Errorf("Invalid default value, found %d", defaultStatusValue)
 	}
-	g.SetAll(defaultStatusValue)
+	if setError := g.SetAll(defaultStatusValue); setError != nil {
+		return nil, setError
+	}
 
 	for statusI := 0; statusI < numberOfStatus; statusI++ {
 		rowStringI, rowStringIError := gr.readCongolwayFileLine(reader)
 		if rowStringIError != nil {
 			return nil, rowStringIError
 		}
-		// TODO: check status is valid
 		status, coords, lineError := sparseLineToCoordinates(rowStringI)
 		if lineError != nil {
 			return nil, lineError
 		}
 		for _, coord := range coords {
-			g.Set(coord.i, coord.j, status)
+			if setError := g.Set(coord.i, coord.j, status); setError != nil {
+				return nil, setError
+			}
 		}
 	}
 	return g, nil
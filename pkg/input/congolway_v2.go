@@ -0,0 +1,222 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
+)
+
+// congolwayV2Keys : keys of the header of the version 2 of the
+// Congolway format. The rest of the keys are custom metadata.
+var congolwayV2Keys = map[string]bool{
+	"name":              true,
+	"description":       true,
+	"rules":             true,
+	"generation":        true,
+	"neighborhood_type": true,
+	"size":              true,
+	"limits":            true,
+	"origin":            true,
+	"grid_type":         true,
+}
+
+// congolwayV2KeyRegex : valid keys of the header
+var congolwayV2KeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// readCongolwayFileV2 : read the version 2 of the Congolway format, whose
+// header has "key: value" lines in any order, comments (lines starting
+// with #) and custom metadata, followed by a dense, sparse or RLE grid.
+// Only the size is mandatory, the rest of the keys have default values.
+func (gr *GolReader) readCongolwayFileV2(reader *bufio.Reader) (base.GolInterface, error) {
+	header := make(map[string]string)
+	var descriptionLines []string
+	var metadataKeys []string
+	for {
+		line, lineError := gr.readCongolwayFileLine(reader)
+		if lineError == io.EOF {
+			return nil, fmt.Errorf("grid: expected, but the end of the file was found")
+		}
+		if lineError != nil {
+			return nil, lineError
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "grid:" {
+			break
+		}
+		lineParts := strings.SplitN(line, ":", 2)
+		if len(lineParts) != 2 {
+			return nil, fmt.Errorf("\"key: value\" expected, found %s", line)
+		}
+		key := strings.TrimSpace(lineParts[0])
+		value := strings.TrimSpace(lineParts[1])
+		if !congolwayV2KeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("Keys must be lowercase letters, digits, - and _, found %s", key)
+		}
+		// Descriptions with several lines have a description key for each one
+		if key == "description" {
+			descriptionLines = append(descriptionLines, value)
+			continue
+		}
+		if _, isRepeated := header[key]; isRepeated {
+			return nil, fmt.Errorf("Key %s is repeated", key)
+		}
+		header[key] = value
+		if !congolwayV2Keys[key] {
+			metadataKeys = append(metadataKeys, key)
+		}
+	}
+
+	gconf, rows, cols, gconfError := congolwayV2Conf(header)
+	if gconfError != nil {
+		return nil, gconfError
+	}
+	g := gr.readGol
+	description := strings.Join(descriptionLines, "\n")
+	if initError := g.InitFromConf(header["name"], description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	for _, key := range metadataKeys {
+		g.SetMetadata(key, header[key])
+	}
+	if origin, originExists := header["origin"]; originExists && g.Unbounded() {
+		originI, originJ, originError := parseCongolwayCoordinates(origin)
+		if originError != nil {
+			return nil, originError
+		}
+		if originError := g.SetOrigin(originI, originJ); originError != nil {
+			return nil, originError
+		}
+	}
+
+	gridType := header["grid_type"]
+	switch gridType {
+	case "", "dense":
+		return gr.readGridInDenseFormat(reader)
+	case "sparse":
+		return gr.readGridInSparseFormat(reader, g.States())
+	case "rle":
+		data, dataError := ioutil.ReadAll(reader)
+		if dataError != nil {
+			return nil, dataError
+		}
+		rleData := strings.NewReplacer("\n", "", "\r", "").Replace(string(data))
+		if cellsError := readRleCells(rleData, rows, cols, g); cellsError != nil {
+			return nil, cellsError
+		}
+		return g, nil
+	}
+	return nil, fmt.Errorf("Invalid grid_type. Only dense, sparse and rle values are accepted, found %s", gridType)
+}
+
+// congolwayV2Conf : return the configuration, rows and
+// columns defined by the header of a version 2 file
+func congolwayV2Conf(header map[string]string) (*base.GolConf, int, int, error) {
+	size, sizeExists := header["size"]
+	if !sizeExists {
+		return nil, 0, 0, fmt.Errorf("size: RxC is mandatory")
+	}
+	sizeParts := strings.Split(size, "x")
+	if len(sizeParts) != 2 {
+		return nil, 0, 0, fmt.Errorf("size: RxC where R and C are positive integers, found %s", size)
+	}
+	rows, rowsError := strconv.Atoi(strings.TrimSpace(sizeParts[0]))
+	cols, colsError := strconv.Atoi(strings.TrimSpace(sizeParts[1]))
+	if rowsError != nil || colsError != nil || rows < 0 || cols < 0 {
+		return nil, 0, 0, fmt.Errorf("size: RxC where R and C are positive integers, found %s", size)
+	}
+
+	generation := base.DefaultGeneration
+	if generationValue, generationExists := header["generation"]; generationExists {
+		var generationError error
+		generation, generationError = strconv.Atoi(generationValue)
+		if generationError != nil || generation < 0 {
+			return nil, 0, 0, fmt.Errorf("generation: D where D is a positive integer, found %s", generationValue)
+		}
+	}
+
+	neighborhoodType := base.DefaultNeighborhoodType
+	if neighborhoodValue, neighborhoodExists := header["neighborhood_type"]; neighborhoodExists {
+		var neighborhoodError error
+		neighborhoodType, neighborhoodError = neighborhood.TypeFromString(neighborhoodValue)
+		if neighborhoodError != nil {
+			return nil, 0, 0, neighborhoodError
+		}
+	}
+
+	// The rules are parsed once the neighborhood is known,
+	// as it sets the maximum number of neighbors of the conditions
+	rulestring := base.DefaultRules
+	if rulesValue, rulesExists := header["rules"]; rulesExists {
+		rulestring = rulesValue
+	}
+	rule, ruleError := rules.ParseForNeighborhood(rulestring, neighborhoodType)
+	if ruleError != nil {
+		return nil, 0, 0, ruleError
+	}
+
+	rowLimitation, colLimitation := base.DefaultRowLimitation, base.DefaultColLimitation
+	if limits, limitsExist := header["limits"]; limitsExist {
+		var limitsError error
+		rowLimitation, colLimitation, limitsError = parseCongolwayLimits(limits)
+		if limitsError != nil {
+			return nil, 0, 0, limitsError
+		}
+	}
+
+	gconf := base.NewGolConf(
+		map[string]interface{}{
+			"rules":            rule.String(),
+			"rowLimitation":    rowLimitation,
+			"colLimitation":    colLimitation,
+			"generation":       generation,
+			"neighborhoodType": neighborhoodType,
+		})
+	return gconf, rows, cols, nil
+}
+
+// parseCongolwayLimits : return the row and column limitations of
+// "rows", "cols", "rows, cols", "no" or "unbounded" limits
+func parseCongolwayLimits(limits string) (string, string, error) {
+	switch limits {
+	case "no":
+		return "unlimited", "unlimited", nil
+	case "unbounded":
+		return "unbounded", "unbounded", nil
+	}
+	rowLimitation, colLimitation := "unlimited", "unlimited"
+	for _, limit := range strings.Split(limits, ",") {
+		switch strings.TrimSpace(limit) {
+		case "rows":
+			rowLimitation = "limited"
+		case "cols":
+			colLimitation = "limited"
+		default:
+			return "", "", fmt.Errorf("limits: rows, cols, \"rows, cols\", no or unbounded expected, found %s", limits)
+		}
+	}
+	return rowLimitation, colLimitation, nil
+}
+
+// parseCongolwayCoordinates : parse an "i, j" position
+func parseCongolwayCoordinates(coordinates string) (int, int, error) {
+	coordinatesParts := strings.Split(coordinates, ",")
+	if len(coordinatesParts) == 2 {
+		i, iError := strconv.Atoi(strings.TrimSpace(coordinatesParts[0]))
+		j, jError := strconv.Atoi(strings.TrimSpace(coordinatesParts[1]))
+		if iError == nil && jError == nil {
+			return i, j, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("\"i, j\" position where i and j are integers expected, found %s", coordinates)
+}
@@ -0,0 +1,100 @@
+package input
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+)
+
+func TestNewGolFromCongolwayV2File(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	description := "The smallest, most common, and first discovered spaceship.\nIt moves diagonally."
+	testCongolwayFromTextFile(t, "glider_v2.txt", "Glider", description, 3, 3, true, true, 0, expectedCells)
+
+	g, _ := readCongolwayFile("glider_v2.txt")
+	expectedMetadata := map[string]string{
+		"author": "Richard K. Guy",
+		"source": "https://www.conwaylife.com/wiki/Glider",
+		"tags":   "spaceship, c/4",
+	}
+	if len(g.MetadataKeys()) != len(expectedMetadata) {
+		t.Errorf("The metadata keys should be author, source and tags, found %v", g.MetadataKeys())
+	}
+	for key, value := range expectedMetadata {
+		if g.Metadata(key) != value {
+			t.Errorf("The metadata %s should be \"%s\", found \"%s\"", key, value, g.Metadata(key))
+		}
+	}
+}
+
+func TestNewGolFromCongolwayV2FileWithDefaults(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	testCongolwayFromTextFile(t, "3x3_v2_defaults.txt", "", "", 3, 3, true, true, 0, expectedCells)
+	g, _ := readCongolwayFile("3x3_v2_defaults.txt")
+	if g != nil && (g.Rules() != base.DefaultRules || g.NeighborhoodTypeString() != neighborhood.MOORESTRING) {
+		t.Errorf("Default rules and neighborhood expected, found %s and %s", g.Rules(), g.NeighborhoodTypeString())
+	}
+}
+
+func TestNewGolFromCongolwayV2Stream(t *testing.T) {
+	content := "CONGOLWAY\nversion: 2\nneighborhood_type: Von Neumann\nrules: B1/S\nlimits: unbounded\n" +
+		"origin: -5, 7\ngeneration: 3\nsize: 2x2\ngrid_type: sparse\ngrid:\ndefault: 0\n0:\n1: (0,1)(1,0)\n"
+	gr := NewGolReader(new(gol.Gol))
+	g, readError := gr.Decode(strings.NewReader(content), "", nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if g.Rules() != "B1/SV" || !g.Unbounded() || g.Generation() != 3 {
+		t.Errorf("Unbounded grid with rules B1/SV in the generation 3 expected, found %s in %d", g.Rules(), g.Generation())
+	}
+	if g.Rows() != 2 || g.Cols() != 2 || g.Get(0, 0) != D || g.Get(0, 1) != A || g.Get(1, 0) != A || g.Get(1, 1) != D {
+		t.Errorf("Wrong grid, the cells 0,1 and 1,0 should be the only alive ones")
+	}
+	if originI, originJ := g.Origin(); originI != -5 || originJ != 7 {
+		t.Errorf("The origin should be -5, 7, found %d, %d", originI, originJ)
+	}
+}
+
+func TestNewGolFromInvalidCongolwayV2Stream(t *testing.T) {
+	invalidContents := []string{
+		"CONGOLWAY\nversion: 2\ngrid:\n",
+		"CONGOLWAY\nversion: 2\nsize: 1x1\nname: A\nname: B\ngrid:\n0\n",
+		"CONGOLWAY\nversion: 2\nsize: 1x1\nAuthor: A\ngrid:\n0\n",
+		"CONGOLWAY\nversion: 2\nsize: 1x1\nlimits: diagonals\ngrid:\n0\n",
+		"CONGOLWAY\nversion: 2\nsize: 1x1\ngrid_type: compressed\ngrid:\n0\n",
+		"CONGOLWAY\nversion: 2\nsize: 1x1\n",
+		"CONGOLWAY\nversion: 2\nsize: 1x1\ngrid_type: rle\ngrid:\n2o!\n",
+	}
+	for _, invalidContent := range invalidContents {
+		gr := NewGolReader(new(gol.Gol))
+		if _, readError := gr.Decode(strings.NewReader(invalidContent), "", nil); readError == nil {
+			t.Errorf("Content\n%s\nshould be invalid", invalidContent)
+		}
+	}
+}
+
+func TestNewGolFromCongolwayV1WithMisspelledNeighborhood(t *testing.T) {
+	content := "CONGOLWAY\nversion: 1\nname: Cross\ndescription: A cross\nrules: B1/S\ngeneration: 0\n" +
+		"neighborhood_type: Von Neumman\nsize: 1x1\nlimits: rows, cols\ngrid_type: dense\ngrid:\n1\n"
+	gr := NewGolReader(new(gol.Gol))
+	g, readError := gr.Decode(strings.NewReader(content), base.CongolwayFormat, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if g.NeighborhoodTypeString() != neighborhood.VONNEUMANNSTRING {
+		t.Errorf("The neighborhood should be Von Neumann, found %s", g.NeighborhoodTypeString())
+	}
+}
@@ -0,0 +1,60 @@
+package input
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+)
+
+// ReadCsvFile : create a new Game of life from a CSV file
+// with a line for each row and the state of each cell
+func (gr *GolReader) ReadCsvFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
+	file, fileError := base.OpenFile(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	return gr.decodeCsv(file, filename, gconf)
+}
+
+// decodeCsv : create a new Game of life from a CSV stream
+// whose source is a file path (or an empty string)
+func (gr *GolReader) decodeCsv(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	// The rows must have the same number of cells
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 0
+	reader.TrimLeadingSpace = true
+	records, recordsError := reader.ReadAll()
+	if recordsError != nil {
+		return nil, recordsError
+	}
+	rows := len(records)
+	cols := 0
+	if rows > 0 {
+		cols = len(records[0])
+	}
+
+	if gconf == nil {
+		gconf = base.NewDefaultGolConf()
+	}
+	g := gr.readGol
+	if initError := g.InitFromConf(sourceName(source), "", rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	for i, record := range records {
+		for j, field := range record {
+			state, stateError := strconv.Atoi(strings.TrimSpace(field))
+			if stateError != nil {
+				return nil, fmt.Errorf("The cell %d, %d must be an integer, found %s", i, j, field)
+			}
+			if setError := g.Set(i, j, state); setError != nil {
+				return nil, setError
+			}
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,29 @@
+package input
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+func TestReadCsvFile(t *testing.T) {
+	dataFilePath, _ := base.GetTestdataFilePath("glider.csv")
+	gr := NewGolReader(new(gol.Gol))
+	g, readError := gr.ReadFile(dataFilePath, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	assertGolIsRight(t, "glider.csv", "glider.csv", "", 3, 3, true, true, base.DefaultGeneration, gliderCells, g)
+}
+
+func TestDecodeCsvError(t *testing.T) {
+	for _, content := range []string{"0,1\n1\n", "0,x\n1,1\n", "0,2\n1,1\n"} {
+		gr := NewGolReader(new(gol.Gol))
+		if _, decodeError := gr.Decode(strings.NewReader(content), base.CsvFormat, nil); decodeError == nil {
+			t.Errorf("An error should have been returned when decoding %q", content)
+		}
+	}
+}
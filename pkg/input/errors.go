@@ -0,0 +1,7 @@
+package input
+
+import "errors"
+
+// ErrHistoryDivergence : a generation of a history does
+// not follow from the previous one under its rules
+var ErrHistoryDivergence = errors.New("History divergence")
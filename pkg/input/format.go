@@ -0,0 +1,214 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+)
+
+// Detector : inform if the first bytes of a stream (at most
+// sniffLength bytes) belong to a format
+type Detector func(head []byte) bool
+
+// Decoder : read a Game of life from a stream whose source
+// is a file path (or an empty string if it has no path)
+type Decoder func(gr *GolReader, r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error)
+
+// Format : file format that can be read by a GolReader
+type Format struct {
+	Name string
+	// File extensions with the starting dot (e.g. ".rle")
+	Extensions []string
+	// Detector of the content (nil if the format
+	// can only be recognized by its extension)
+	Detect Detector
+	Decode Decoder
+}
+
+// formats : registered formats, in order of detection
+var formats []*Format
+
+func init() {
+	builtinFormats := []Format{
+		{
+			Name:       base.CongolwayFormat,
+			Extensions: []string{".txt", ".congol"},
+			Detect:     prefixDetector("CONGOLWAY"),
+			Decode: func(gr *GolReader, r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+				return gr.decodeCongolway(r)
+			},
+		},
+		{
+			Name:   base.Life105Format,
+			Detect: prefixDetector("#Life 1.05"),
+			Decode: (*GolReader).decodeLife105,
+		},
+		{
+			Name:   base.Life106Format,
+			Detect: prefixDetector("#Life 1.06"),
+			Decode: (*GolReader).decodeLife106,
+		},
+		{
+			Name:       base.LifeFormat,
+			Extensions: []string{".life", ".lif"},
+			Decode:     (*GolReader).decodeLife,
+		},
+		{
+			Name:       base.GifFormat,
+			Extensions: []string{".gif"},
+			Detect:     prefixDetector("GIF87a", "GIF89a"),
+			Decode:     (*GolReader).decodeStillImage,
+		},
+		{
+			Name:       base.PngFormat,
+			Extensions: []string{".png"},
+			Detect:     prefixDetector("\x89PNG\r\n\x1a\n"),
+			Decode:     (*GolReader).decodeStillImage,
+		},
+		{
+			Name:       base.BmpFormat,
+			Extensions: []string{".bmp"},
+			Detect:     prefixDetector("BM"),
+			Decode:     (*GolReader).decodeStillImage,
+		},
+		{
+			Name:       base.JpegFormat,
+			Extensions: []string{".jpg", ".jpeg"},
+			Detect:     prefixDetector("\xff\xd8\xff"),
+			Decode:     (*GolReader).decodeStillImage,
+		},
+		{
+			Name:       base.MacrocellFormat,
+			Extensions: []string{".mc"},
+			Detect:     prefixDetector("[M2]"),
+			Decode:     (*GolReader).decodeMacrocell,
+		},
+		{
+			Name:       base.NpyFormat,
+			Extensions: []string{".npy"},
+			Detect:     prefixDetector(npyMagic),
+			Decode:     (*GolReader).decodeNpy,
+		},
+		{
+			Name:       base.RleFormat,
+			Extensions: []string{".rle"},
+			Detect:     isRle,
+			Decode:     (*GolReader).decodeRle,
+		},
+		{
+			Name:       base.CellsFormat,
+			Extensions: []string{".cells"},
+			Detect:     prefixDetector("!"),
+			Decode: func(gr *GolReader, r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+				return gr.decodeCells(r, gconf)
+			},
+		},
+		{
+			Name:       base.CsvFormat,
+			Extensions: []string{".csv"},
+			Decode:     (*GolReader).decodeCsv,
+		},
+	}
+	for formatI := range builtinFormats {
+		formats = append(formats, &builtinFormats[formatI])
+	}
+}
+
+// RegisterFormat : add a format to the ones that can be read. The content
+// of the streams is checked by the detectors in order of registration, and
+// the extensions are only used when no detector recognizes the content.
+func RegisterFormat(format Format) error {
+	if format.Name == "" || format.Decode == nil {
+		return fmt.Errorf("Formats must have a name and a decoder")
+	}
+	if formatByName(format.Name) != nil {
+		return fmt.Errorf("Format \"%s\" is already registered", format.Name)
+	}
+	for _, extension := range format.Extensions {
+		if registeredFormat := formatByExtension(extension); registeredFormat != nil {
+			return fmt.Errorf("Extension \"%s\" is already registered by the format \"%s\"",
+				extension, registeredFormat.Name)
+		}
+	}
+	formats = append(formats, &format)
+	return nil
+}
+
+// Formats : return the names of the formats
+// that can be read, in order of registration
+func Formats() []string {
+	names := make([]string, len(formats))
+	for formatI, format := range formats {
+		names[formatI] = format.Name
+	}
+	return names
+}
+
+// SniffFormat : return the format of the content
+// whose first bytes are passed as argument
+func SniffFormat(head []byte) (string, error) {
+	for _, format := range formats {
+		if format.Detect != nil && format.Detect(head) {
+			return format.Name, nil
+		}
+	}
+	return "", fmt.Errorf("The format of the content could not be recognized")
+}
+
+func formatByName(name string) *Format {
+	for _, format := range formats {
+		if format.Name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+func formatByExtension(extension string) *Format {
+	for _, format := range formats {
+		for _, formatExtension := range format.Extensions {
+			if strings.EqualFold(formatExtension, extension) {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// extensionsString : return the registered extensions separated by commas
+func extensionsString() string {
+	var extensions []string
+	for _, format := range formats {
+		extensions = append(extensions, format.Extensions...)
+	}
+	return strings.Join(extensions, ", ")
+}
+
+// prefixDetector : return a detector of the contents
+// that start with any of the prefixes
+func prefixDetector(prefixes ...string) Detector {
+	return func(head []byte) bool {
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(head, []byte(prefix)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isRle : inform if the content has an "x = m, y = n" header
+// after its comment lines (#N, #C...), as RLE files do
+func isRle(head []byte) bool {
+	for _, line := range strings.Split(string(head), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.HasPrefix(line, "x") && strings.Contains(line, "=")
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package input
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestReadFileByContent(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	description := "Richard K. Guy\nThe smallest, most common, and first discovered spaceship."
+	// Missing, wrong and unknown extensions
+	for _, pattern := range []string{"temp_gol*", "temp_gol*.cells", "temp_gol*.pattern"} {
+		filePath := copyTestdataFile(t, "glider.rle", pattern)
+		if filePath == "" {
+			return
+		}
+		defer os.Remove(filePath)
+		gr := NewGolReader(new(gol.Gol))
+		g, readError := gr.ReadFile(filePath, nil)
+		if readError != nil {
+			t.Errorf("%s should be read as a RLE file: %s", filePath, readError)
+			continue
+		}
+		assertGolIsRight(t, filePath, "Glider", description, 3, 3, true, true, base.DefaultGeneration, expectedCells, g)
+	}
+}
+
+func TestReadCongolFile(t *testing.T) {
+	filePath := copyTestdataFile(t, "5x5.txt", "temp_gol*.congol")
+	if filePath == "" {
+		return
+	}
+	defer os.Remove(filePath)
+	gr := NewGolReader(new(gol.Gol))
+	if _, readError := gr.ReadFile(filePath, nil); readError != nil {
+		t.Error(readError)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	// Format with a single alive cell in the position given by the file
+	registerError := RegisterFormat(Format{
+		Name:       "cell",
+		Extensions: []string{".cell"},
+		Detect:     prefixDetector("CELL"),
+		Decode: func(gr *GolReader, r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+			content, contentError := ioutil.ReadAll(r)
+			if contentError != nil {
+				return nil, contentError
+			}
+			size := len(bytes.TrimSpace(content))
+			g := gr.Gol()
+			if initError := g.InitFromConf("Cell", "", size, size, gconf); initError != nil {
+				return nil, initError
+			}
+			return g, g.Set(size-1, size-1, statuses.ALIVE)
+		},
+	})
+	if registerError != nil {
+		t.Error(registerError)
+		return
+	}
+	gr := NewGolReader(new(gol.Gol))
+	g, decodeError := gr.Decode(bytes.NewBufferString("CELL\n"), "", base.NewDefaultGolConf())
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	if g.Rows() != 4 || g.Get(3, 3) != A {
+		t.Errorf("The registered format was not used to decode the content")
+	}
+
+	invalidFormats := []Format{
+		{Name: "cell", Decode: (*GolReader).decodeRle},
+		{Name: "cell2", Extensions: []string{".RLE"}, Decode: (*GolReader).decodeRle},
+		{Name: "cell3"},
+	}
+	for _, invalidFormat := range invalidFormats {
+		if RegisterFormat(invalidFormat) == nil {
+			t.Errorf("Format %s should not be registered", invalidFormat.Name)
+		}
+	}
+}
+
+// copyTestdataFile : copy a test data file to a temporary file whose name
+// follows a pattern, returning its path (an empty string if it fails)
+func copyTestdataFile(t *testing.T, filename string, pattern string) string {
+	dataFilePath, _ := base.GetTestdataFilePath(filename)
+	content, readFileError := ioutil.ReadFile(dataFilePath)
+	if readFileError != nil {
+		t.Error(readFileError)
+		return ""
+	}
+	file, fileError := ioutil.TempFile("", pattern)
+	if fileError != nil {
+		t.Error(fileError)
+		return ""
+	}
+	defer file.Close()
+	file.Write(content)
+	return file.Name()
+}
@@ -0,0 +1,54 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+)
+
+// ReadHistoryFile : read every frame of an animated GIF or APNG file (e.g.
+// made by animator.MakeGif or animator.MakeApng) as a sequence of generations
+// and check that each one follows from the previous one under the rules of
+// the configuration (see VerifyHistory). The image options of the reader
+// must match the ones used to draw the animation (e.g. its cell size).
+func (gr *GolReader) ReadHistoryFile(filename string, gconf *base.GolConf) ([]base.GolInterface, error) {
+	history, readError := gr.ReadImageFrames(filename, gconf)
+	if readError != nil {
+		return nil, readError
+	}
+	if _, verifyError := VerifyHistory(history, ""); verifyError != nil {
+		return history, verifyError
+	}
+	return history, nil
+}
+
+// VerifyHistory : check that each generation of a history follows from the
+// previous one under some rules (the ones of the generations if empty).
+// Return the index of the first generation that diverges and an error
+// wrapping ErrHistoryDivergence, or -1 and nil if all of them are right.
+func VerifyHistory(history []base.GolInterface, rulestring string) (int, error) {
+	for frameI := 1; frameI < len(history); frameI++ {
+		previous := history[frameI-1]
+		if rulestring != "" && previous.Rules() != rulestring {
+			previous = previous.Clone()
+			if rulesError := previous.SetRules(rulestring); rulesError != nil {
+				return frameI, rulesError
+			}
+		}
+		expected := previous.NextGeneration()
+		current := history[frameI]
+		if expected.Rows() != current.Rows() || expected.Cols() != current.Cols() {
+			return frameI, fmt.Errorf("%w: frame %d has %dx%d cells, expected %dx%d",
+				ErrHistoryDivergence, frameI, current.Rows(), current.Cols(), expected.Rows(), expected.Cols())
+		}
+		for i := 0; i < current.Rows(); i++ {
+			for j := 0; j < current.Cols(); j++ {
+				if expected.Get(i, j) != current.Get(i, j) {
+					return frameI, fmt.Errorf("%w: cell %d, %d of frame %d is %d, expected %d",
+						ErrHistoryDivergence, i, j, frameI, current.Get(i, j), expected.Get(i, j))
+				}
+			}
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,143 @@
+package input
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/animator"
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestReadHistoryFile(t *testing.T) {
+	tempDir, tempDirError := ioutil.TempDir("", "")
+	if tempDirError != nil {
+		t.Error(tempDirError)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	generations := 12
+	makers := map[string]func(g *gol.Gol, outputFilepath string) error{
+		"glider.gif": func(g *gol.Gol, outputFilepath string) error {
+			return animator.MakeGif(g, outputFilepath, generations, 0, nil)
+		},
+		"glider.apng": func(g *gol.Gol, outputFilepath string) error {
+			return animator.MakeApng(g, outputFilepath, generations)
+		},
+	}
+	gconf := base.NewGolConf(map[string]interface{}{"rowLimitation": "unlimited", "colLimitation": "unlimited"})
+	for filename, makeAnimation := range makers {
+		g := toroidalGlider(t)
+		if g == nil {
+			return
+		}
+		outputFilepath := filepath.Join(tempDir, filename)
+		if makeError := makeAnimation(g, outputFilepath); makeError != nil {
+			t.Error(makeError)
+			continue
+		}
+
+		gr := NewGolReader(new(gol.Gol))
+		history, readError := gr.ReadHistoryFile(outputFilepath, gconf)
+		if readError != nil {
+			t.Errorf("%s: %s", filename, readError)
+			continue
+		}
+		if len(history) != generations {
+			t.Errorf("%s should have %d generations, found %d", filename, generations, len(history))
+			continue
+		}
+		for generation, readG := range history {
+			if readG.Generation() != generation {
+				t.Errorf("Frame %d of %s has the generation %d", generation, filename, readG.Generation())
+			}
+			if !g.GridEquals(readG, "values") {
+				t.Errorf("Frame %d of %s is not the generation %d", generation, filename, generation)
+			}
+			g = g.NextGeneration().(*gol.Gol)
+		}
+	}
+}
+
+func TestReadHistoryFileStatesPalette(t *testing.T) {
+	brainFilePath, _ := base.GetTestdataFilePath("brians_brain.rle")
+	g, readError := NewGolReader(new(gol.Gol)).ReadRleFile(brainFilePath, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	outputFile, outputFileError := ioutil.TempFile("", "temp_gol*.gif")
+	if outputFileError != nil {
+		t.Error(outputFileError)
+		return
+	}
+	outputFile.Close()
+	defer os.Remove(outputFile.Name())
+	if makeError := animator.MakeGif(g.(*gol.Gol), outputFile.Name(), 5, 0, nil); makeError != nil {
+		t.Error(makeError)
+		return
+	}
+
+	// The grid of the pattern is a torus
+	gconf := base.NewGolConf(map[string]interface{}{
+		"rules": g.Rules(), "rowLimitation": "unlimited", "colLimitation": "unlimited",
+	})
+	// Dying cells are read as alive cells without the palette of the states
+	gr := NewGolReader(new(gol.Gol))
+	if _, historyError := gr.ReadHistoryFile(outputFile.Name(), gconf); !errors.Is(historyError, ErrHistoryDivergence) {
+		t.Errorf("The history without the palette of the states should diverge, found %v", historyError)
+	}
+	gr = NewGolReader(new(gol.Gol))
+	gr.SetImageOptions(&ImageOptions{CellSize: 1, StatesPalette: animator.StatesPalette(g.States())})
+	if _, historyError := gr.ReadHistoryFile(outputFile.Name(), gconf); historyError != nil {
+		t.Error(historyError)
+	}
+}
+
+func TestVerifyHistory(t *testing.T) {
+	g := toroidalGlider(t)
+	if g == nil {
+		return
+	}
+	history := []base.GolInterface{g}
+	for generation := 1; generation < 6; generation++ {
+		history = append(history, history[generation-1].NextGeneration())
+	}
+	if frame, verifyError := VerifyHistory(history, ""); frame != -1 || verifyError != nil {
+		t.Errorf("The history should not diverge, found frame %d (%v)", frame, verifyError)
+	}
+	// None of the dead cells of a glider has 6 neighbors,
+	// so it evolves in the same way under HighLife rules
+	if frame, verifyError := VerifyHistory(history, "B36/S23"); frame != -1 || verifyError != nil {
+		t.Errorf("The history should not diverge with B36/S23, found frame %d (%v)", frame, verifyError)
+	}
+	if frame, verifyError := VerifyHistory(history, "B3/S2"); frame != 1 || !errors.Is(verifyError, ErrHistoryDivergence) {
+		t.Errorf("The history should diverge in the frame 1 with B3/S2, found frame %d (%v)", frame, verifyError)
+	}
+
+	history[4] = history[4].Clone()
+	history[4].Set(0, 0, statuses.ALIVE)
+	if frame, verifyError := VerifyHistory(history, ""); frame != 4 || !errors.Is(verifyError, ErrHistoryDivergence) {
+		t.Errorf("The history should diverge in the frame 4, found frame %d (%v)", frame, verifyError)
+	}
+}
+
+// toroidalGlider : return a glider in a 8x8 grid whose rows and columns wrap
+func toroidalGlider(t *testing.T) *gol.Gol {
+	g, gError := gol.NewGol("Glider", "", "B3/S23", "dok", "unlimited", "unlimited", 8, 8, 0)
+	if gError != nil {
+		t.Error(gError)
+		return nil
+	}
+	for i, row := range gliderCells {
+		for j, cell := range row {
+			g.Set(i+1, j+1, cell)
+		}
+	}
+	return g
+}
@@ -0,0 +1,265 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	// Decoders of the image formats read by image.Decode
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/bmp"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+	"github.com/kettek/apng"
+)
+
+// maxLuminance : luminance of the white pixels
+const maxLuminance = 0xffff
+
+// ImageOptions : options of the conversion of the pixels of an image to cells
+type ImageOptions struct {
+	// Luminance (from 0 to 1) from which the pixels are dead cells,
+	// i.e. the pixels whose luminance is lower are alive cells
+	Threshold float64
+	// Invert the luminance, so the lighter pixels are the alive cells
+	Invert bool
+	// Side in pixels of the squares drawn for each cell. The state of a
+	// cell is the one of the pixel in the center of its square.
+	CellSize int
+	// Color of each state (e.g. the dying states of Generations rules).
+	// If it is not empty, each cell has the state of the closest color
+	// of the palette, and the threshold and inversion are ignored.
+	StatesPalette color.Palette
+}
+
+// NewDefaultImageOptions : return the default options, where each pixel is
+// a cell and only white and transparent pixels are dead cells
+func NewDefaultImageOptions() *ImageOptions {
+	return &ImageOptions{Threshold: 1, Invert: false, CellSize: 1}
+}
+
+// check : return an error if the options are not valid
+func (options *ImageOptions) check() error {
+	if options.Threshold < 0 || options.Threshold > 1 {
+		return fmt.Errorf("The threshold must be between 0 and 1, found %v", options.Threshold)
+	}
+	if options.CellSize < 1 {
+		return fmt.Errorf("The cell size must be a positive integer, found %d", options.CellSize)
+	}
+	return nil
+}
+
+// state : return the state of the cell of a pixel
+func (options *ImageOptions) state(clr color.Color) int {
+	r, g, b, a := clr.RGBA()
+	// Transparent pixels are always dead cells
+	if a == 0 {
+		return statuses.DEAD
+	}
+	if len(options.StatesPalette) > 0 {
+		return options.StatesPalette.Index(clr)
+	}
+	// Translucent pixels are blended with a white background
+	background := maxLuminance - a
+	luminance := color.Gray16Model.Convert(color.RGBA64{
+		R: uint16(r + background), G: uint16(g + background), B: uint16(b + background), A: maxLuminance,
+	}).(color.Gray16).Y
+	if options.Invert {
+		luminance = maxLuminance - luminance
+	}
+	if float64(luminance) < options.Threshold*maxLuminance {
+		return statuses.ALIVE
+	}
+	return statuses.DEAD
+}
+
+// SetImageOptions : set the options used to read images
+// (nil to use the default ones)
+func (gr *GolReader) SetImageOptions(options *ImageOptions) {
+	gr.imageOptions = options
+}
+
+// ImageOptions : return the options used to read images
+func (gr *GolReader) ImageOptions() *ImageOptions {
+	if gr.imageOptions == nil {
+		return NewDefaultImageOptions()
+	}
+	return gr.imageOptions
+}
+
+// ReadImageFile : create a new Game of life from a PNG, GIF (its first
+// frame), BMP or JPEG file, following the image options of the reader
+func (gr *GolReader) ReadImageFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
+	file, fileError := base.OpenFile(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	return gr.decodeStillImage(file, filename, gconf)
+}
+
+// ReadImageFrames : create a Game of life instance for each frame of a
+// GIF or APNG file (or for the only frame of a PNG, BMP or JPEG file).
+// The first frame fills the instance of the reader, and the rest of them
+// are clones whose generations are the following ones.
+func (gr *GolReader) ReadImageFrames(filename string, gconf *base.GolConf) ([]base.GolInterface, error) {
+	file, fileError := base.OpenFile(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	return gr.DecodeImageFrames(file, gconf)
+}
+
+// DecodeImageFrames : create a Game of life instance for each frame
+// of a GIF or APNG stream (or for the only frame of other images)
+func (gr *GolReader) DecodeImageFrames(r io.Reader, gconf *base.GolConf) ([]base.GolInterface, error) {
+	reader, head, sniffError := sniff(r)
+	if sniffError != nil {
+		return nil, sniffError
+	}
+	if prefixDetector("GIF87a", "GIF89a")(head) {
+		gifAnim, gifAnimError := gif.DecodeAll(reader)
+		if gifAnimError != nil {
+			return nil, gifAnimError
+		}
+		return gr.decodeGifFrames(gifAnim, "", gconf)
+	}
+	if prefixDetector("\x89PNG\r\n\x1a\n")(head) {
+		animation, animationError := apng.DecodeAll(reader)
+		if animationError != nil {
+			return nil, animationError
+		}
+		return gr.decodeApngFrames(animation, "", gconf)
+	}
+	g, decodeError := gr.decodeStillImage(reader, "", gconf)
+	if decodeError != nil {
+		return nil, decodeError
+	}
+	return []base.GolInterface{g}, nil
+}
+
+// decodeStillImage : create a new Game of life from a PNG, GIF (its
+// first frame), BMP or JPEG stream whose source is a file path
+func (gr *GolReader) decodeStillImage(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	img, _, imgError := image.Decode(bufio.NewReader(r))
+	if imgError != nil {
+		return nil, imgError
+	}
+	return gr.decodeImage(img, source, gconf)
+}
+
+// decodeGifFrames : create a Game of life instance for each frame of a GIF
+// animation. The frames are drawn over the previous ones following their
+// disposal methods, as they can only have the pixels that change.
+func (gr *GolReader) decodeGifFrames(gifAnim *gif.GIF, source string, gconf *base.GolConf) ([]base.GolInterface, error) {
+	if len(gifAnim.Image) < 1 {
+		return nil, fmt.Errorf("The GIF image has no frames")
+	}
+	canvasBounds := image.Rect(0, 0, gifAnim.Config.Width, gifAnim.Config.Height)
+	if canvasBounds.Empty() {
+		canvasBounds = gifAnim.Image[0].Bounds()
+	}
+	canvas := image.NewRGBA(canvasBounds)
+	images := make([]image.Image, 0, len(gifAnim.Image))
+	for frameI, frame := range gifAnim.Image {
+		var previousCanvas *image.RGBA
+		disposal := byte(0)
+		if frameI < len(gifAnim.Disposal) {
+			disposal = gifAnim.Disposal[frameI]
+		}
+		if disposal == gif.DisposalPrevious {
+			previousCanvas = image.NewRGBA(canvasBounds)
+			draw.Draw(previousCanvas, canvasBounds, canvas, canvasBounds.Min, draw.Src)
+		}
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		frameImage := image.NewRGBA(canvasBounds)
+		draw.Draw(frameImage, canvasBounds, canvas, canvasBounds.Min, draw.Src)
+		images = append(images, frameImage)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previousCanvas
+		}
+	}
+	return gr.decodeImages(images, source, gconf)
+}
+
+// decodeImages : create a Game of life instance for each image. The
+// first image fills the instance of the reader, and the rest of them
+// are clones whose generations are the following ones.
+func (gr *GolReader) decodeImages(images []image.Image, source string, gconf *base.GolConf) ([]base.GolInterface, error) {
+	first, decodeError := gr.decodeImage(images[0], source, gconf)
+	if decodeError != nil {
+		return nil, decodeError
+	}
+	gols := []base.GolInterface{first}
+	for imageI, img := range images[1:] {
+		g := first.Clone()
+		if cellsError := gr.setImageCells(img, g); cellsError != nil {
+			return nil, cellsError
+		}
+		g.SetGeneration(first.Generation() + imageI + 1)
+		gols = append(gols, g)
+	}
+	return gols, nil
+}
+
+// decodeImage : create a new Game of life from an image where each
+// pixel (or each square of pixels of the cell size) is a cell. By
+// default, transparent and white pixels are dead cells and the rest
+// of them are alive cells (see ImageOptions).
+func (gr *GolReader) decodeImage(img image.Image, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	options := gr.ImageOptions()
+	if optionsError := options.check(); optionsError != nil {
+		return nil, optionsError
+	}
+	imgBounds := img.Bounds()
+	// Max is not included in the bounds but min is
+	rows := imgBounds.Dy() / options.CellSize
+	cols := imgBounds.Dx() / options.CellSize
+
+	if gconf == nil {
+		gconf = base.NewDefaultGolConf()
+	}
+
+	description := ""
+	if source != "" {
+		description = fmt.Sprintf("Read from file %s", source)
+	}
+	g := gr.readGol
+	if initError := g.InitFromConf(source, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	if cellsError := gr.setImageCells(img, g); cellsError != nil {
+		return nil, cellsError
+	}
+	return g, nil
+}
+
+// setImageCells : set the state of each cell of an instance
+// from the pixel in the center of its square of the image
+func (gr *GolReader) setImageCells(img image.Image, g base.GolInterface) error {
+	options := gr.ImageOptions()
+	imgBounds := img.Bounds()
+	for i := 0; i < g.Rows(); i++ {
+		y := imgBounds.Min.Y + i*options.CellSize + options.CellSize/2
+		for j := 0; j < g.Cols(); j++ {
+			x := imgBounds.Min.X + j*options.CellSize + options.CellSize/2
+			if setError := g.Set(i, j, options.state(img.At(x, y))); setError != nil {
+				return setError
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package input
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"testing"
+
+	"golang.org/x/image/bmp"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+var gliderCells [][]int = [][]int{
+	{D, A, D},
+	{D, D, A},
+	{A, A, A},
+}
+
+func TestDecodeImageThreshold(t *testing.T) {
+	// Dark gray alive cells over a light gray background
+	img := cellsImage(gliderCells, 1, color.Palette{color.Gray{Y: 200}, color.Gray{Y: 60}})
+	testCases := []struct {
+		options ImageOptions
+		cells   [][]int
+	}{
+		{ImageOptions{Threshold: 1, CellSize: 1}, [][]int{{A, A, A}, {A, A, A}, {A, A, A}}},
+		{ImageOptions{Threshold: 0.5, CellSize: 1}, gliderCells},
+		{ImageOptions{Threshold: 0.5, Invert: true, CellSize: 1}, [][]int{{A, D, A}, {A, A, D}, {D, D, D}}},
+		{ImageOptions{Threshold: 0.1, CellSize: 1}, [][]int{{D, D, D}, {D, D, D}, {D, D, D}}},
+	}
+	for _, testCase := range testCases {
+		options := testCase.options
+		gr := NewGolReader(new(gol.Gol))
+		gr.SetImageOptions(&options)
+		g, decodeError := gr.decodeImage(img, "", nil)
+		if decodeError != nil {
+			t.Error(decodeError)
+			continue
+		}
+		assertGolIsRight(t, "", "", "", 3, 3, true, true, 0, testCase.cells, g)
+	}
+}
+
+func TestDecodeImageCellSize(t *testing.T) {
+	var buffer bytes.Buffer
+	if encodeError := bmp.Encode(&buffer, cellsImage(gliderCells, 5, nil)); encodeError != nil {
+		t.Error(encodeError)
+		return
+	}
+	gr := NewGolReader(new(gol.Gol))
+	gr.SetImageOptions(&ImageOptions{Threshold: 1, CellSize: 5})
+	g, decodeError := gr.Decode(&buffer, "", nil)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	assertGolIsRight(t, "", "", "", 3, 3, true, true, 0, gliderCells, g)
+}
+
+func TestDecodeJpeg(t *testing.T) {
+	// The lossy compression of JPEG changes the color of the
+	// pixels, so each cell is a big square and the threshold is 0.5
+	var buffer bytes.Buffer
+	if encodeError := jpeg.Encode(&buffer, cellsImage(gliderCells, 16, nil), nil); encodeError != nil {
+		t.Error(encodeError)
+		return
+	}
+	gr := NewGolReader(new(gol.Gol))
+	gr.SetImageOptions(&ImageOptions{Threshold: 0.5, CellSize: 16})
+	g, decodeError := gr.Decode(&buffer, base.JpegFormat, nil)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	assertGolIsRight(t, "", "", "", 3, 3, true, true, 0, gliderCells, g)
+}
+
+func TestDecodeImageStatesPalette(t *testing.T) {
+	palette := color.Palette{color.White, color.Black, color.RGBA{R: 255, A: 255}}
+	cells := [][]int{
+		{0, 1, 2},
+		{2, 0, 1},
+		{1, 2, 0},
+	}
+	gr := NewGolReader(new(gol.Gol))
+	gr.SetImageOptions(&ImageOptions{CellSize: 1, StatesPalette: palette})
+	g, decodeError := gr.decodeImage(cellsImage(cells, 1, palette), "", base.NewGolConf(map[string]interface{}{"rules": "B2/S/C3"}))
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	for i, row := range cells {
+		for j, state := range row {
+			if g.Get(i, j) != state {
+				t.Errorf("Cell %d, %d is %d, expected %d", i, j, g.Get(i, j), state)
+			}
+		}
+	}
+}
+
+func TestDecodeImageFrames(t *testing.T) {
+	palette := color.Palette{color.White, color.Black, color.Transparent}
+	secondCells := [][]int{
+		{D, D, D},
+		{A, D, A},
+		{2, A, A},
+	}
+	// The second frame only has the two bottom rows, and its
+	// transparent pixel keeps the alive cell of the first frame
+	secondFrame := image.NewPaletted(image.Rect(0, 1, 3, 3), palette)
+	copy(secondFrame.Pix, cellsImage(secondCells, 1, palette).Pix[3:])
+	gifAnim := &gif.GIF{
+		Image:    []*image.Paletted{cellsImage(gliderCells, 1, palette), secondFrame},
+		Delay:    []int{0, 0},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalNone},
+	}
+	secondCells[0] = gliderCells[0]
+	secondCells[2][0] = A
+	var buffer bytes.Buffer
+	if encodeError := gif.EncodeAll(&buffer, gifAnim); encodeError != nil {
+		t.Error(encodeError)
+		return
+	}
+
+	gr := NewGolReader(new(gol.Gol))
+	gols, decodeError := gr.DecodeImageFrames(&buffer, nil)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	if len(gols) != 2 {
+		t.Errorf("2 frames expected, found %d", len(gols))
+		return
+	}
+	assertGolIsRight(t, "", "", "", 3, 3, true, true, 0, gliderCells, gols[0])
+	assertGolIsRight(t, "", "", "", 3, 3, true, true, 1, secondCells, gols[1])
+}
+
+func TestDecodeImageError(t *testing.T) {
+	img := cellsImage(gliderCells, 1, nil)
+	for _, options := range []ImageOptions{{Threshold: 1.5, CellSize: 1}, {Threshold: 0.5, CellSize: 0}} {
+		options := options
+		gr := NewGolReader(new(gol.Gol))
+		gr.SetImageOptions(&options)
+		if _, decodeError := gr.decodeImage(img, "", nil); decodeError == nil {
+			t.Errorf("An error should have been returned for the options %v", options)
+		}
+	}
+}
+
+// cellsImage : return an image where each cell is a square of size x size
+// pixels whose color is the one of its state in the palette (white for
+// dead cells and black for alive cells if the palette is nil)
+func cellsImage(cells [][]int, size int, palette color.Palette) *image.Paletted {
+	if palette == nil {
+		palette = color.Palette{color.White, color.Black}
+	}
+	img := image.NewPaletted(image.Rect(0, 0, len(cells[0])*size, len(cells)*size), palette)
+	for y := 0; y < len(cells)*size; y++ {
+		for x := 0; x < len(cells[0])*size; x++ {
+			img.SetColorIndex(x, y, uint8(cells[y/size][x/size]))
+		}
+	}
+	return img
+}
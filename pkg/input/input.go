@@ -1,40 +1,105 @@
 package input
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
 )
 
+// sniffLength : number of bytes read from the start of
+// a stream to guess its format
+const sniffLength = 512
+
 // GolReader : tasked with reading a Game of Life from files
 type GolReader struct {
-	readGol base.GolInterface
+	readGol      base.GolInterface
+	imageOptions *ImageOptions
 }
 
 // NewGolReader : returns a new pointer to GolReader
 func NewGolReader(g base.GolInterface) *GolReader {
-	return &GolReader{g}
+	return &GolReader{g, nil}
+}
+
+// Gol : return the Game of life instance that is filled by the reader
+func (gr *GolReader) Gol() base.GolInterface {
+	return gr.readGol
 }
 
-// ReadFile : read a file from a path
+// ReadFile : read a file from a path. Its format is guessed from
+// its content, and if it is not recognized, from its extension.
+// Files with a .gz or .zst extension after the one of the format
+// (e.g. "gun.rle.gz") are decompressed.
 func (gr *GolReader) ReadFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
-	lastDotIndex := strings.LastIndex(filename, ".")
-	if lastDotIndex < 0 {
-		return nil, fmt.Errorf("File \"%s\" has no extension. Only .txt and .cells files are allowed", filename)
+	file, fileError := base.OpenFile(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+
+	reader, head, sniffError := sniff(file)
+	if sniffError != nil {
+		return nil, sniffError
+	}
+	format := ""
+	if formatName, formatError := SniffFormat(head); formatError == nil {
+		format = formatName
+	} else if extensionFormat := formatByExtension(base.FormatExtension(filename)); extensionFormat != nil {
+		format = extensionFormat.Name
+	} else {
+		return nil, fmt.Errorf(
+			"The format of the file \"%s\" could not be recognized. Only %s extensions are allowed",
+			filename, extensionsString())
+	}
+	return gr.decode(reader, format, filename, gconf)
+}
+
+// Decode : read a Game of life from a stream in one of the registered
+// formats (see Formats). If the format is empty, it is guessed from
+// the content of the stream.
+func (gr *GolReader) Decode(r io.Reader, format string, gconf *base.GolConf) (base.GolInterface, error) {
+	if format == "" {
+		reader, head, sniffError := sniff(r)
+		if sniffError != nil {
+			return nil, sniffError
+		}
+		var formatError error
+		format, formatError = SniffFormat(head)
+		if formatError != nil {
+			return nil, formatError
+		}
+		r = reader
+	}
+	return gr.decode(r, format, "", gconf)
+}
+
+// decode : read a Game of life from a stream whose source is
+// a file path (or an empty string if it has no path)
+func (gr *GolReader) decode(r io.Reader, format string, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	registeredFormat := formatByName(format)
+	if registeredFormat == nil {
+		return nil, fmt.Errorf("Format \"%s\" not recognized. Only %v are allowed", format, Formats())
 	}
-	fileExtension := filename[lastDotIndex:]
-
-	if fileExtension == ".txt" {
-		return gr.ReadCongolwayFile(filename)
-	} else if fileExtension == ".cells" {
-		return gr.ReadCellsFile(filename, gconf)
-	} else if fileExtension == ".life" {
-		return gr.ReadLifeFile(filename, gconf)
-	} else if fileExtension == ".gif" {
-		return gr.ReadGifFile(filename, gconf)
+	return registeredFormat.Decode(gr, r, source, gconf)
+}
+
+// sniff : return a reader with the content of a stream
+// and the first bytes of the stream
+func sniff(r io.Reader) (io.Reader, []byte, error) {
+	bufferedReader := bufio.NewReaderSize(r, sniffLength)
+	head, peekError := bufferedReader.Peek(sniffLength)
+	if peekError != nil && peekError != io.EOF && peekError != bufio.ErrBufferFull {
+		return nil, nil, peekError
 	}
-	return nil, fmt.Errorf(
-		"File extension \"%s\" not recognized. "+
-			"Only .txt, .cells and .gif are allowed", fileExtension)
+	return bufferedReader, head, nil
+}
+
+// sourceName : return the name of a pattern read from a
+// source (the name of the file or an empty string)
+func sourceName(source string) string {
+	sourceParts := strings.Split(source, "/")
+	return sourceParts[len(sourceParts)-1]
 }
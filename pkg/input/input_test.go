@@ -0,0 +1,109 @@
+package input
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+func TestDecode(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	formats := map[string]string{"glider.rle": base.RleFormat, "glider.mc": base.MacrocellFormat}
+	for filename, format := range formats {
+		dataFilePath, _ := base.GetTestdataFilePath(filename)
+		content, readFileError := ioutil.ReadFile(dataFilePath)
+		if readFileError != nil {
+			t.Error(readFileError)
+			return
+		}
+		for _, decodeFormat := range []string{format, ""} {
+			gr := NewGolReader(new(gol.Gol))
+			g, decodeError := gr.Decode(strings.NewReader(string(content)), decodeFormat, nil)
+			if decodeError != nil {
+				t.Errorf("%s could not be decoded as \"%s\": %s", filename, decodeFormat, decodeError)
+				continue
+			}
+			description := "Richard K. Guy\nThe smallest, most common, and first discovered spaceship."
+			assertGolIsRight(t, filename, "Glider", description, 3, 3, true, true, base.DefaultGeneration, expectedCells, g)
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	gr := NewGolReader(new(gol.Gol))
+	if _, decodeError := gr.Decode(strings.NewReader("!Name: X\n.O\n"), "plaintext", nil); decodeError == nil {
+		t.Errorf("An error should have been returned when decoding an unknown format")
+	}
+	if _, decodeError := gr.Decode(strings.NewReader("Hello world\n"), "", nil); decodeError == nil {
+		t.Errorf("An error should have been returned when decoding content of an unknown format")
+	}
+}
+
+func TestSniffFormat(t *testing.T) {
+	expectedFormats := map[string]string{
+		"CONGOLWAY\nversion: 1\n": base.CongolwayFormat,
+		"!Name: Glider\n.O.\n":    base.CellsFormat,
+		"#Life 1.05\n#P 0 0\n*\n": base.Life105Format,
+		"#Life 1.06\n0 0\n":       base.Life106Format,
+		"GIF89a":                  base.GifFormat,
+		"\x89PNG\r\n\x1a\n":       base.PngFormat,
+		"#N Glider\n#C A comment\nx = 3, y = 3\nbo$2bo$3o!\n": base.RleFormat,
+		"x=3,y=3\n3o!\n":     base.RleFormat,
+		"[M2] (golly 2.0)\n": base.MacrocellFormat,
+	}
+	for content, expectedFormat := range expectedFormats {
+		format, formatError := SniffFormat([]byte(content))
+		if formatError != nil || format != expectedFormat {
+			t.Errorf("Content %q should be sniffed as %s, found %s (%v)", content, expectedFormat, format, formatError)
+		}
+	}
+	if _, formatError := SniffFormat([]byte("#Life 2.0\n")); formatError == nil {
+		t.Errorf("An unknown header should not be sniffed as a format")
+	}
+}
+
+func TestReadCompressedFile(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	description := "Richard K. Guy\nThe smallest, most common, and first discovered spaceship."
+	for _, filename := range []string{"glider.rle.gz", "glider.rle.zst"} {
+		dataFilePath, _ := base.GetTestdataFilePath(filename)
+		gr := NewGolReader(new(gol.Gol))
+		g, readError := gr.ReadFile(dataFilePath, nil)
+		if readError != nil {
+			t.Errorf("%s could not be read: %s", filename, readError)
+			continue
+		}
+		assertGolIsRight(t, filename, "Glider", description, 3, 3, true, true, base.DefaultGeneration, expectedCells, g)
+
+		gr = NewGolReader(new(gol.Gol))
+		g, readError = gr.ReadRleFile(dataFilePath, nil)
+		if readError != nil {
+			t.Errorf("%s could not be read as a RLE file: %s", filename, readError)
+			continue
+		}
+		assertGolIsRight(t, filename, "Glider", description, 3, 3, true, true, base.DefaultGeneration, expectedCells, g)
+	}
+
+	// Uncompressed content with a compression extension
+	filePath := copyTestdataFile(t, "glider.rle", "temp_gol*.rle.gz")
+	if filePath == "" {
+		return
+	}
+	defer os.Remove(filePath)
+	gr := NewGolReader(new(gol.Gol))
+	if _, readError := gr.ReadFile(filePath, nil); readError == nil {
+		t.Errorf("An error should have been returned when reading an uncompressed .gz file")
+	}
+}
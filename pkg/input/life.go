@@ -3,7 +3,7 @@ package input
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
@@ -14,24 +14,30 @@ import (
 // - 1.05: https://www.conwaylife.com/wiki/Life_1.05
 // - 1.06: https://www.conwaylife.com/wiki/Life_1.06
 func (gr *GolReader) ReadLifeFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
-	file, fileError := os.Open(filename)
-	defer file.Close()
-
+	file, fileError := base.OpenFile(filename)
 	if fileError != nil {
 		return nil, fileError
 	}
+	defer file.Close()
+	return gr.decodeLife(file, filename, gconf)
+}
 
-	reader := bufio.NewReader(file)
+// decodeLife : create a new Game of life from a .life stream
+// whose source is a file path (or an empty string)
+func (gr *GolReader) decodeLife(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	reader := bufio.NewReader(r)
 	headerLine, headerLineError := reader.ReadString('\n')
 	if headerLineError != nil {
 		return nil, headerLineError
 	}
+	// The header is read again by the 1.05 and 1.06 readers
+	r = io.MultiReader(strings.NewReader(headerLine), reader)
 	headerText := strings.TrimSuffix(headerLine, "\n")
 	if headerText == "#Life 1.05" {
-		return gr.ReadLife105File(filename, gconf)
+		return gr.decodeLife105(r, source, gconf)
 	}
 	if headerText == "#Life 1.06" {
-		return gr.ReadLife106File(filename, gconf)
+		return gr.decodeLife106(r, source, gconf)
 	}
 	return nil, fmt.Errorf("Invalid header \"%s\" for a Life file", headerText)
 }
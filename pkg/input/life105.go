@@ -2,13 +2,15 @@ package input
 
 import (
 	"bufio"
+	"bytes"
 	"io"
+	"io/ioutil"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
@@ -17,15 +19,23 @@ import (
 // See the following link for more information:
 // - 1.05: https://www.conwaylife.com/wiki/Life_1.05
 func (gr *GolReader) ReadLife105File(filepath string, gconf *base.GolConf) (base.GolInterface, error) {
-	file, fileError := os.Open(filepath)
-	defer file.Close()
-
+	file, fileError := base.OpenFile(filepath)
 	if fileError != nil {
 		return nil, fileError
 	}
+	defer file.Close()
+	return gr.decodeLife105(file, filepath, gconf)
+}
 
-	file.Seek(0, io.SeekStart)
-	reader := bufio.NewReader(file)
+// decodeLife105 : read a Game of life from a Life 1.05 stream
+// whose source is a file path (or an empty string)
+func (gr *GolReader) decodeLife105(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	// The cells are read once the dimensions are known
+	content, contentError := ioutil.ReadAll(r)
+	if contentError != nil {
+		return nil, contentError
+	}
+	reader := bufio.NewReader(bytes.NewReader(content))
 
 	// Get minimum grid size
 	maxX := math.MinInt32
@@ -34,7 +44,7 @@ func (gr *GolReader) ReadLife105File(filepath string, gconf *base.GolConf) (base
 	minY := math.MaxInt32
 	eof := false
 	description := ""
-	rules := ""
+	rulestring := ""
 	maxRows := 0
 	maxBlockRows := 0
 	maxWidth := -1
@@ -52,10 +62,14 @@ func (gr *GolReader) ReadLife105File(filepath string, gconf *base.GolConf) (base
 				description += strings.TrimSuffix(line[3:], "\n")
 			}
 			if line[1:2] == "N" {
-				rules += base.DefaultRules
+				rulestring = base.DefaultRules
 			}
 			if line[1:2] == "R" {
-				rules += line[2:]
+				rule, ruleError := rules.Parse(line[2:])
+				if ruleError != nil {
+					return nil, ruleError
+				}
+				rulestring = rule.String()
 			}
 			if line[1:2] == "P" {
 				maxRows = utils.MaxInt(maxRows, maxBlockRows)
@@ -90,13 +104,23 @@ func (gr *GolReader) ReadLife105File(filepath string, gconf *base.GolConf) (base
 	if gconf == nil {
 		gconf = base.NewDefaultGolConf()
 	}
-	filepathParts := strings.Split(filepath, "/")
-	name := filepathParts[len(filepathParts)-1]
+	// Rules of the file take precedence over the configuration ones
+	if rulestring != "" {
+		gconf = gconf.Copy(map[string]interface{}{"rules": rulestring})
+	}
+	name := sourceName(source)
 	g := gr.readGol
-	g.InitFromConf(name, description, rows, cols, gconf)
+	if initError := g.InitFromConf(name, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	// Unbounded grids keep the real position of the cells
+	if g.Unbounded() {
+		if originError := g.SetOrigin(minY, minX); originError != nil {
+			return nil, originError
+		}
+	}
 
-	file.Seek(0, io.SeekStart)
-	reader = bufio.NewReader(file)
+	reader = bufio.NewReader(bytes.NewReader(content))
 
 	eof = false
 	line, lineError := reader.ReadString('\n')
@@ -135,7 +159,9 @@ func (gr *GolReader) ReadLife105File(filepath string, gconf *base.GolConf) (base
 					break
 				} else {
 					colOffset := xOffset + xBlockOffset
-					gr.addLife105Row(rowIndex, colOffset, blockLine)
+					if rowError := gr.addLife105Row(rowIndex, colOffset, blockLine); rowError != nil {
+						return nil, rowError
+					}
 					rowIndex++
 				}
 			}
@@ -146,12 +172,15 @@ func (gr *GolReader) ReadLife105File(filepath string, gconf *base.GolConf) (base
 	return g, nil
 }
 
-func (gr *GolReader) addLife105Row(rowIndex, colOffset int, rawRow string) {
+func (gr *GolReader) addLife105Row(rowIndex, colOffset int, rawRow string) error {
 	row := strings.TrimSuffix(rawRow, "\n")
 	g := gr.readGol
 	for j := 0; j < len(row); j++ {
 		if row[j:j+1] == "*" {
-			g.Set(rowIndex, colOffset+j, statuses.ALIVE)
+			if setError := g.Set(rowIndex, colOffset+j, statuses.ALIVE); setError != nil {
+				return setError
+			}
 		}
 	}
+	return nil
 }
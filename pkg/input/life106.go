@@ -1,34 +1,46 @@
 package input
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"os"
+	"io/ioutil"
+	"math"
 	"strconv"
 	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
 )
 
 // ReadLife106File : read a Game of life from a Life 1.06 file.
 // See the following link for more information:
 // - 1.06: https://www.conwaylife.com/wiki/Life_1.06
 func (gr *GolReader) ReadLife106File(filepath string, gconf *base.GolConf) (base.GolInterface, error) {
-	file, fileError := os.Open(filepath)
-	defer file.Close()
-
+	file, fileError := base.OpenFile(filepath)
 	if fileError != nil {
 		return nil, fileError
 	}
+	defer file.Close()
+	return gr.decodeLife106(file, filepath, gconf)
+}
 
-	reader := newLife106Reader(file)
+// decodeLife106 : read a Game of life from a Life 1.06 stream
+// whose source is a file path (or an empty string)
+func (gr *GolReader) decodeLife106(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	// The cells are read once the dimensions are known
+	content, contentError := ioutil.ReadAll(r)
+	if contentError != nil {
+		return nil, contentError
+	}
+	reader := newLife106Reader(bytes.NewReader(content))
 
 	// Read the #Life 1.06 header
 	reader.readLine()
 
 	// Read the dimensions
-	rows, cols, dimsError := reader.readDimensions()
+	minRow, minCol, maxRow, maxCol, dimsError := reader.readDimensions()
 	if dimsError != nil {
 		return nil, dimsError
 	}
@@ -37,15 +49,35 @@ func (gr *GolReader) ReadLife106File(filepath string, gconf *base.GolConf) (base
 	if gconf == nil {
 		gconf = base.NewDefaultGolConf()
 	}
-	filepathParts := strings.Split(filepath, "/")
-	name := filepathParts[len(filepathParts)-1]
-	description := fmt.Sprintf("File path: %s", filepath)
+	// Unbounded grids fit the cells and keep their real position, bounded
+	// ones start at 0, 0 unless there are cells with negative coordinates
+	unbounded := gconf.RowLimitation() == "unbounded" || gconf.ColLimitation() == "unbounded"
+	if !unbounded {
+		minRow = utils.MinInt(0, minRow)
+		minCol = utils.MinInt(0, minCol)
+	}
+	rows := maxRow - minRow + 1
+	cols := maxCol - minCol + 1
+	name := sourceName(source)
+	description := ""
+	if source != "" {
+		description = fmt.Sprintf("File path: %s", source)
+	}
 
 	g := gr.readGol
-	g.InitFromConf(name, description, rows, cols, gconf)
+	if initError := g.InitFromConf(name, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	if unbounded {
+		if originError := g.SetOrigin(minRow, minCol); originError != nil {
+			return nil, originError
+		}
+	}
 
 	// Read alive cells
-	reader.readGrid(rows, cols, g)
+	if gridError := reader.readGrid(minRow, minCol, g); gridError != nil {
+		return nil, gridError
+	}
 	return g, nil
 }
 
@@ -65,9 +97,13 @@ func (r *life106Reader) seekStart() {
 	r.fr.SeekStart()
 }
 
-func (r *life106Reader) readDimensions() (int, int, error) {
-	maxRow := -1
-	maxCol := -1
+// readDimensions : return the coordinates of the top-left
+// and bottom-right corners of the bounding box of the cells
+func (r *life106Reader) readDimensions() (int, int, int, int, error) {
+	minRow := math.MaxInt32
+	minCol := math.MaxInt32
+	maxRow := math.MinInt32
+	maxCol := math.MinInt32
 	eof := false
 	rowNum := 1
 	for !eof {
@@ -76,38 +112,36 @@ func (r *life106Reader) readDimensions() (int, int, error) {
 			if lineError == io.EOF {
 				eof = true
 			} else {
-				return -1, -1, lineError
+				return 0, 0, -1, -1, lineError
 			}
 		}
 		line := *r.currentLine()
 		if eof && line == "" {
-			return -1, -1, lineError
+			return 0, 0, -1, -1, lineError
 		}
 		lineText := strings.TrimSuffix(line, "\n")
 		positions := strings.Split(lineText, " ")
 		if len(positions) != 2 {
-			return -1, -1, fmt.Errorf("Expected two dimensions on row %d, found line \"%s\"", rowNum, lineText)
+			return 0, 0, -1, -1, fmt.Errorf("Expected two dimensions on row %d, found line \"%s\"", rowNum, lineText)
 		}
 		row, rowError := strconv.Atoi(positions[0])
 		if rowError != nil {
-			return -1, -1, rowError
-		}
-		if maxRow < row {
-			maxRow = row
+			return 0, 0, -1, -1, rowError
 		}
+		minRow = utils.MinInt(minRow, row)
+		maxRow = utils.MaxInt(maxRow, row)
 		col, colError := strconv.Atoi(positions[1])
 		if colError != nil {
-			return -1, -1, colError
-		}
-		if maxCol < col {
-			maxCol = col
+			return 0, 0, -1, -1, colError
 		}
+		minCol = utils.MinInt(minCol, col)
+		maxCol = utils.MaxInt(maxCol, col)
 		rowNum++
 	}
-	return maxRow + 1, maxCol + 1, nil
+	return minRow, minCol, maxRow, maxCol, nil
 }
 
-func (r *life106Reader) readGrid(rows, cols int, g base.GolInterface) error {
+func (r *life106Reader) readGrid(minRow, minCol int, g base.GolInterface) error {
 	// To the top of the file again and read the life 1.06 header
 	r.seekStart()
 	r.readLine()
@@ -137,12 +171,14 @@ func (r *life106Reader) readGrid(rows, cols int, g base.GolInterface) error {
 		if colError != nil {
 			return colError
 		}
-		g.Set(row, col, statuses.ALIVE)
+		if setError := g.Set(row-minRow, col-minCol, statuses.ALIVE); setError != nil {
+			return setError
+		}
 		rowNum++
 	}
 	return nil
 }
 
-func newLife106Reader(file *os.File) *life106Reader {
-	return &life106Reader{newFileReader(file)}
+func newLife106Reader(reader io.ReadSeeker) *life106Reader {
+	return &life106Reader{newFileReader(reader)}
 }
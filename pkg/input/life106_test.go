@@ -114,4 +114,46 @@ func TestNewGolFromLife106NoCol(t *testing.T) {
 		t.Errorf("Expected error should be: \"%s\". Returned %s", expectedErrorString, golReadErrorString)
 		return
 	}
-}
\ No newline at end of file
+}
+func TestNewGolFromLife106NegativeCoordinates(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	filename := "glider_v1.06_negative.life"
+	filepath, filepathError := base.GetTestdataFilePath(filename)
+	if filepathError != nil {
+		t.Error(filepathError)
+		return
+	}
+	description := fmt.Sprintf("File path: %s", filepath)
+
+	gr := NewGolReader(new(gol.Gol))
+	g, golReadError := gr.ReadLife106File(filepath, nil)
+	if golReadError != nil {
+		t.Error(golReadError)
+		return
+	}
+	assertGolIsRight(t, filename, filename, description, 3, 3, true, true, 0, expectedCells, g)
+
+	// Unbounded grids keep the position of the cells
+	gconf := base.NewGolConf(map[string]interface{}{
+		"rowLimitation": "unbounded",
+		"colLimitation": "unbounded",
+	})
+	ug, ugReadError := NewGolReader(new(gol.Gol)).ReadLife106File(filepath, gconf)
+	if ugReadError != nil {
+		t.Error(ugReadError)
+		return
+	}
+	if !ug.Unbounded() {
+		t.Errorf("Grid should be unbounded")
+		return
+	}
+	originI, originJ := ug.Origin()
+	if originI != -2 || originJ != -2 {
+		t.Errorf("Origin should be -2,-2, found %d,%d", originI, originJ)
+	}
+	assertGolIsRight(t, filename, filename, description, 3, 3, false, false, 0, expectedCells, ug)
+}
@@ -0,0 +1,277 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// macrocellMaxLevel : maximum level of the nodes of a macrocell
+// file, so the coordinates of their cells fit in an int
+const macrocellMaxLevel = 62
+
+// macrocellNode : node of the quadtree of a macrocell file. Leaves are
+// 8x8 cells (two-state rules) or 2x2 cells (multi-state rules), and the
+// other nodes have the indexes of their four children (0 is an empty node).
+type macrocellNode struct {
+	level int
+	// Cells of the leaves, by rows
+	cells [][]int
+	// Children of the other nodes: nw, ne, sw, se
+	children [4]int
+}
+
+// macrocellBounds : bounding box of the alive cells of a node,
+// relative to its top-left cell
+type macrocellBounds struct {
+	minI, minJ, maxI, maxJ int
+}
+
+// ReadMacrocellFile : read a Game of life from a Golly macrocell (.mc)
+// file. The cells of the quadtree are centered in the 0, 0 position, so
+// unbounded grids keep their real position, and bounded grids get the size
+// of the bounding box of the alive cells. The #R (rules) and #G (generation)
+// lines take precedence over the configuration ones, the #N line is the name
+// of the pattern (the file name if there is none) and the #C and #D lines
+// are its description.
+// See https://www.conwaylife.com/wiki/Macrocell
+func (gr *GolReader) ReadMacrocellFile(filepath string, gconf *base.GolConf) (base.GolInterface, error) {
+	file, fileError := base.OpenFile(filepath)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	return gr.decodeMacrocell(file, filepath, gconf)
+}
+
+// decodeMacrocell : read a Game of life from a macrocell stream
+// whose source is a file path (or an empty string)
+func (gr *GolReader) decodeMacrocell(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "[M2]") {
+		return nil, fmt.Errorf("[M2] header expected in the first line of the macrocell file")
+	}
+
+	if gconf == nil {
+		gconf = base.NewDefaultGolConf()
+	}
+	name := sourceName(source)
+	var descriptionLines []string
+	// Nodes are 1-indexed, 0 is the empty node
+	nodes := []*macrocellNode{nil}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if len(line) < 2 {
+				continue
+			}
+			content := strings.TrimSpace(line[2:])
+			switch line[1] {
+			case 'N':
+				name = content
+			case 'C', 'D':
+				descriptionLines = append(descriptionLines, content)
+			case 'R':
+				rule, ruleError := rules.Parse(content)
+				if ruleError != nil {
+					return nil, ruleError
+				}
+				gconf = gconf.Copy(map[string]interface{}{"rules": rule.String()})
+			case 'G':
+				generation, generationError := strconv.Atoi(content)
+				if generationError != nil || generation < 0 {
+					return nil, fmt.Errorf("#G G where G is a positive integer, found %s", content)
+				}
+				gconf = gconf.Copy(map[string]interface{}{"generation": generation})
+			}
+			continue
+		}
+		node, nodeError := parseMacrocellNode(line, nodes)
+		if nodeError != nil {
+			return nil, fmt.Errorf("Invalid node %d: %s", len(nodes), nodeError)
+		}
+		nodes = append(nodes, node)
+	}
+	if scannerError := scanner.Err(); scannerError != nil {
+		return nil, scannerError
+	}
+
+	// The root is the last node
+	root := len(nodes) - 1
+	bounds := make(map[int]*macrocellBounds)
+	rootBounds := macrocellNodeBounds(nodes, root, bounds)
+	rows, cols := 0, 0
+	originI, originJ := 0, 0
+	if rootBounds != nil {
+		rows = rootBounds.maxI - rootBounds.minI + 1
+		cols = rootBounds.maxJ - rootBounds.minJ + 1
+		half := 0
+		if root > 0 {
+			half = (1 << uint(nodes[root].level)) / 2
+		}
+		originI, originJ = rootBounds.minI-half, rootBounds.minJ-half
+	}
+
+	g := gr.readGol
+	description := strings.Join(descriptionLines, "\n")
+	if initError := g.InitFromConf(name, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	if rootBounds == nil {
+		return g, nil
+	}
+	// Unbounded grids keep the real position of the cells
+	if g.Unbounded() {
+		if originError := g.SetOrigin(originI, originJ); originError != nil {
+			return nil, originError
+		}
+	}
+	setError := setMacrocellCells(nodes, root, -rootBounds.minI, -rootBounds.minJ, bounds, g)
+	if setError != nil {
+		return nil, setError
+	}
+	return g, nil
+}
+
+// parseMacrocellNode : parse a line with a 8x8 leaf (e.g. "$.*$..*$***$")
+// or a node with its level and children (e.g. "4 1 0 2 3")
+func parseMacrocellNode(line string, nodes []*macrocellNode) (*macrocellNode, error) {
+	if line[0] == '.' || line[0] == '*' || line[0] == '$' {
+		node := &macrocellNode{level: 3, cells: make([][]int, 8)}
+		for i := range node.cells {
+			node.cells[i] = make([]int, 8)
+		}
+		i, j := 0, 0
+		for _, cell := range line {
+			if cell == '$' {
+				i++
+				j = 0
+				continue
+			}
+			if i >= 8 || j >= 8 || (cell != '.' && cell != '*') {
+				return nil, fmt.Errorf("8x8 rows of . and * separated by $ expected, found %s", line)
+			}
+			if cell == '*' {
+				node.cells[i][j] = statuses.ALIVE
+			}
+			j++
+		}
+		return node, nil
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("level and four children expected, found %s", line)
+	}
+	values := make([]int, 5)
+	for valueI, field := range fields {
+		value, valueError := strconv.Atoi(field)
+		if valueError != nil || value < 0 {
+			return nil, fmt.Errorf("non-negative integers expected, found %s", line)
+		}
+		values[valueI] = value
+	}
+	node := &macrocellNode{level: values[0]}
+	if node.level < 1 || node.level > macrocellMaxLevel {
+		return nil, fmt.Errorf("level must be between 1 and %d, found %d", macrocellMaxLevel, node.level)
+	}
+	if node.level == 1 {
+		// Multi-state leaves have the states of their 2x2 cells
+		node.cells = [][]int{{values[1], values[2]}, {values[3], values[4]}}
+		return node, nil
+	}
+	for childI, child := range values[1:] {
+		if child >= len(nodes) {
+			return nil, fmt.Errorf("child %d is not defined yet", child)
+		}
+		if child > 0 && nodes[child].level != node.level-1 {
+			return nil, fmt.Errorf("child %d should have level %d", child, node.level-1)
+		}
+		node.children[childI] = child
+	}
+	return node, nil
+}
+
+// macrocellNodeBounds : return the bounding box of the alive cells of
+// a node (nil if it is empty), memoizing them as nodes are shared
+func macrocellNodeBounds(nodes []*macrocellNode, nodeI int, bounds map[int]*macrocellBounds) *macrocellBounds {
+	if nodeI == 0 {
+		return nil
+	}
+	if nodeBounds, isComputed := bounds[nodeI]; isComputed {
+		return nodeBounds
+	}
+	node := nodes[nodeI]
+	var nodeBounds *macrocellBounds
+	extend := func(i, j int) {
+		if nodeBounds == nil {
+			nodeBounds = &macrocellBounds{i, j, i, j}
+			return
+		}
+		nodeBounds.minI = utils.MinInt(nodeBounds.minI, i)
+		nodeBounds.minJ = utils.MinInt(nodeBounds.minJ, j)
+		nodeBounds.maxI = utils.MaxInt(nodeBounds.maxI, i)
+		nodeBounds.maxJ = utils.MaxInt(nodeBounds.maxJ, j)
+	}
+	if node.cells != nil {
+		for i, row := range node.cells {
+			for j, cell := range row {
+				if cell != statuses.DEAD {
+					extend(i, j)
+				}
+			}
+		}
+	} else {
+		half := (1 << uint(node.level)) / 2
+		for childI, child := range node.children {
+			childBounds := macrocellNodeBounds(nodes, child, bounds)
+			if childBounds == nil {
+				continue
+			}
+			offsetI, offsetJ := (childI/2)*half, (childI%2)*half
+			extend(offsetI+childBounds.minI, offsetJ+childBounds.minJ)
+			extend(offsetI+childBounds.maxI, offsetJ+childBounds.maxJ)
+		}
+	}
+	bounds[nodeI] = nodeBounds
+	return nodeBounds
+}
+
+// setMacrocellCells : set the cells of a node whose
+// top-left cell is in the i, j position of the grid
+func setMacrocellCells(nodes []*macrocellNode, nodeI int, i, j int, bounds map[int]*macrocellBounds, g base.GolInterface) error {
+	if bounds[nodeI] == nil {
+		return nil
+	}
+	node := nodes[nodeI]
+	if node.cells != nil {
+		for cellI, row := range node.cells {
+			for cellJ, cell := range row {
+				if cell != statuses.DEAD {
+					if setError := g.Set(i+cellI, j+cellJ, cell); setError != nil {
+						return setError
+					}
+				}
+			}
+		}
+		return nil
+	}
+	half := (1 << uint(node.level)) / 2
+	for childI, child := range node.children {
+		childError := setMacrocellCells(nodes, child, i+(childI/2)*half, j+(childI%2)*half, bounds, g)
+		if childError != nil {
+			return childError
+		}
+	}
+	return nil
+}
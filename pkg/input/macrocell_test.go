@@ -0,0 +1,89 @@
+package input
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+func TestNewGolFromMacrocellFile(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	filename := "glider.mc"
+	description := "Richard K. Guy\nThe smallest, most common, and first discovered spaceship."
+	g, readError := readTestdataFile(filename, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	assertGolIsRight(t, filename, "Glider", description, 3, 3, true, true, base.DefaultGeneration, expectedCells, g)
+	if g.Rules() != "B3/S23" {
+		t.Errorf("The rules should be B3/S23, found %s", g.Rules())
+	}
+}
+
+func TestNewGolFromUnboundedMacrocellFile(t *testing.T) {
+	gconf := base.NewGolConf(map[string]interface{}{
+		"rowLimitation": "unbounded",
+		"colLimitation": "unbounded",
+	})
+	g, readError := readTestdataFile("glider.mc", gconf)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	originI, originJ := g.Origin()
+	if originI != 0 || originJ != 0 {
+		t.Errorf("The origin should be 0, 0, found %d, %d", originI, originJ)
+	}
+}
+
+func TestNewGolFromMultiStateMacrocellFile(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, 2, D},
+		{A, A, D, D},
+		{D, D, D, D},
+		{D, D, D, 2},
+	}
+	filename := "brians_brain.mc"
+	g, readError := readTestdataFile(filename, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	assertGolIsRight(t, filename, "Brian's Brain", "", 4, 4, true, true, 7, expectedCells, g)
+	if g.Rules() != "B2/S/C3" {
+		t.Errorf("The rules should be B2/S/C3, found %s", g.Rules())
+	}
+}
+
+func TestNewGolFromInvalidMacrocellFile(t *testing.T) {
+	invalidContents := []string{
+		"#R B3/S23\n.*$\n",
+		"[M2]\n.*$.*$.*$.*$.*$.*$.*$.*$.*$\n",
+		"[M2]\n.*o$\n",
+		"[M2]\n.*$\n4 0 0 0 2\n",
+		"[M2]\n1 0 1 1 1\n3 0 0 0 1\n",
+		"[M2]\n4 0 0 1\n",
+	}
+	for _, invalidContent := range invalidContents {
+		file, err := ioutil.TempFile("", "temp_gol*.mc")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		file.WriteString(invalidContent)
+		file.Close()
+		gr := NewGolReader(new(gol.Gol))
+		if _, readError := gr.ReadFile(file.Name(), nil); readError == nil {
+			t.Errorf("Macrocell file\n%s\nshould be invalid", invalidContent)
+		}
+		os.Remove(file.Name())
+	}
+}
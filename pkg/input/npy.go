@@ -0,0 +1,247 @@
+package input
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+)
+
+// npyMagic : first bytes of the NumPy .npy files
+const npyMagic = "\x93NUMPY"
+
+// npyDescrRegex : integer and boolean types of the arrays that can be read
+var npyDescrRegex = regexp.MustCompile(`'descr':\s*'([<>|=]?)([biu])(1|2|4|8)'`)
+
+// npyFortranOrderRegex : order of the elements of the array
+var npyFortranOrderRegex = regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+
+// npyShapeRegex : dimensions of the array
+var npyShapeRegex = regexp.MustCompile(`'shape':\s*\(([^)]*)\)`)
+
+// npyArray : cells of the grids of a .npy file, in row-major order
+type npyArray struct {
+	grids int
+	rows  int
+	cols  int
+	cells []int
+}
+
+// get : return the state of the cell i, j of a grid
+func (array *npyArray) get(grid, i, j int) int {
+	return array.cells[(grid*array.rows+i)*array.cols+j]
+}
+
+// ReadNpyFile : create a new Game of life from a NumPy .npy file with a 2-D
+// array of integer or boolean states (the first grid of a 3-D array is read).
+// See https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html
+func (gr *GolReader) ReadNpyFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
+	file, fileError := base.OpenFile(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	return gr.decodeNpy(file, filename, gconf)
+}
+
+// ReadNpyGenerations : create a Game of life instance for each grid of a
+// NumPy .npy file with a 3-D array (or for the only grid of a 2-D array).
+// The first grid fills the instance of the reader, and the rest of them
+// are clones whose generations are the following ones.
+func (gr *GolReader) ReadNpyGenerations(filename string, gconf *base.GolConf) ([]base.GolInterface, error) {
+	file, fileError := base.OpenFile(filename)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	array, arrayError := readNpyArray(file)
+	if arrayError != nil {
+		return nil, arrayError
+	}
+	first, initError := gr.initNpyGol(array, filename, gconf)
+	if initError != nil {
+		return nil, initError
+	}
+	gols := []base.GolInterface{first}
+	for grid := 1; grid < array.grids; grid++ {
+		g := first.Clone()
+		if cellsError := setNpyCells(array, grid, g); cellsError != nil {
+			return nil, cellsError
+		}
+		g.SetGeneration(first.Generation() + grid)
+		gols = append(gols, g)
+	}
+	return gols, nil
+}
+
+// decodeNpy : create a new Game of life from a NumPy .npy
+// stream whose source is a file path (or an empty string)
+func (gr *GolReader) decodeNpy(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	array, arrayError := readNpyArray(r)
+	if arrayError != nil {
+		return nil, arrayError
+	}
+	return gr.initNpyGol(array, source, gconf)
+}
+
+// initNpyGol : initialize the instance of the reader
+// with the cells of the first grid of an array
+func (gr *GolReader) initNpyGol(array *npyArray, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	if gconf == nil {
+		gconf = base.NewDefaultGolConf()
+	}
+	g := gr.readGol
+	if initError := g.InitFromConf(sourceName(source), "", array.rows, array.cols, gconf); initError != nil {
+		return nil, initError
+	}
+	if cellsError := setNpyCells(array, 0, g); cellsError != nil {
+		return nil, cellsError
+	}
+	return g, nil
+}
+
+// setNpyCells : set the cells of an instance from a grid of an array
+func setNpyCells(array *npyArray, grid int, g base.GolInterface) error {
+	for i := 0; i < array.rows; i++ {
+		for j := 0; j < array.cols; j++ {
+			if setError := g.Set(i, j, array.get(grid, i, j)); setError != nil {
+				return setError
+			}
+		}
+	}
+	return nil
+}
+
+// readNpyArray : read the header and the cells of a .npy stream
+func readNpyArray(r io.Reader) (*npyArray, error) {
+	reader := bufio.NewReader(r)
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, prefixError := io.ReadFull(reader, prefix); prefixError != nil {
+		return nil, prefixError
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("Invalid header for a .npy file")
+	}
+	// The length of the header has 2 bytes in the version 1
+	// and 4 bytes in the versions 2 and 3
+	var headerLength uint32
+	switch majorVersion := prefix[len(npyMagic)]; majorVersion {
+	case 1:
+		var headerLength16 uint16
+		if lengthError := binary.Read(reader, binary.LittleEndian, &headerLength16); lengthError != nil {
+			return nil, lengthError
+		}
+		headerLength = uint32(headerLength16)
+	case 2, 3:
+		if lengthError := binary.Read(reader, binary.LittleEndian, &headerLength); lengthError != nil {
+			return nil, lengthError
+		}
+	default:
+		return nil, fmt.Errorf("Version %d of the .npy files is not supported", majorVersion)
+	}
+	header := make([]byte, headerLength)
+	if _, headerError := io.ReadFull(reader, header); headerError != nil {
+		return nil, headerError
+	}
+
+	descrMatch := npyDescrRegex.FindSubmatch(header)
+	if descrMatch == nil {
+		return nil, fmt.Errorf("Only arrays of integers or booleans can be read, found header %s",
+			strings.TrimSpace(string(header)))
+	}
+	var byteOrder binary.ByteOrder = binary.LittleEndian
+	if string(descrMatch[1]) == ">" {
+		byteOrder = binary.BigEndian
+	}
+	signed := string(descrMatch[2]) == "i"
+	itemSize, _ := strconv.Atoi(string(descrMatch[3]))
+
+	fortranOrderMatch := npyFortranOrderRegex.FindSubmatch(header)
+	if fortranOrderMatch == nil {
+		return nil, fmt.Errorf("fortran_order not found in the header of the .npy file")
+	}
+	fortranOrder := string(fortranOrderMatch[1]) == "True"
+
+	shapeMatch := npyShapeRegex.FindSubmatch(header)
+	if shapeMatch == nil {
+		return nil, fmt.Errorf("shape not found in the header of the .npy file")
+	}
+	var shape []int
+	for _, sizeString := range strings.Split(string(shapeMatch[1]), ",") {
+		sizeString = strings.TrimSpace(sizeString)
+		if sizeString == "" {
+			continue
+		}
+		size, sizeError := strconv.Atoi(sizeString)
+		if sizeError != nil || size < 0 {
+			return nil, fmt.Errorf("Invalid shape (%s) of the .npy file", shapeMatch[1])
+		}
+		shape = append(shape, size)
+	}
+	array := &npyArray{grids: 1}
+	switch len(shape) {
+	case 2:
+		array.rows, array.cols = shape[0], shape[1]
+	case 3:
+		array.grids, array.rows, array.cols = shape[0], shape[1], shape[2]
+	default:
+		return nil, fmt.Errorf("Only 2-D and 3-D arrays can be read, found %d dimensions", len(shape))
+	}
+	if array.grids < 1 {
+		return nil, fmt.Errorf("The 3-D array of the .npy file has no grids")
+	}
+
+	data, dataError := ioutil.ReadAll(reader)
+	if dataError != nil {
+		return nil, dataError
+	}
+	length := array.grids * array.rows * array.cols
+	if len(data) < length*itemSize {
+		return nil, fmt.Errorf("The .npy file has %d bytes of data, expected %d", len(data), length*itemSize)
+	}
+	array.cells = make([]int, length)
+	for index := 0; index < length; index++ {
+		value := npyValue(data[index*itemSize:(index+1)*itemSize], byteOrder, signed)
+		if fortranOrder {
+			// The first index varies the fastest
+			grid := index % array.grids
+			i := (index / array.grids) % array.rows
+			j := index / (array.grids * array.rows)
+			array.cells[(grid*array.rows+i)*array.cols+j] = value
+		} else {
+			array.cells[index] = value
+		}
+	}
+	return array, nil
+}
+
+// npyValue : return the integer stored in the bytes of an item
+func npyValue(item []byte, byteOrder binary.ByteOrder, signed bool) int {
+	switch len(item) {
+	case 1:
+		if signed {
+			return int(int8(item[0]))
+		}
+		return int(item[0])
+	case 2:
+		if signed {
+			return int(int16(byteOrder.Uint16(item)))
+		}
+		return int(byteOrder.Uint16(item))
+	case 4:
+		if signed {
+			return int(int32(byteOrder.Uint32(item)))
+		}
+		return int(byteOrder.Uint32(item))
+	}
+	if signed {
+		return int(int64(byteOrder.Uint64(item)))
+	}
+	return int(byteOrder.Uint64(item))
+}
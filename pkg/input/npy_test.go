@@ -0,0 +1,55 @@
+package input
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+func TestReadNpyFile(t *testing.T) {
+	// Array of int64 in row-major order and array of booleans in column-major order
+	for _, filename := range []string{"glider.npy", "glider_fortran.npy"} {
+		dataFilePath, _ := base.GetTestdataFilePath(filename)
+		gr := NewGolReader(new(gol.Gol))
+		g, readError := gr.ReadFile(dataFilePath, nil)
+		if readError != nil {
+			t.Errorf("%s could not be read: %s", filename, readError)
+			continue
+		}
+		assertGolIsRight(t, filename, filename, "", 3, 3, true, true, base.DefaultGeneration, gliderCells, g)
+	}
+}
+
+func TestReadNpyGenerations(t *testing.T) {
+	dataFilePath, _ := base.GetTestdataFilePath("glider.npy")
+	gr := NewGolReader(new(gol.Gol))
+	gols, readError := gr.ReadNpyGenerations(dataFilePath, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if len(gols) != 1 {
+		t.Errorf("A 2-D array should be read as 1 generation, found %d", len(gols))
+		return
+	}
+	assertGolIsRight(t, "glider.npy", "glider.npy", "", 3, 3, true, true, base.DefaultGeneration, gliderCells, gols[0])
+}
+
+func TestDecodeNpyError(t *testing.T) {
+	headers := []string{
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (1, 1), }\n",
+		"{'descr': '|u1', 'fortran_order': False, 'shape': (1,), }\n",
+		"{'descr': '|u1', 'fortran_order': False, 'shape': (2, 2), }\n",
+		"{'descr': '|u1', 'shape': (1, 1), }\n",
+	}
+	for _, header := range headers {
+		content := append([]byte("\x93NUMPY\x01\x00"), byte(len(header)), 0)
+		content = append(append(content, header...), 0)
+		gr := NewGolReader(new(gol.Gol))
+		if _, decodeError := gr.Decode(bytes.NewReader(content), base.NpyFormat, nil); decodeError == nil {
+			t.Errorf("An error should have been returned when decoding the header %s", header)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package input
+
+import (
+	"github.com/diegojromerolopez/congolway/pkg/base"
+)
+
+// ReadPngFile : create a new Game of life from a .png file
+func (gr *GolReader) ReadPngFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
+	return gr.ReadImageFile(filename, gconf)
+}
@@ -0,0 +1,42 @@
+package input
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+func TestNewGolFromPngStream(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	for i, row := range expectedCells {
+		for j, cell := range row {
+			cellColor := color.White
+			if cell == A {
+				cellColor = color.Black
+			}
+			img.Set(j, i, cellColor)
+		}
+	}
+	var buffer bytes.Buffer
+	if encodeError := png.Encode(&buffer, img); encodeError != nil {
+		t.Error(encodeError)
+		return
+	}
+
+	gr := NewGolReader(new(gol.Gol))
+	g, decodeError := gr.Decode(&buffer, "", nil)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	assertGolIsRight(t, "", "", "", 3, 3, true, true, 0, expectedCells, g)
+}
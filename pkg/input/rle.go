@@ -0,0 +1,267 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/rules"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// ReadRleFile : read a Game of life from a Run Length Encoded (.rle) file.
+// The #N line is the name of the pattern (the file name if there is none),
+// the #C, #c and #O lines are its description and the #R or #P lines the
+// position of its top-left cell (only used by unbounded grids).
+// The rules of the header (if any) take precedence over the configuration
+// ones, and a Golly topology suffix (":P" for a plane, ":T" for a torus)
+// limits the rows and columns of bounded grids.
+// See https://www.conwaylife.com/wiki/Run_Length_Encoded
+func (gr *GolReader) ReadRleFile(filepath string, gconf *base.GolConf) (base.GolInterface, error) {
+	file, fileError := base.OpenFile(filepath)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+	return gr.decodeRle(file, filepath, gconf)
+}
+
+// decodeRle : read a Game of life from a Run Length Encoded stream
+// whose source is a file path (or an empty string)
+func (gr *GolReader) decodeRle(r io.Reader, source string, gconf *base.GolConf) (base.GolInterface, error) {
+	name := sourceName(source)
+	var descriptionLines []string
+	originI, originJ := 0, 0
+	headerFound := false
+	rows, cols := 0, 0
+	rulestring, topology := "", ""
+	var data strings.Builder
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if headerFound {
+			data.WriteString(line)
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if len(line) < 2 {
+				continue
+			}
+			content := strings.TrimSpace(line[2:])
+			switch line[1] {
+			case 'N':
+				name = content
+			case 'C', 'c', 'O':
+				descriptionLines = append(descriptionLines, content)
+			case 'R', 'P':
+				var positionError error
+				originJ, originI, positionError = parseRlePosition(content)
+				if positionError != nil {
+					return nil, positionError
+				}
+			}
+			continue
+		}
+		var headerError error
+		rows, cols, rulestring, topology, headerError = parseRleHeader(line)
+		if headerError != nil {
+			return nil, headerError
+		}
+		headerFound = true
+	}
+	if scannerError := scanner.Err(); scannerError != nil {
+		return nil, scannerError
+	}
+	if !headerFound {
+		return nil, fmt.Errorf("\"x = m, y = n\" header expected, but it was not found")
+	}
+
+	if gconf == nil {
+		gconf = base.NewDefaultGolConf()
+	}
+	if rulestring != "" {
+		rule, ruleError := rules.Parse(rulestring)
+		if ruleError != nil {
+			return nil, ruleError
+		}
+		gconf = gconf.Copy(map[string]interface{}{"rules": rule.String()})
+	}
+	unbounded := gconf.RowLimitation() == "unbounded" || gconf.ColLimitation() == "unbounded"
+	if topology != "" && !unbounded {
+		limitation := "limited"
+		if topology == "T" {
+			limitation = "unlimited"
+		}
+		gconf = gconf.Copy(map[string]interface{}{"rowLimitation": limitation, "colLimitation": limitation})
+	}
+
+	g := gr.readGol
+	description := strings.Join(descriptionLines, "\n")
+	if initError := g.InitFromConf(name, description, rows, cols, gconf); initError != nil {
+		return nil, initError
+	}
+	// Unbounded grids keep the real position of the cells
+	if g.Unbounded() {
+		if originError := g.SetOrigin(originI, originJ); originError != nil {
+			return nil, originError
+		}
+	}
+	if cellsError := readRleCells(data.String(), rows, cols, g); cellsError != nil {
+		return nil, cellsError
+	}
+	return g, nil
+}
+
+// parseRlePosition : parse the "x y" position of the top-left cell
+func parseRlePosition(position string) (int, int, error) {
+	coordinates := strings.Fields(position)
+	if len(coordinates) != 2 {
+		return 0, 0, fmt.Errorf("\"x y\" position expected, found \"%s\"", position)
+	}
+	x, xError := strconv.Atoi(coordinates[0])
+	if xError != nil {
+		return 0, 0, xError
+	}
+	y, yError := strconv.Atoi(coordinates[1])
+	if yError != nil {
+		return 0, 0, yError
+	}
+	return x, y, nil
+}
+
+// parseRleHeader : parse the "x = m, y = n, rule = B3/S23" header line,
+// returning the rows, cols, rules and topology (P, T or empty)
+func parseRleHeader(line string) (int, int, string, string, error) {
+	rows, cols := -1, -1
+	rulestring, topology := "", ""
+	// The rule is the last parameter, and it can have commas
+	// (e.g. Larger than Life rules or Golly bounded grids)
+	parameters := line
+	if ruleIndex := strings.Index(line, "rule"); ruleIndex >= 0 {
+		parameters = line[:ruleIndex]
+		ruleParts := strings.SplitN(line[ruleIndex:], "=", 2)
+		if len(ruleParts) != 2 {
+			return 0, 0, "", "", fmt.Errorf("\"rule = B3/S23\" expected, found \"%s\"", line[ruleIndex:])
+		}
+		rulestring = strings.TrimSpace(ruleParts[1])
+		if colonIndex := strings.Index(rulestring, ":"); colonIndex >= 0 {
+			topology = strings.ToUpper(strings.TrimSpace(rulestring[colonIndex+1:]))
+			rulestring = strings.TrimSpace(rulestring[:colonIndex])
+			if topology == "" || (topology[0] != 'P' && topology[0] != 'T') {
+				return 0, 0, "", "", fmt.Errorf(
+					"Only plane (P) and torus (T) topologies are accepted, found \"%s\"", topology,
+				)
+			}
+			topology = topology[0:1]
+		}
+	}
+	for _, parameter := range strings.Split(parameters, ",") {
+		if strings.TrimSpace(parameter) == "" {
+			continue
+		}
+		parameterParts := strings.SplitN(parameter, "=", 2)
+		if len(parameterParts) != 2 {
+			return 0, 0, "", "", fmt.Errorf("\"name = value\" parameter expected, found \"%s\"", parameter)
+		}
+		value, valueError := strconv.Atoi(strings.TrimSpace(parameterParts[1]))
+		if valueError != nil || value < 0 {
+			return 0, 0, "", "", fmt.Errorf("Non-negative integer expected, found \"%s\"", parameterParts[1])
+		}
+		switch strings.TrimSpace(parameterParts[0]) {
+		case "x":
+			cols = value
+		case "y":
+			rows = value
+		default:
+			return 0, 0, "", "", fmt.Errorf("x, y or rule parameter expected, found \"%s\"", parameterParts[0])
+		}
+	}
+	if rows < 0 || cols < 0 {
+		return 0, 0, "", "", fmt.Errorf("\"x = m, y = n\" header expected, found \"%s\"", line)
+	}
+	return rows, cols, rulestring, topology, nil
+}
+
+// readRleCells : set the cells of the Game of life according to the
+// RLE data. Each run is an optional count followed by a tag: b or . for
+// dead cells, o for alive cells, A-X for the states 1-24 and p-y followed
+// by A-X for the states 25-255 (multi-state rules). $ ends a row and ! ends
+// the pattern.
+func readRleCells(data string, rows, cols int, g base.GolInterface) error {
+	i, j := 0, 0
+	count := 0
+	prefix := byte(0)
+	for pos := 0; pos < len(data); pos++ {
+		tag := data[pos]
+		if tag >= '0' && tag <= '9' {
+			count = count*10 + int(tag-'0')
+			continue
+		}
+		if tag == ' ' || tag == '\t' {
+			continue
+		}
+		if tag == '!' {
+			return nil
+		}
+		if tag >= 'p' && tag <= 'y' {
+			// The count is the one of the multi-state tag
+			prefix = tag
+			continue
+		}
+		runLength := 1
+		if count > 0 {
+			runLength = count
+		}
+		count = 0
+		if tag == '$' {
+			i += runLength
+			j = 0
+			continue
+		}
+		status, statusError := rleStatus(prefix, tag)
+		if statusError != nil {
+			return statusError
+		}
+		prefix = 0
+		if status != statuses.DEAD {
+			if i >= rows || j+runLength > cols {
+				return fmt.Errorf("Run of %d cells at %d,%d is outside of the %dx%d grid", runLength, i, j, rows, cols)
+			}
+			for k := 0; k < runLength; k++ {
+				if setError := g.Set(i, j+k, status); setError != nil {
+					return setError
+				}
+			}
+		}
+		j += runLength
+	}
+	return fmt.Errorf("The RLE data should end with !")
+}
+
+// rleStatus : return the status of a tag with an optional
+// multi-state prefix (p-y), e.g. "o" is 1 and "pA" is 25
+func rleStatus(prefix, tag byte) (int, error) {
+	if prefix == 0 {
+		switch {
+		case tag == 'b' || tag == '.':
+			return statuses.DEAD, nil
+		case tag == 'o':
+			return statuses.ALIVE, nil
+		case tag >= 'A' && tag <= 'X':
+			return int(tag-'A') + 1, nil
+		}
+	} else if tag >= 'A' && tag <= 'X' {
+		return int(prefix-'p'+1)*24 + int(tag-'A') + 1, nil
+	}
+	if prefix != 0 {
+		return 0, fmt.Errorf("Invalid RLE tag %c%c", prefix, tag)
+	}
+	return 0, fmt.Errorf("Invalid RLE tag %c", tag)
+}
@@ -0,0 +1,92 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+func TestNewGolFromRleFile(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, D},
+		{D, D, A},
+		{A, A, A},
+	}
+	filename := "glider.rle"
+	description := "Richard K. Guy\nThe smallest, most common, and first discovered spaceship."
+	g, readError := readTestdataFile(filename, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	assertGolIsRight(t, filename, "Glider", description, 3, 3, true, true, base.DefaultGeneration, expectedCells, g)
+	if g.Rules() != "B3/S23" {
+		t.Errorf("The rules should be B3/S23, found %s", g.Rules())
+	}
+}
+
+func TestNewGolFromMultiStateRleFile(t *testing.T) {
+	var expectedCells [][]int = [][]int{
+		{D, A, 2, D},
+		{A, A, D, D},
+		{D, D, D, D},
+		{D, D, D, 2},
+	}
+	filename := "brians_brain.rle"
+	g, readError := readTestdataFile(filename, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	// Torus topology
+	assertGolIsRight(t, filename, "Brian's Brain", "Three-state rule", 4, 4, false, false,
+		base.DefaultGeneration, expectedCells, g)
+	if g.Rules() != "B2/S/C3" {
+		t.Errorf("The rules should be B2/S/C3, found %s", g.Rules())
+	}
+}
+
+func TestRleHeader(t *testing.T) {
+	rows, cols, rulestring, topology, headerError := parseRleHeader("x = 12, y = 7, rule = R2,C0,M1,S2..3,B3..3,NM:P12,7")
+	if headerError != nil {
+		t.Error(headerError)
+		return
+	}
+	if rows != 7 || cols != 12 || rulestring != "R2,C0,M1,S2..3,B3..3,NM" || topology != "P" {
+		t.Errorf("Wrong header: %d rows, %d cols, rule %s and topology %s", rows, cols, rulestring, topology)
+	}
+
+	invalidHeaders := []string{"x = 3", "y = 3, x = a", "x = 3, y = 3, z = 1", "x = 3, y = 3, rule = B3/S23:K3,3"}
+	for _, invalidHeader := range invalidHeaders {
+		if _, _, _, _, headerError := parseRleHeader(invalidHeader); headerError == nil {
+			t.Errorf("Header \"%s\" should be invalid", invalidHeader)
+		}
+	}
+}
+
+func TestRleStatus(t *testing.T) {
+	expectedStatuses := map[string]int{"b": D, ".": D, "o": A, "A": 1, "B": 2, "X": 24, "pA": 25, "pX": 48, "qA": 49, "yO": 255}
+	for tag, expectedStatus := range expectedStatuses {
+		prefix := byte(0)
+		if len(tag) == 2 {
+			prefix = tag[0]
+		}
+		status, statusError := rleStatus(prefix, tag[len(tag)-1])
+		if statusError != nil || status != expectedStatus {
+			t.Errorf("Tag %s should be status %d, found %d (%v)", tag, expectedStatus, status, statusError)
+		}
+	}
+	if _, statusError := rleStatus(0, 'z'); statusError == nil {
+		t.Errorf("Tag z should be invalid")
+	}
+}
+
+func readTestdataFile(filename string, gconf *base.GolConf) (base.GolInterface, error) {
+	dataFilePath, dataFilePathError := base.GetTestdataFilePath(filename)
+	if dataFilePathError != nil {
+		return nil, dataFilePathError
+	}
+	gr := NewGolReader(new(gol.Gol))
+	return gr.ReadFile(dataFilePath, gconf)
+}
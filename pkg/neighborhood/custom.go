@@ -0,0 +1,195 @@
+package neighborhood
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Custom neighborhoods are described by a mask of integer weights centered
+// in the cell, like the ones of Golly's weighted rules. Cells whose weight
+// is 0 are not neighbors, and the weights of the neighbors are added
+// when counting them (see NeighborsCount).
+//
+// Masks are written as rows of weights, one row per line (or separated
+// by semicolons when inlined, e.g. "1,2,1;2,0,2;1,2,1"), with the weights
+// separated by spaces or commas. Lines starting with # are comments.
+// Masks must have an odd number of rows and columns.
+
+// CUSTOM : neighborhood type of the first custom neighborhood registered,
+// the next ones get the following neighborhood types (see RegisterMask)
+const CUSTOM = 6
+
+// CUSTOMSTRING : prefix of the name of the custom neighborhoods,
+// that is followed by their inlined mask (e.g. "Custom 1,2,1;2,0,2;1,2,1")
+const CUSTOMSTRING = "Custom"
+
+// Mask : weights of the neighbors of a custom neighborhood
+type Mask struct {
+	weights [][]int
+	// Offsets and weights of the cells whose weight is not 0
+	offsets       [][2]int
+	offsetWeights []int
+}
+
+// customNeighborhoods : registry of the custom neighborhoods, so each
+// different mask has its own neighborhood type
+var customNeighborhoods = struct {
+	sync.RWMutex
+	masks []*Mask
+	types map[string]int
+}{types: make(map[string]int)}
+
+// NewMask : creates a mask from its rows of weights
+func NewMask(weights [][]int) (*Mask, error) {
+	rows := len(weights)
+	if rows%2 == 0 {
+		return nil, fmt.Errorf("Wrong mask: the number of rows must be odd, found %d", rows)
+	}
+	cols := len(weights[0])
+	m := &Mask{weights: make([][]int, rows)}
+	for i, row := range weights {
+		if len(row) != cols || cols%2 == 0 {
+			return nil, fmt.Errorf("Wrong mask: every row must have the same odd number of weights")
+		}
+		m.weights[i] = append([]int(nil), row...)
+		for j, weight := range row {
+			if weight != 0 {
+				m.offsets = append(m.offsets, [2]int{i - rows/2, j - cols/2})
+				m.offsetWeights = append(m.offsetWeights, weight)
+			}
+		}
+	}
+	if len(m.offsets) == 0 {
+		return nil, fmt.Errorf("Wrong mask: there must be at least a neighbor")
+	}
+	return m, nil
+}
+
+// ParseMask : parse a mask written in a text file or inlined
+func ParseMask(mask string) (*Mask, error) {
+	var weights [][]int
+	for _, line := range strings.FieldsFunc(mask, func(r rune) bool { return r == '\n' || r == ';' }) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var row []int
+		for _, weightString := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			weight, weightError := strconv.Atoi(weightString)
+			if weightError != nil {
+				return nil, fmt.Errorf("Wrong mask: %s is not a weight", weightString)
+			}
+			row = append(row, weight)
+		}
+		weights = append(weights, row)
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("Wrong mask: empty mask")
+	}
+	return NewMask(weights)
+}
+
+// ReadMaskFile : read a mask from a text file
+func ReadMaskFile(filePath string) (*Mask, error) {
+	file, fileError := os.Open(filePath)
+	if fileError != nil {
+		return nil, fileError
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if scannerError := scanner.Err(); scannerError != nil {
+		return nil, scannerError
+	}
+	return ParseMask(strings.Join(lines, "\n"))
+}
+
+// String : return the mask inlined (e.g. "1,2,1;2,0,2;1,2,1")
+func (m *Mask) String() string {
+	rows := make([]string, len(m.weights))
+	for i, row := range m.weights {
+		weights := make([]string, len(row))
+		for j, weight := range row {
+			weights[j] = strconv.Itoa(weight)
+		}
+		rows[i] = strings.Join(weights, ",")
+	}
+	return strings.Join(rows, ";")
+}
+
+// Radius : return the maximum distance between the cell and its neighbors
+// (the half of the rows or the columns of the mask, whichever is greater)
+func (m *Mask) Radius() int {
+	rowsRadius, colsRadius := len(m.weights)/2, len(m.weights[0])/2
+	if rowsRadius > colsRadius {
+		return rowsRadius
+	}
+	return colsRadius
+}
+
+// neighbors : return the neighbors of a cell (the ones whose weight is not 0)
+func (m *Mask) neighbors(g gettable, i int, j int) []int {
+	neighbors := make([]int, len(m.offsets))
+	for neighborI, offset := range m.offsets {
+		neighbors[neighborI] = g.Get(i+offset[0], j+offset[1])
+	}
+	return neighbors
+}
+
+// RegisterMask : register a custom neighborhood and return its neighborhood
+// type. Registering the same mask again returns the same neighborhood type.
+func RegisterMask(m *Mask) int {
+	customNeighborhoods.Lock()
+	defer customNeighborhoods.Unlock()
+	if neighborhoodType, isRegistered := customNeighborhoods.types[m.String()]; isRegistered {
+		return neighborhoodType
+	}
+	neighborhoodType := CUSTOM + len(customNeighborhoods.masks)
+	customNeighborhoods.masks = append(customNeighborhoods.masks, m)
+	customNeighborhoods.types[m.String()] = neighborhoodType
+	return neighborhoodType
+}
+
+// GetMask : return the mask of a custom neighborhood, or nil if
+// the neighborhood type is not a registered custom one
+func GetMask(neighborhoodType int) *Mask {
+	customNeighborhoods.RLock()
+	defer customNeighborhoods.RUnlock()
+	maskI := neighborhoodType - CUSTOM
+	if maskI < 0 || maskI >= len(customNeighborhoods.masks) {
+		return nil
+	}
+	return customNeighborhoods.masks[maskI]
+}
+
+// IsCustomType : inform if the neighborhood type is a registered custom one
+func IsCustomType(neighborhoodType int) bool {
+	return GetMask(neighborhoodType) != nil
+}
+
+// GetWeights : return the weights of the neighbors returned by the
+// neighborhood function, or nil if every neighbor weighs 1
+func GetWeights(neighborhoodType int) []int {
+	if m := GetMask(neighborhoodType); m != nil {
+		return m.offsetWeights
+	}
+	return nil
+}
+
+// parseCustomType : return the neighborhood type of a custom
+// neighborhood name (e.g. "Custom 1,2,1;2,0,2;1,2,1")
+func parseCustomType(neighborhoodType string) (int, error) {
+	m, maskError := ParseMask(strings.TrimPrefix(neighborhoodType, CUSTOMSTRING))
+	if maskError != nil {
+		return NONE, fmt.Errorf("%w type %s: %s", ErrInvalidNeighborhood, neighborhoodType, maskError)
+	}
+	return RegisterMask(m), nil
+}
@@ -0,0 +1,73 @@
+package neighborhood
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMask(t *testing.T) {
+	maskFilePath := filepath.Join("..", "..", "testdata", "weighted_mask.txt")
+	fileMask, fileMaskError := ReadMaskFile(maskFilePath)
+	if fileMaskError != nil {
+		t.Error(fileMaskError)
+		return
+	}
+	inlinedMask, inlinedMaskError := ParseMask("1,2,1; 2,0,2; 1,2,1")
+	if inlinedMaskError != nil {
+		t.Error(inlinedMaskError)
+		return
+	}
+	if fileMask.String() != "1,2,1;2,0,2;1,2,1" || inlinedMask.String() != fileMask.String() {
+		t.Errorf("Both masks should be 1,2,1;2,0,2;1,2,1, found %s and %s", fileMask, inlinedMask)
+	}
+	if fileMask.Radius() != 1 {
+		t.Errorf("The radius of the mask should be 1, found %d", fileMask.Radius())
+	}
+	for mask, expectedRadius := range map[string]int{"1;1;0;1;1": 2, "1,1,0,1,1": 2, "1,1,1;1,0,1;1,1,1;1,1,1;1,1,1": 2} {
+		if parsedMask, _ := ParseMask(mask); parsedMask.Radius() != expectedRadius {
+			t.Errorf("The radius of the mask %s should be %d, found %d", mask, expectedRadius, parsedMask.Radius())
+		}
+	}
+
+	invalidMasks := []string{"", "1,1;1,1", "1,1,1;1,0;1,1,1", "1,a,1;1,0,1;1,1,1", "0,0,0;0,0,0;0,0,0"}
+	for _, invalidMask := range invalidMasks {
+		if _, maskError := ParseMask(invalidMask); maskError == nil {
+			t.Errorf("Mask \"%s\" should be invalid", invalidMask)
+		}
+	}
+}
+
+func TestCustomNeighborhood(t *testing.T) {
+	mask, _ := ParseMask("1,2,1;2,0,2;1,2,1")
+	neighborhoodType := RegisterMask(mask)
+	sameMask, _ := ParseMask("1 2 1\n2 0 2\n1 2 1")
+	if RegisterMask(sameMask) != neighborhoodType {
+		t.Errorf("The same mask should have the same neighborhood type")
+	}
+	otherMask, _ := ParseMask("0,1,0;1,0,1;0,1,0")
+	if RegisterMask(otherMask) == neighborhoodType {
+		t.Errorf("Different masks should have different neighborhood types")
+	}
+	if !IsValidType(neighborhoodType) || Size(neighborhoodType) != 12 || Radius(neighborhoodType) != 1 {
+		t.Errorf("Wrong custom neighborhood %d", neighborhoodType)
+	}
+
+	name := typeName(neighborhoodType)
+	if name != "Custom 1,2,1;2,0,2;1,2,1" {
+		t.Errorf("The name of the neighborhood should be \"Custom 1,2,1;2,0,2;1,2,1\", found \"%s\"", name)
+	}
+	if parsedType, parseError := TypeFromString(name); parseError != nil || parsedType != neighborhoodType {
+		t.Errorf("Neighborhood %s should be parsed as %d, found %d (%s)", name, neighborhoodType, parsedType, parseError)
+	}
+
+	g := &testGrid{cells: [][]int{
+		{1, 1, 0},
+		{0, 1, 1},
+		{0, 0, 1},
+	}}
+	// 1 (NW) + 2 (N) + 2 (E) + 1 (SE)
+	count := NeighborsCount(g, 1, 1, 1, getFunc(t, neighborhoodType), GetWeights(neighborhoodType))
+	if count != 6 {
+		t.Errorf("The weighted count of cell 1,1 should be 6, found %d", count)
+	}
+}
@@ -0,0 +1,7 @@
+package neighborhood
+
+import "errors"
+
+// ErrInvalidNeighborhood : the neighborhood type is not one of the known
+// ones, or it cannot be used with the requested radius
+var ErrInvalidNeighborhood = errors.New("Invalid neighborhood")
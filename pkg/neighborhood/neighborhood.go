@@ -1,17 +1,37 @@
 package neighborhood
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 const NONE = -1
 const MOORE = 1
 const VONNEUMANN = 2
+const HEXAGONAL = 3
+const TRIANGULAR = 4
+const CIRCULAR = 5
 const MOORESTRING = "Moore"
-const VONNEUMANNSTRING = "Von Neumman"
+const VONNEUMANNSTRING = "Von Neumann"
+const HEXAGONALSTRING = "Hexagonal"
+const TRIANGULARSTRING = "Triangular"
+const CIRCULARSTRING = "Circular"
+
+// vonNeumannMisspelledString : name of the Von Neumann
+// neighborhood written by older versions of Congolway
+const vonNeumannMisspelledString = "Von Neumman"
 
 type gettable interface {
 	Get(i int, j int) int
 }
 
+// originable : grids whose cells are indexed relative to an origin
+// (e.g. unbounded grids). The tilings that depend on the parity of
+// the rows or columns use the absolute coordinates of the cells.
+type originable interface {
+	Origin() (int, int)
+}
+
 // Func : neighborhood function type
 type Func func(g gettable, i int, j int) []int
 
@@ -31,66 +51,184 @@ func vonNeumannNeighbors(g gettable, i int, j int) []int {
 	}
 }
 
-// GetFunc : returns the neighborhood function based on its code.
-func GetFunc(neighborhoodType int) Func {
-	if neighborhoodType == MOORE {
-		return mooreNeighbors
+// hexagonalNeighbors : neighbors in a hexagonal tiling where the cells are
+// stored in offset rows, i.e. odd rows are shifted half a cell to the right
+func hexagonalNeighbors(g gettable, i int, j int) []int {
+	absI, _ := absolute(g, i, j)
+	if absI&1 == 0 {
+		return []int{
+			g.Get(i-1, j-1), g.Get(i-1, j),
+			g.Get(i, j-1), g.Get(i, j+1),
+			g.Get(i+1, j-1), g.Get(i+1, j),
+		}
 	}
-	if neighborhoodType == VONNEUMANN {
-		return vonNeumannNeighbors
+	return []int{
+		g.Get(i-1, j), g.Get(i-1, j+1),
+		g.Get(i, j-1), g.Get(i, j+1),
+		g.Get(i+1, j), g.Get(i+1, j+1),
 	}
-	panic(
-		fmt.Sprintf(
-			"Wrong neighborhoodType %d, expected %d (Moore) or %d (Von Neumman)",
-			neighborhoodType, MOORE, VONNEUMANN,
-		),
-	)
 }
 
-// AssertType : assert the neightborhood type as int
-func AssertType(neighborhoodType int) {
-	if neighborhoodType != MOORE && neighborhoodType == VONNEUMANN {
-		panic(
-			fmt.Sprintf(
-				"Wrong neighborhoodType %d, expected %d (Moore) or %d (Von Neumman)",
-				neighborhoodType, MOORE, VONNEUMANN,
-			),
-		)
+// triangularNeighbors : neighbors in a triangular tiling where each row
+// alternates up and down triangles (cells with even i+j point up). The
+// neighbors are the 12 triangles that share an edge or a vertex with the cell.
+func triangularNeighbors(g gettable, i int, j int) []int {
+	absI, absJ := absolute(g, i, j)
+	if (absI+absJ)&1 == 0 {
+		// Pointing up: 3 triangles above its apex, 5 below its base
+		return []int{
+			g.Get(i-1, j-1), g.Get(i-1, j), g.Get(i-1, j+1),
+			g.Get(i, j-2), g.Get(i, j-1), g.Get(i, j+1), g.Get(i, j+2),
+			g.Get(i+1, j-2), g.Get(i+1, j-1), g.Get(i+1, j), g.Get(i+1, j+1), g.Get(i+1, j+2),
+		}
 	}
+	// Pointing down: 5 triangles above its base, 3 below its apex
+	return []int{
+		g.Get(i-1, j-2), g.Get(i-1, j-1), g.Get(i-1, j), g.Get(i-1, j+1), g.Get(i-1, j+2),
+		g.Get(i, j-2), g.Get(i, j-1), g.Get(i, j+1), g.Get(i, j+2),
+		g.Get(i+1, j-1), g.Get(i+1, j), g.Get(i+1, j+1),
+	}
+}
+
+// absolute : return the absolute coordinates of a cell
+func absolute(g gettable, i int, j int) (int, int) {
+	if og, isOriginable := g.(originable); isOriginable {
+		originI, originJ := og.Origin()
+		return originI + i, originJ + j
+	}
+	return i, j
 }
 
-// StringFromType : returns the neighborhood name based on its code.
-func StringFromType(neighborhoodType int) string {
-	if neighborhoodType == MOORE {
-		return MOORESTRING
+// GetFunc : returns the neighborhood function based on its code
+// or an error if the neighborhood is not known
+func GetFunc(neighborhoodType int) (Func, error) {
+	switch neighborhoodType {
+	case MOORE:
+		return mooreNeighbors, nil
+	case VONNEUMANN:
+		return vonNeumannNeighbors, nil
+	case HEXAGONAL:
+		return hexagonalNeighbors, nil
+	case TRIANGULAR:
+		return triangularNeighbors, nil
+	case CIRCULAR:
+		return GetRangeFunc(CIRCULAR, 1)
 	}
-	if neighborhoodType == VONNEUMANN {
-		return VONNEUMANNSTRING
+	if m := GetMask(neighborhoodType); m != nil {
+		return m.neighbors, nil
 	}
-	panic("Wrong neighborhoodType")
+	return nil, wrongTypeError(neighborhoodType)
+}
+
+// IsValidType : inform if the neighborhood type is one of the known ones
+func IsValidType(neighborhoodType int) bool {
+	return neighborhoodType == MOORE || neighborhoodType == VONNEUMANN ||
+		neighborhoodType == HEXAGONAL || neighborhoodType == TRIANGULAR || neighborhoodType == CIRCULAR ||
+		IsCustomType(neighborhoodType)
+}
+
+// Size : return the number of neighbors of a cell
+// (see RangeSize for neighborhoods with a radius greater than 1).
+// The size of custom neighborhoods is the sum of their positive weights.
+func Size(neighborhoodType int) int {
+	if weights := GetWeights(neighborhoodType); weights != nil {
+		size := 0
+		for _, weight := range weights {
+			if weight > 0 {
+				size += weight
+			}
+		}
+		return size
+	}
+	switch neighborhoodType {
+	case VONNEUMANN:
+		return 4
+	case HEXAGONAL:
+		return 6
+	case TRIANGULAR:
+		return 12
+	}
+	return 8
+}
+
+// Radius : return the maximum distance (in rows or columns)
+// between a cell and its neighbors
+func Radius(neighborhoodType int) int {
+	if m := GetMask(neighborhoodType); m != nil {
+		return m.Radius()
+	}
+	if neighborhoodType == TRIANGULAR {
+		return 2
+	}
+	return 1
+}
+
+func wrongTypeError(neighborhoodType int) error {
+	return fmt.Errorf(
+		"%w type %d, expected %d (Moore), %d (Von Neumann), %d (Hexagonal), %d (Triangular), "+
+			"%d (Circular) or a registered custom neighborhood",
+		ErrInvalidNeighborhood, neighborhoodType, MOORE, VONNEUMANN, HEXAGONAL, TRIANGULAR, CIRCULAR,
+	)
+}
+
+// StringFromType : returns the neighborhood name based on its code
+// or an error if the neighborhood is not known
+func StringFromType(neighborhoodType int) (string, error) {
+	switch neighborhoodType {
+	case MOORE:
+		return MOORESTRING, nil
+	case VONNEUMANN:
+		return VONNEUMANNSTRING, nil
+	case HEXAGONAL:
+		return HEXAGONALSTRING, nil
+	case TRIANGULAR:
+		return TRIANGULARSTRING, nil
+	case CIRCULAR:
+		return CIRCULARSTRING, nil
+	}
+	if m := GetMask(neighborhoodType); m != nil {
+		return fmt.Sprintf("%s %s", CUSTOMSTRING, m), nil
+	}
+	return "", wrongTypeError(neighborhoodType)
 }
 
 // TypeFromString : returns the neighborhood type from a string
-func TypeFromString(neighborhoodType string) int {
-	if neighborhoodType == MOORESTRING {
-		return MOORE
+// or an error if the neighborhood is not known
+func TypeFromString(neighborhoodType string) (int, error) {
+	switch neighborhoodType {
+	case MOORESTRING:
+		return MOORE, nil
+	case VONNEUMANNSTRING, vonNeumannMisspelledString:
+		return VONNEUMANN, nil
+	case HEXAGONALSTRING:
+		return HEXAGONAL, nil
+	case TRIANGULARSTRING:
+		return TRIANGULAR, nil
+	case CIRCULARSTRING:
+		return CIRCULAR, nil
 	}
-	if neighborhoodType == VONNEUMANNSTRING {
-		return VONNEUMANN
+	if strings.HasPrefix(neighborhoodType, CUSTOMSTRING+" ") {
+		return parseCustomType(neighborhoodType)
 	}
-	panic(fmt.Sprintf(
-		"Wrong neighborhoodType %s, expected \"%s\" or \"%s\"",
-		neighborhoodType, MOORESTRING, VONNEUMANNSTRING,
-	))
+	return NONE, fmt.Errorf(
+		"%w type %s, expected \"%s\", \"%s\", \"%s\", \"%s\", \"%s\" or \"%s <mask>\"",
+		ErrInvalidNeighborhood, neighborhoodType,
+		MOORESTRING, VONNEUMANNSTRING, HEXAGONALSTRING, TRIANGULARSTRING, CIRCULARSTRING, CUSTOMSTRING,
+	)
 }
 
 // NeighborsCount : number of neighbors alive surronding
-// cell i, j of the grid
-func NeighborsCount(g gettable, i int, j int, status int, neighborhoodFunc Func) int {
+// cell i, j of the grid. If weights is not nil, each neighbor
+// adds its weight (see GetWeights) instead of 1.
+func NeighborsCount(g gettable, i int, j int, status int, neighborhoodFunc Func, weights []int) int {
 	neighborsCount := 0
-	for _, neighborhood := range neighborhoodFunc(g, i, j) {
+	for neighborI, neighborhood := range neighborhoodFunc(g, i, j) {
 		if neighborhood == status {
-			neighborsCount++
+			if weights == nil {
+				neighborsCount++
+			} else {
+				neighborsCount += weights[neighborI]
+			}
 		}
 	}
 	return neighborsCount
@@ -0,0 +1,119 @@
+package neighborhood
+
+import (
+	"errors"
+	"testing"
+)
+
+type testGrid struct {
+	cells            [][]int
+	originI, originJ int
+}
+
+func (tg *testGrid) Get(i int, j int) int {
+	if i < 0 || i >= len(tg.cells) || j < 0 || j >= len(tg.cells[i]) {
+		return 0
+	}
+	return tg.cells[i][j]
+}
+
+func (tg *testGrid) Origin() (int, int) {
+	return tg.originI, tg.originJ
+}
+
+// getFunc : return the neighborhood function of a neighborhood type,
+// failing the test if it has none
+func getFunc(t *testing.T, neighborhoodType int) Func {
+	neighborhoodFunc, funcError := GetFunc(neighborhoodType)
+	if funcError != nil {
+		t.Fatal(funcError)
+	}
+	return neighborhoodFunc
+}
+
+// typeName : return the name of a neighborhood type for the test messages
+func typeName(neighborhoodType int) string {
+	name, _ := StringFromType(neighborhoodType)
+	return name
+}
+
+func TestNeighborsSize(t *testing.T) {
+	g := &testGrid{cells: [][]int{
+		{1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1},
+	}}
+	for _, neighborhoodType := range []int{MOORE, VONNEUMANN, HEXAGONAL, TRIANGULAR} {
+		// Cells of both parities
+		for j := 2; j <= 3; j++ {
+			count := NeighborsCount(g, 1, j, 1, getFunc(t, neighborhoodType), nil)
+			if count != Size(neighborhoodType) {
+				t.Errorf("%s cell 1,%d should have %d alive neighbors, found %d",
+					typeName(neighborhoodType), j, Size(neighborhoodType), count)
+			}
+		}
+	}
+}
+
+func TestHexagonalNeighbors(t *testing.T) {
+	// Odd rows are shifted half a cell to the right
+	cells := [][]int{
+		{0, 0, 1, 1},
+		{0, 1, 0, 1},
+		{0, 0, 1, 1},
+	}
+	g := &testGrid{cells: cells}
+	if count := NeighborsCount(g, 1, 2, 1, getFunc(t, HEXAGONAL), nil); count != 6 {
+		t.Errorf("Cell 1,2 should have 6 alive neighbors, found %d", count)
+	}
+	// The parity of the rows is the one of their absolute coordinates
+	shifted := &testGrid{cells: cells, originI: 1}
+	if count := NeighborsCount(shifted, 1, 2, 1, getFunc(t, HEXAGONAL), nil); count != 4 {
+		t.Errorf("Cell 1,2 of the shifted grid should have 4 alive neighbors, found %d", count)
+	}
+}
+
+func TestTriangularNeighbors(t *testing.T) {
+	g := &testGrid{cells: [][]int{
+		{0, 1, 1, 1, 0, 0, 0},
+		{1, 1, 0, 1, 1, 0, 0},
+		{1, 1, 1, 1, 1, 0, 0},
+	}}
+	// Cell 1,2 points down: 5 neighbors above, 4 in its row and 3 below
+	if count := NeighborsCount(g, 1, 2, 1, getFunc(t, TRIANGULAR), nil); count != 10 {
+		t.Errorf("Cell 1,2 should have 10 alive neighbors, found %d", count)
+	}
+	// Cell 1,3 points up: 3 neighbors above, 4 in its row and 5 below
+	if count := NeighborsCount(g, 1, 3, 1, getFunc(t, TRIANGULAR), nil); count != 8 {
+		t.Errorf("Cell 1,3 should have 8 alive neighbors, found %d", count)
+	}
+}
+
+func TestTypeFromString(t *testing.T) {
+	for _, neighborhoodType := range []int{MOORE, VONNEUMANN, HEXAGONAL, TRIANGULAR} {
+		parsedType, parseError := TypeFromString(typeName(neighborhoodType))
+		if parseError != nil || parsedType != neighborhoodType {
+			t.Errorf("Neighborhood %s should be parsed as %d, found %d (%s)",
+				typeName(neighborhoodType), neighborhoodType, parsedType, parseError)
+		}
+	}
+	// Name written by older versions
+	if parsedType, _ := TypeFromString("Von Neumman"); parsedType != VONNEUMANN {
+		t.Errorf("Von Neumman should be parsed as the Von Neumann neighborhood, found %d", parsedType)
+	}
+	if _, parseError := TypeFromString("Square"); !errors.Is(parseError, ErrInvalidNeighborhood) {
+		t.Errorf("Square should not be a valid neighborhood, found %v", parseError)
+	}
+}
+
+func TestInvalidType(t *testing.T) {
+	if _, funcError := GetFunc(NONE); !errors.Is(funcError, ErrInvalidNeighborhood) {
+		t.Errorf("GetFunc should fail with unknown neighborhood types, found %v", funcError)
+	}
+	if _, nameError := StringFromType(NONE); !errors.Is(nameError, ErrInvalidNeighborhood) {
+		t.Errorf("StringFromType should fail with unknown neighborhood types, found %v", nameError)
+	}
+	if IsValidType(NONE) || !IsValidType(HEXAGONAL) {
+		t.Errorf("Only known neighborhood types should be valid")
+	}
+}
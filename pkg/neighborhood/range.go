@@ -0,0 +1,169 @@
+package neighborhood
+
+import (
+	"fmt"
+	"math"
+)
+
+// Neighborhoods of a radius greater than 1 are used by Larger than Life rules.
+// Moore, Von Neumann and circular neighborhoods are defined for any radius r:
+//   - Moore: cells whose row and column offsets are at most r.
+//   - Von Neumann: cells whose Manhattan distance is at most r.
+//   - Circular: cells whose euclidean distance is less than r + 1/2.
+// See https://www.conwaylife.com/wiki/Larger_than_Life
+
+// MaxRadius : maximum radius of the neighborhoods
+const MaxRadius = 100
+
+// IsRangeType : inform if the neighborhood type can have a radius
+func IsRangeType(neighborhoodType int) bool {
+	return neighborhoodType == MOORE || neighborhoodType == VONNEUMANN || neighborhoodType == CIRCULAR
+}
+
+// rowWidths : return the maximum column offset of the neighbors in
+// each row offset from -radius to radius
+func rowWidths(neighborhoodType, radius int) ([]int, error) {
+	if !IsRangeType(neighborhoodType) {
+		return nil, fmt.Errorf("%w type %d: it has no radius", ErrInvalidNeighborhood, neighborhoodType)
+	}
+	if radius < 1 || radius > MaxRadius {
+		return nil, fmt.Errorf(
+			"%w radius %d, expected a number between 1 and %d",
+			ErrInvalidNeighborhood, radius, MaxRadius,
+		)
+	}
+	widths := make([]int, 2*radius+1)
+	for di := -radius; di <= radius; di++ {
+		switch neighborhoodType {
+		case MOORE:
+			widths[di+radius] = radius
+		case VONNEUMANN:
+			widths[di+radius] = radius - absInt(di)
+		case CIRCULAR:
+			// dj * dj <= radius * radius + radius - di * di
+			widths[di+radius] = int(math.Sqrt(float64(radius*radius + radius - di*di)))
+		}
+	}
+	return widths, nil
+}
+
+// RangeSize : return the number of neighbors of a cell in a neighborhood
+// of a radius
+func RangeSize(neighborhoodType, radius int) (int, error) {
+	if radius == 1 && !IsRangeType(neighborhoodType) {
+		return Size(neighborhoodType), nil
+	}
+	widths, widthsError := rowWidths(neighborhoodType, radius)
+	if widthsError != nil {
+		return 0, widthsError
+	}
+	size := -1 // The cell is not its own neighbor
+	for _, width := range widths {
+		size += 2*width + 1
+	}
+	return size, nil
+}
+
+// GetRangeFunc : returns the neighborhood function of a neighborhood
+// type with a radius or an error if the neighborhood cannot have that radius
+func GetRangeFunc(neighborhoodType, radius int) (Func, error) {
+	if radius == 1 && neighborhoodType != CIRCULAR {
+		return GetFunc(neighborhoodType)
+	}
+	widths, widthsError := rowWidths(neighborhoodType, radius)
+	if widthsError != nil {
+		return nil, widthsError
+	}
+	size, _ := RangeSize(neighborhoodType, radius)
+	return func(g gettable, i int, j int) []int {
+		neighbors := make([]int, 0, size)
+		for di := -radius; di <= radius; di++ {
+			width := widths[di+radius]
+			for dj := -width; dj <= width; dj++ {
+				if di != 0 || dj != 0 {
+					neighbors = append(neighbors, g.Get(i+di, j+dj))
+				}
+			}
+		}
+		return neighbors
+	}, nil
+}
+
+// RangeCounter : counts the neighbors with a status of the cells of a
+// region of a grid without visiting every neighbor of each cell.
+// Moore neighborhoods use a summed-area table, so each count takes
+// constant time, while the other neighborhoods sum a sliding window
+// of each row, so each count takes a time proportional to the radius.
+type RangeCounter struct {
+	top, left int
+	radius    int
+	widths    []int
+	moore     bool
+	// Cells of the region and its surroundings that have the status
+	matches [][]int
+	// sums[i][j] : number of matches before column j of row i-1 (rows)
+	// or in the rectangle of i rows and j columns (summed-area table)
+	sums [][]int
+}
+
+// NewRangeCounter : creates a counter of the neighbors with a status
+// of the rows x cols cells whose top-left cell is top, left
+func NewRangeCounter(g gettable, top, left, rows, cols, neighborhoodType, radius, status int) (*RangeCounter, error) {
+	widths, widthsError := rowWidths(neighborhoodType, radius)
+	if widthsError != nil {
+		return nil, widthsError
+	}
+	rc := &RangeCounter{
+		top:    top,
+		left:   left,
+		radius: radius,
+		widths: widths,
+		moore:  neighborhoodType == MOORE,
+	}
+	paddedRows := rows + 2*radius
+	paddedCols := cols + 2*radius
+	rc.matches = make([][]int, paddedRows)
+	rc.sums = make([][]int, paddedRows+1)
+	rc.sums[0] = make([]int, paddedCols+1)
+	for i := 0; i < paddedRows; i++ {
+		rc.matches[i] = make([]int, paddedCols)
+		rc.sums[i+1] = make([]int, paddedCols+1)
+		for j := 0; j < paddedCols; j++ {
+			if g.Get(top-radius+i, left-radius+j) == status {
+				rc.matches[i][j] = 1
+			}
+			rc.sums[i+1][j+1] = rc.sums[i+1][j] + rc.matches[i][j]
+			if rc.moore {
+				rc.sums[i+1][j+1] += rc.sums[i][j+1] - rc.sums[i][j]
+			}
+		}
+	}
+	return rc, nil
+}
+
+// Count : return the number of neighbors with the status of the cell i, j
+// of the grid. The cell must be in the region of the counter.
+func (rc *RangeCounter) Count(i, j int) int {
+	// Position of the cell in the padded region
+	pi := i - rc.top + rc.radius
+	pj := j - rc.left + rc.radius
+	count := -rc.matches[pi][pj]
+	if rc.moore {
+		top, bottom := pi-rc.radius, pi+rc.radius+1
+		left, right := pj-rc.radius, pj+rc.radius+1
+		return count + rc.sums[bottom][right] - rc.sums[top][right] - rc.sums[bottom][left] + rc.sums[top][left]
+	}
+	for di := -rc.radius; di <= rc.radius; di++ {
+		width := rc.widths[di+rc.radius]
+		row := rc.sums[pi+di+1]
+		count += row[pj+width+1] - row[pj-width]
+	}
+	return count
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package neighborhood
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestRangeSize(t *testing.T) {
+	expectedSizes := map[int][]int{
+		MOORE:      {8, 24, 48},
+		VONNEUMANN: {4, 12, 24},
+		CIRCULAR:   {8, 20, 36},
+	}
+	for neighborhoodType, sizes := range expectedSizes {
+		for radiusI, expectedSize := range sizes {
+			radius := radiusI + 1
+			if size, _ := RangeSize(neighborhoodType, radius); size != expectedSize {
+				t.Errorf("%s neighborhood of radius %d should have %d cells, found %d",
+					typeName(neighborhoodType), radius, expectedSize, size)
+			}
+			if size := len(getRangeFunc(t, neighborhoodType, radius)(&testGrid{}, 0, 0)); size != expectedSize {
+				t.Errorf("%s neighborhood function of radius %d should return %d cells, found %d",
+					typeName(neighborhoodType), radius, expectedSize, size)
+			}
+		}
+	}
+}
+
+// getRangeFunc : return the neighborhood function of a neighborhood type
+// with a radius, failing the test if it has none
+func getRangeFunc(t *testing.T, neighborhoodType, radius int) Func {
+	neighborhoodFunc, funcError := GetRangeFunc(neighborhoodType, radius)
+	if funcError != nil {
+		t.Fatal(funcError)
+	}
+	return neighborhoodFunc
+}
+
+func TestInvalidRange(t *testing.T) {
+	if _, funcError := GetRangeFunc(HEXAGONAL, 2); !errors.Is(funcError, ErrInvalidNeighborhood) {
+		t.Errorf("Hexagonal neighborhoods should not have a radius, found %v", funcError)
+	}
+	if _, sizeError := RangeSize(MOORE, MaxRadius+1); !errors.Is(sizeError, ErrInvalidNeighborhood) {
+		t.Errorf("The radius should be at most %d, found %v", MaxRadius, sizeError)
+	}
+}
+
+func TestRangeCounter(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	cells := make([][]int, 20)
+	for i := range cells {
+		cells[i] = make([]int, 30)
+		for j := range cells[i] {
+			cells[i][j] = random.Intn(2)
+		}
+	}
+	g := &testGrid{cells: cells}
+	for _, neighborhoodType := range []int{MOORE, VONNEUMANN, CIRCULAR} {
+		for _, radius := range []int{1, 2, 5} {
+			// The region has cells outside of the grid too
+			counter, counterError := NewRangeCounter(g, -2, -3, 24, 36, neighborhoodType, radius, 1)
+			if counterError != nil {
+				t.Error(counterError)
+				return
+			}
+			neighborhoodFunc := getRangeFunc(t, neighborhoodType, radius)
+			for i := -2; i < 22; i++ {
+				for j := -3; j < 33; j++ {
+					expected := NeighborsCount(g, i, j, 1, neighborhoodFunc, nil)
+					if count := counter.Count(i, j); count != expected {
+						t.Errorf("%s neighborhood of radius %d: cell %d,%d should have %d neighbors, found %d",
+							typeName(neighborhoodType), radius, i, j, expected, count)
+						return
+					}
+				}
+			}
+		}
+	}
+}
@@ -3,21 +3,19 @@ package output
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 // SaveToCellsFile : prints on stdout the current state of the grid
 func (gout *GolOutputer) SaveToCellsFile(filename string) error {
-	file, err := os.Create(filename)
-	defer file.Close()
-
-	if err != nil {
-		return err
-	}
+	return saveToFile(filename, gout.encodeCells)
+}
 
-	writer := bufio.NewWriter(file)
+// encodeCells : write the game of life instance to a .cells stream
+func (gout *GolOutputer) encodeCells(w io.Writer) error {
+	writer := bufio.NewWriter(w)
 
 	rows := gout.gol.Rows()
 	cols := gout.gol.Cols()
@@ -38,6 +36,5 @@ func (gout *GolOutputer) SaveToCellsFile(filename string) error {
 		}
 	}
 
-	writer.Flush()
-	return nil
+	return writer.Flush()
 }
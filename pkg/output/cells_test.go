@@ -17,7 +17,7 @@ func TestSaveToCellsFile(t *testing.T) {
 }
 
 func TestSaveToCellsFileError(t *testing.T) {
-	g := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", 10, 10, 42)
+	g, _ := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", 10, 10, 42)
 
 	golo := NewGolOutputer(g)
 	savingError := golo.SaveToCellsFile("/non-existant-path.cells")
@@ -37,7 +37,7 @@ func testSaveToCellsFile(t *testing.T, rows int, cols int, randomSeed int64) {
 	outputFilePath := file.Name()
 	defer os.Remove(outputFilePath)
 
-	g := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", rows, cols, randomSeed)
+	g, _ := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", rows, cols, randomSeed)
 
 	golo := NewGolOutputer(g)
 	golo.SaveToCellsFile(outputFilePath)
@@ -3,46 +3,113 @@ package output
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
-// SaveToCongolwayFile : prints on stdout the current state of the grid
+// maxDenseStates : maximum number of states that can be stored
+// in a dense grid, where each cell is written as a base-36 digit
+const maxDenseStates = 36
+
+// congolwayReservedKeys : keys of the header of the Congolway
+// format that cannot be used as custom metadata keys
+var congolwayReservedKeys = map[string]bool{
+	"version":           true,
+	"name":              true,
+	"description":       true,
+	"rules":             true,
+	"generation":        true,
+	"neighborhood_type": true,
+	"size":              true,
+	"limits":            true,
+	"origin":            true,
+	"grid_type":         true,
+	"grid":              true,
+}
+
+// congolwayKeyRegex : valid keys of the header
+var congolwayKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// SaveToCongolwayFile : save the game of life instance to a Congolway
+// (version 2) file with a "dense", "sparse" or "rle" grid
 func (gout *GolOutputer) SaveToCongolwayFile(filename string, fileType string) error {
-	file, err := os.Create(filename)
-	defer file.Close()
+	if checkError := gout.checkCongolwayFileType(fileType); checkError != nil {
+		return checkError
+	}
+	return saveToFile(filename, func(w io.Writer) error {
+		return gout.encodeCongolway(w, fileType)
+	})
+}
 
-	if err != nil {
-		return err
+// checkCongolwayFileType : check the game of life
+// instance can be stored as a file type
+func (gout *GolOutputer) checkCongolwayFileType(fileType string) error {
+	if fileType != "dense" && fileType != "sparse" && fileType != "rle" {
+		return fmt.Errorf("Invalid file type, expected \"dense\", \"sparse\" or \"rle\", found %s", fileType)
 	}
-	writer := bufio.NewWriter(file)
+	if fileType == "dense" && gout.gol.States() > maxDenseStates {
+		return fmt.Errorf("Dense grids can store up to %d states, use a sparse or rle grid for %d states",
+			maxDenseStates, gout.gol.States())
+	}
+	for _, key := range gout.gol.MetadataKeys() {
+		if !congolwayKeyRegex.MatchString(key) || congolwayReservedKeys[key] {
+			return fmt.Errorf("Invalid metadata key %s, it must be lowercase letters, digits, - and _ "+
+				"and cannot be a key of the header", key)
+		}
+		if strings.ContainsAny(gout.gol.Metadata(key), "\r\n") {
+			return fmt.Errorf("Invalid metadata %s, its value cannot have several lines", key)
+		}
+	}
+	return nil
+}
+
+// encodeCongolway : write the game of life instance to
+// a Congolway stream with a dense, sparse or rle grid
+func (gout *GolOutputer) encodeCongolway(w io.Writer, fileType string) error {
+	if checkError := gout.checkCongolwayFileType(fileType); checkError != nil {
+		return checkError
+	}
+	writer := bufio.NewWriter(w)
 
 	rows := gout.gol.Rows()
 	cols := gout.gol.Cols()
 
 	writer.WriteString("CONGOLWAY\n")
-	writer.WriteString("version: 1\n")
+	writer.WriteString("version: 2\n")
 	writer.WriteString(fmt.Sprintf("name: %s\n", gout.name()))
-	writer.WriteString(fmt.Sprintf("description: %s\n", gout.description()))
+	if gout.description() != "" {
+		for _, descriptionLine := range strings.Split(gout.description(), "\n") {
+			writer.WriteString(fmt.Sprintf("description: %s\n", descriptionLine))
+		}
+	}
+	for _, key := range gout.gol.MetadataKeys() {
+		writer.WriteString(fmt.Sprintf("%s: %s\n", key, gout.gol.Metadata(key)))
+	}
 	writer.WriteString(fmt.Sprintf("rules: %s\n", gout.rules()))
 	writer.WriteString(fmt.Sprintf("generation: %d\n", gout.generation()))
 	writer.WriteString(fmt.Sprintf("neighborhood_type: %s\n", gout.neighborhoodTypeString()))
 	writer.WriteString(fmt.Sprintf("size: %dx%d\n", rows, cols))
 	writer.WriteString(fmt.Sprintf("limits: %s\n", gout.limitsString()))
+	if gout.gol.Unbounded() {
+		originI, originJ := gout.gol.Origin()
+		writer.WriteString(fmt.Sprintf("origin: %d, %d\n", originI, originJ))
+	}
 	writer.WriteString(fmt.Sprintf("grid_type: %s\n", fileType))
 	writer.WriteString("grid:\n")
 
-	if fileType == "dense" {
+	switch fileType {
+	case "dense":
 		gout.writeDenseGrid(writer)
-	} else if fileType == "sparse" {
+	case "sparse":
 		gout.writeSparseGrid(writer)
-	} else {
-		return fmt.Errorf("Invalid file type, expected \"dense\" or \"sparse\", found %s", fileType)
+	case "rle":
+		gout.writeRleData(writer)
 	}
-
-	writer.Flush()
-	return nil
+	return writer.Flush()
 }
 
 func (gout *GolOutputer) writeSparseGrid(writer *bufio.Writer) {
@@ -61,14 +128,19 @@ func (gout *GolOutputer) writeSparseGrid(writer *bufio.Writer) {
 			statusCount[ijValue]++
 		}
 	}
-	if statusCount[0] >= statusCount[1] {
-		writer.WriteString("default: 0\n")
-		writer.WriteString("0:\n")
-		writer.WriteString(fmt.Sprintf("1: %s\n", gout.coordinateString(1)))
-	} else {
-		writer.WriteString("default: 1\n")
-		writer.WriteString(fmt.Sprintf("0: %s\n", gout.coordinateString(0)))
-		writer.WriteString("1:\n")
+	defaultStatus := statuses.DEAD
+	for status := 0; status < gout.gol.States(); status++ {
+		if statusCount[status] > statusCount[defaultStatus] {
+			defaultStatus = status
+		}
+	}
+	writer.WriteString(fmt.Sprintf("default: %d\n", defaultStatus))
+	for status := 0; status < gout.gol.States(); status++ {
+		if status == defaultStatus {
+			writer.WriteString(fmt.Sprintf("%d:\n", status))
+		} else {
+			writer.WriteString(fmt.Sprintf("%d: %s\n", status, gout.coordinateString(status)))
+		}
 	}
 }
 
@@ -78,7 +150,11 @@ func (gout *GolOutputer) writeDenseGrid(writer *bufio.Writer) {
 
 	for i := 0; i < rows; i++ {
 		for j := 0; j < cols; j++ {
-			if gout.get(i, j) == statuses.ALIVE {
+			cell := gout.get(i, j)
+			if gout.gol.States() > 2 {
+				// Cells with several states are written as base-36 digits
+				writer.WriteString(strconv.FormatInt(int64(cell), 36))
+			} else if cell == statuses.ALIVE {
 				writer.WriteString("1")
 			} else {
 				writer.WriteString("0")
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 func TestSparseSavedToCongolwayFile(t *testing.T) {
@@ -31,7 +34,7 @@ func testRandomGolSavedToCongolwayFile(t *testing.T, rows int, cols int, rules s
 	outputFilePath := file.Name()
 	defer os.Remove(outputFilePath)
 
-	g := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", rows, cols, randomSeed)
+	g, _ := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", rows, cols, randomSeed)
 
 	golo := NewGolOutputer(g)
 	golo.SaveToCongolwayFile(outputFilePath, fileType)
@@ -53,3 +56,240 @@ func testRandomGolSavedToCongolwayFile(t *testing.T, rows int, cols int, rules s
 		t.Errorf("Both game of life instances should be equal and they don't")
 	}
 }
+
+func TestNonTotalisticRulesSavedToCongolwayFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "temp_gol.txt")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	outputFilePath := file.Name()
+	defer os.Remove(outputFilePath)
+
+	g, _ := gol.NewRandomGol("Random", "", "b2ceikn/s21", "dok", "limited", "limited", 10, 10, int64(1))
+	golo := NewGolOutputer(g)
+	golo.SaveToCongolwayFile(outputFilePath, "sparse")
+
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, readError := gr.ReadCongolwayFile(outputFilePath)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if readG.Rules() != "B2-a/S12" {
+		t.Errorf("Rules should be B2-a/S12, but they are %s", readG.Rules())
+	}
+	if equalsError := readG.EqualsError(g); equalsError != nil {
+		t.Error(equalsError)
+	}
+}
+
+func TestGenerationsRulesSavedToCongolwayFile(t *testing.T) {
+	for _, fileType := range []string{"dense", "sparse"} {
+		file, err := ioutil.TempFile("", "temp_gol.txt")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outputFilePath := file.Name()
+		defer os.Remove(outputFilePath)
+
+		g, _ := gol.NewGol("Star Wars", "", "345/2/4", "dok", "limited", "limited", 4, 4, 0)
+		g.Set(0, 0, statuses.ALIVE)
+		g.Set(1, 1, 2)
+		g.Set(2, 2, 3)
+		golo := NewGolOutputer(g)
+		if saveError := golo.SaveToCongolwayFile(outputFilePath, fileType); saveError != nil {
+			t.Error(saveError)
+			return
+		}
+
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, readError := gr.ReadCongolwayFile(outputFilePath)
+		if readError != nil {
+			t.Error(readError)
+			return
+		}
+		if readG.Rules() != "B2/S345/C4" {
+			t.Errorf("Rules should be B2/S345/C4, but they are %s", readG.Rules())
+		}
+		if equalsError := readG.EqualsError(g); equalsError != nil {
+			t.Error(equalsError)
+		}
+	}
+}
+
+func TestTilingsSavedToCongolwayFile(t *testing.T) {
+	for _, neighborhoodType := range []int{neighborhood.HEXAGONAL, neighborhood.TRIANGULAR} {
+		file, err := ioutil.TempFile("", "temp_gol.txt")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outputFilePath := file.Name()
+		defer os.Remove(outputFilePath)
+
+		g, _ := gol.NewGol("Tiling", "", "B2/S34", "dense", "limited", "limited", 4, 6, 0)
+		g.SetNeighborhoodType(neighborhoodType)
+		g.Set(1, 1, statuses.ALIVE)
+		g.Set(2, 4, statuses.ALIVE)
+		golo := NewGolOutputer(g)
+		if saveError := golo.SaveToCongolwayFile(outputFilePath, "dense"); saveError != nil {
+			t.Error(saveError)
+			return
+		}
+
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, readError := gr.ReadCongolwayFile(outputFilePath)
+		if readError != nil {
+			t.Error(readError)
+			return
+		}
+		if readG.NeighborhoodTypeString() != g.NeighborhoodTypeString() {
+			t.Errorf("The neighborhood should be %s, but it is %s",
+				g.NeighborhoodTypeString(), readG.NeighborhoodTypeString())
+		}
+		if equalsError := readG.EqualsError(g); equalsError != nil {
+			t.Error(equalsError)
+		}
+	}
+}
+
+func TestLargerThanLifeSavedToCongolwayFile(t *testing.T) {
+	for _, fileType := range []string{"dense", "sparse"} {
+		file, err := ioutil.TempFile("", "temp_gol.txt")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outputFilePath := file.Name()
+		defer os.Remove(outputFilePath)
+
+		g, _ := gol.NewGol("Larger than Life", "", "R2,C3,M1,S2..5,B3..4,NC", "dok", "limited", "limited", 5, 5, 0)
+		g.Set(0, 0, statuses.ALIVE)
+		g.Set(2, 2, 2)
+		golo := NewGolOutputer(g)
+		if saveError := golo.SaveToCongolwayFile(outputFilePath, fileType); saveError != nil {
+			t.Error(saveError)
+			return
+		}
+
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, readError := gr.ReadCongolwayFile(outputFilePath)
+		if readError != nil {
+			t.Error(readError)
+			return
+		}
+		if readG.Rules() != "R2,C3,M1,S2..5,B3..4,NC" {
+			t.Errorf("Rules should be R2,C3,M1,S2..5,B3..4,NC, but they are %s", readG.Rules())
+		}
+		if equalsError := readG.EqualsError(g); equalsError != nil {
+			t.Error(equalsError)
+		}
+	}
+}
+
+func TestCustomNeighborhoodSavedToCongolwayFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "temp_gol.txt")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	outputFilePath := file.Name()
+	defer os.Remove(outputFilePath)
+
+	mask, _ := neighborhood.ParseMask("0,1,0,1,0;1,2,0,2,1;0,0,0,0,0;1,2,0,2,1;0,1,0,1,0")
+	g, _ := gol.NewGol("Weighted", "", "B4/S", "dok", "limited", "limited", 5, 5, 0)
+	g.SetNeighborhoodType(neighborhood.RegisterMask(mask))
+	g.Set(1, 1, statuses.ALIVE)
+	golo := NewGolOutputer(g)
+	if saveError := golo.SaveToCongolwayFile(outputFilePath, "dense"); saveError != nil {
+		t.Error(saveError)
+		return
+	}
+
+	content, readFileError := ioutil.ReadFile(outputFilePath)
+	if readFileError != nil {
+		t.Error(readFileError)
+		return
+	}
+	if !strings.Contains(string(content), "neighborhood_type: Custom 0,1,0,1,0;1,2,0,2,1;0,0,0,0,0;1,2,0,2,1;0,1,0,1,0\n") {
+		t.Errorf("The mask should be inlined in the neighborhood type, found:\n%s", content)
+	}
+
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, readError := gr.ReadCongolwayFile(outputFilePath)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if equalsError := readG.EqualsError(g); equalsError != nil {
+		t.Error(equalsError)
+	}
+}
+
+func TestCongolwayV2SavedToCongolwayFile(t *testing.T) {
+	for _, fileType := range []string{"dense", "sparse", "rle"} {
+		file, err := ioutil.TempFile("", "temp_gol.txt")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outputFilePath := file.Name()
+		defer os.Remove(outputFilePath)
+
+		g, _ := gol.NewGol("Star Wars", "A pattern\nof Star Wars", "34/2/4", "dok", "unbounded", "unbounded", 4, 4, 7)
+		g.SetNeighborhoodType(neighborhood.VONNEUMANN)
+		g.SetOrigin(-3, 5)
+		g.SetMetadata("author", "Mirek Wojtowicz")
+		g.SetMetadata("tags", "generations, c/4")
+		g.Set(0, 0, statuses.ALIVE)
+		g.Set(1, 1, 2)
+		g.Set(3, 3, 3)
+		golo := NewGolOutputer(g)
+		if saveError := golo.SaveToCongolwayFile(outputFilePath, fileType); saveError != nil {
+			t.Error(saveError)
+			return
+		}
+
+		content, readFileError := ioutil.ReadFile(outputFilePath)
+		if readFileError != nil {
+			t.Error(readFileError)
+			return
+		}
+		if !strings.Contains(string(content), "version: 2\n") ||
+			!strings.Contains(string(content), "description: A pattern\ndescription: of Star Wars\n") ||
+			!strings.Contains(string(content), "neighborhood_type: Von Neumann\n") ||
+			!strings.Contains(string(content), "origin: -3, 5\n") {
+			t.Errorf("Wrong version 2 header:\n%s", content)
+		}
+
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, readError := gr.ReadCongolwayFile(outputFilePath)
+		if readError != nil {
+			t.Error(readError)
+			return
+		}
+		if equalsError := readG.EqualsError(g); equalsError != nil {
+			t.Error(equalsError)
+		}
+		if originI, originJ := readG.Origin(); originI != -3 || originJ != 5 {
+			t.Errorf("The origin should be -3, 5, found %d, %d", originI, originJ)
+		}
+	}
+}
+
+func TestInvalidMetadataSavedToCongolwayFile(t *testing.T) {
+	for _, key := range []string{"rules", "Author", "source url"} {
+		g, _ := gol.NewGol("Gol", "", "B3/S23", "dok", "limited", "limited", 4, 4, 0)
+		g.SetMetadata(key, "value")
+		if checkError := NewGolOutputer(g).checkCongolwayFileType("dense"); checkError == nil {
+			t.Errorf("The metadata key \"%s\" should not be allowed", key)
+		}
+	}
+	g, _ := gol.NewGol("Gol", "", "B3/S23", "dok", "limited", "limited", 4, 4, 0)
+	g.SetMetadata("notes", "two\nlines")
+	if checkError := NewGolOutputer(g).checkCongolwayFileType("dense"); checkError == nil {
+		t.Errorf("Metadata with several lines should not be allowed")
+	}
+}
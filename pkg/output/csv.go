@@ -0,0 +1,30 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// SaveToCsvFile : save the grid of the game of life instance to a
+// CSV file with a line for each row and the state of each cell
+func (gout *GolOutputer) SaveToCsvFile(filename string) error {
+	return saveToFile(filename, gout.encodeCsv)
+}
+
+// encodeCsv : write the grid of the game of life instance to a CSV stream
+func (gout *GolOutputer) encodeCsv(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	g := gout.gol
+	record := make([]string, g.Cols())
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			record[j] = strconv.Itoa(g.Get(i, j))
+		}
+		if writeError := writer.Write(record); writeError != nil {
+			return writeError
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
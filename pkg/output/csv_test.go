@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/input"
+)
+
+func TestEncodeCsv(t *testing.T) {
+	g, _ := gol.NewGol("Glider", "", "23/3", "dok", "limited", "limited", 3, 3, 0)
+	g.Set(0, 1, 1)
+	g.Set(1, 2, 1)
+	g.Set(2, 0, 1)
+	g.Set(2, 1, 1)
+	g.Set(2, 2, 1)
+	var buffer bytes.Buffer
+	if encodeError := NewGolOutputer(g).Encode(&buffer, base.CsvFormat); encodeError != nil {
+		t.Error(encodeError)
+		return
+	}
+	expectedContent := "0,1,0\n0,0,1\n1,1,1\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Wrong CSV content %q, expected %q", buffer.String(), expectedContent)
+		return
+	}
+
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, decodeError := gr.Decode(&buffer, base.CsvFormat, nil)
+	if decodeError != nil {
+		t.Error(decodeError)
+		return
+	}
+	if !readG.GridEquals(g, "values") {
+		t.Errorf("The grid read from the CSV content is different")
+	}
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+)
+
+// Encoder : write a game of life instance to a stream
+type Encoder func(gout *GolOutputer, w io.Writer) error
+
+// Format : file format that can be written by a GolOutputer
+type Format struct {
+	Name string
+	// File extensions with the starting dot (e.g. ".rle")
+	Extensions []string
+	Encode     Encoder
+}
+
+// formats : registered formats, in order of registration
+var formats []*Format
+
+func init() {
+	builtinFormats := []Format{
+		{
+			Name:       base.CongolwayFormat,
+			Extensions: []string{".txt", ".congol"},
+			Encode: func(gout *GolOutputer, w io.Writer) error {
+				return gout.encodeCongolway(w, "dense")
+			},
+		},
+		{
+			Name:       base.CellsFormat,
+			Extensions: []string{".cells"},
+			Encode:     (*GolOutputer).encodeCells,
+		},
+		// Life files are written in the 1.06 version
+		{
+			Name:       base.LifeFormat,
+			Extensions: []string{".life", ".lif"},
+			Encode:     (*GolOutputer).encodeLife106,
+		},
+		{
+			Name:   base.Life105Format,
+			Encode: (*GolOutputer).encodeLife105,
+		},
+		{
+			Name:   base.Life106Format,
+			Encode: (*GolOutputer).encodeLife106,
+		},
+		{
+			Name:       base.RleFormat,
+			Extensions: []string{".rle"},
+			Encode:     (*GolOutputer).encodeRle,
+		},
+		{
+			Name:       base.MacrocellFormat,
+			Extensions: []string{".mc"},
+			Encode:     (*GolOutputer).encodeMacrocell,
+		},
+		{
+			Name:       base.NpyFormat,
+			Extensions: []string{".npy"},
+			Encode:     (*GolOutputer).encodeNpy,
+		},
+		{
+			Name:       base.CsvFormat,
+			Extensions: []string{".csv"},
+			Encode:     (*GolOutputer).encodeCsv,
+		},
+	}
+	for formatI := range builtinFormats {
+		formats = append(formats, &builtinFormats[formatI])
+	}
+}
+
+// RegisterFormat : add a format to the ones that can be written
+func RegisterFormat(format Format) error {
+	if format.Name == "" || format.Encode == nil {
+		return fmt.Errorf("Formats must have a name and an encoder")
+	}
+	if formatByName(format.Name) != nil {
+		return fmt.Errorf("Format \"%s\" is already registered", format.Name)
+	}
+	for _, extension := range format.Extensions {
+		if registeredFormat := formatByExtension(extension); registeredFormat != nil {
+			return fmt.Errorf("Extension \"%s\" is already registered by the format \"%s\"",
+				extension, registeredFormat.Name)
+		}
+	}
+	formats = append(formats, &format)
+	return nil
+}
+
+// Formats : return the names of the formats
+// that can be written, in order of registration
+func Formats() []string {
+	names := make([]string, len(formats))
+	for formatI, format := range formats {
+		names[formatI] = format.Name
+	}
+	return names
+}
+
+func formatByName(name string) *Format {
+	for _, format := range formats {
+		if format.Name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+func formatByExtension(extension string) *Format {
+	for _, format := range formats {
+		for _, formatExtension := range format.Extensions {
+			if strings.EqualFold(formatExtension, extension) {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// extensionsString : return the registered extensions separated by commas
+func extensionsString() string {
+	var extensions []string
+	for _, format := range formats {
+		extensions = append(extensions, format.Extensions...)
+	}
+	return strings.Join(extensions, ", ")
+}
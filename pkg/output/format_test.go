@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/input"
+)
+
+func TestSaveToCongolFile(t *testing.T) {
+	g, _ := gol.NewRandomGol("Random", "A random pattern", "23/3", "dok", "limited", "limited", 10, 12, int64(1))
+	tempDir, tempDirError := ioutil.TempDir("", "")
+	if tempDirError != nil {
+		t.Error(tempDirError)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	outputFilePath := filepath.Join(tempDir, "random.congol")
+	if saveError := NewGolOutputer(g).SaveToFile(outputFilePath); saveError != nil {
+		t.Error(saveError)
+		return
+	}
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, readError := gr.ReadFile(outputFilePath, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if equalsError := readG.EqualsError(g); equalsError != nil {
+		t.Error(equalsError)
+	}
+
+	if saveError := NewGolOutputer(g).SaveToFile(filepath.Join(tempDir, "random.gif")); saveError == nil {
+		t.Errorf("An error should have been returned when saving to a .gif file")
+	}
+}
+
+func TestSaveToCompressedFile(t *testing.T) {
+	g, _ := gol.NewRandomGol("Random", "A random pattern", "23/3", "dok", "limited", "limited", 10, 12, int64(1))
+	tempDir, tempDirError := ioutil.TempDir("", "")
+	if tempDirError != nil {
+		t.Error(tempDirError)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	// Magic numbers of the gzip and zstd streams
+	expectedHeaders := map[string][]byte{
+		"random.txt.gz":     {0x1f, 0x8b},
+		"random.congol.zst": {0x28, 0xb5, 0x2f, 0xfd},
+	}
+	for filename, expectedHeader := range expectedHeaders {
+		outputFilePath := filepath.Join(tempDir, filename)
+		if saveError := NewGolOutputer(g).SaveToFile(outputFilePath); saveError != nil {
+			t.Error(saveError)
+			continue
+		}
+		content, _ := ioutil.ReadFile(outputFilePath)
+		if !bytes.HasPrefix(content, expectedHeader) {
+			t.Errorf("%s is not compressed", filename)
+			continue
+		}
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, readError := gr.ReadFile(outputFilePath, nil)
+		if readError != nil {
+			t.Error(readError)
+			continue
+		}
+		if equalsError := readG.EqualsError(g); equalsError != nil {
+			t.Errorf("%s: %s", filename, equalsError)
+		}
+	}
+
+	if saveError := NewGolOutputer(g).SaveToFile(filepath.Join(tempDir, "random.gz")); saveError == nil {
+		t.Errorf("An error should have been returned when saving to a .gz file without format extension")
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	// Format with the number of rows and columns of the grid
+	registerError := RegisterFormat(Format{
+		Name:       "size",
+		Extensions: []string{".size"},
+		Encode: func(gout *GolOutputer, w io.Writer) error {
+			_, writeError := fmt.Fprintf(w, "%dx%d\n", gout.Gol().Rows(), gout.Gol().Cols())
+			return writeError
+		},
+	})
+	if registerError != nil {
+		t.Error(registerError)
+		return
+	}
+	g, _ := gol.NewGol("Gol", "", "23/3", "dok", "limited", "limited", 5, 7, 0)
+	var buffer bytes.Buffer
+	if encodeError := NewGolOutputer(g).Encode(&buffer, "size"); encodeError != nil {
+		t.Error(encodeError)
+		return
+	}
+	if buffer.String() != "5x7\n" {
+		t.Errorf("The registered format was not used to encode the instance, found %s", buffer.String())
+	}
+
+	invalidFormats := []Format{
+		{Name: "size", Encode: (*GolOutputer).encodeRle},
+		{Name: "size2", Extensions: []string{".txt"}, Encode: (*GolOutputer).encodeRle},
+		{Name: "size3"},
+	}
+	for _, invalidFormat := range invalidFormats {
+		if RegisterFormat(invalidFormat) == nil {
+			t.Errorf("Format %s should not be registered", invalidFormat.Name)
+		}
+	}
+}
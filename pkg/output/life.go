@@ -3,10 +3,11 @@ package output
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"math"
-	"os"
 	"strings"
 
+	"github.com/diegojromerolopez/congolway/pkg/rules"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
@@ -30,12 +31,12 @@ func (gout *GolOutputer) SaveToLifeFile(filename string, version string) error {
 // SaveToLife105File : save the game of life instance to a
 // 1.05 .life file (1.05: https://www.conwaylife.com/wiki/Life_1.05)
 func (gout *GolOutputer) SaveToLife105File(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	writer := bufio.NewWriter(file)
+	return saveToFile(filename, gout.encodeLife105)
+}
+
+// encodeLife105 : write the game of life instance to a Life 1.05 stream
+func (gout *GolOutputer) encodeLife105(w io.Writer) error {
+	writer := bufio.NewWriter(w)
 
 	g := gout.gol
 
@@ -48,20 +49,26 @@ func (gout *GolOutputer) SaveToLife105File(filename string) error {
 	for _, descriptionLine := range strings.Split(g.Description(), "\n") {
 		writer.WriteString(fmt.Sprintf("#D %s\n", descriptionLine))
 	}
-	// Rules of the GOL
-	writer.WriteString(fmt.Sprintf("#R %s\n", g.Rules()))
+	// Rules of the GOL (in S/B notation, the one used by Life 1.05)
+	rule, ruleError := rules.Parse(g.Rules())
+	if ruleError != nil {
+		return ruleError
+	}
+	writer.WriteString(fmt.Sprintf("#R %s\n", rule.SurvivalBirthString()))
 	// Write cell blocks
 	rows := g.Rows()
 	cols := g.Cols()
+	// Unbounded grids are written in their real position
+	originI, originJ := g.Origin()
 	cellBlockMaxWidth := 80
 	cellBlocks := int(math.Ceil(float64(cols) / float64(cellBlockMaxWidth)))
 	for cellBlockI := 0; cellBlockI < cellBlocks; cellBlockI++ {
 		startCol := cellBlockI * cellBlockMaxWidth
 		endColPlusOne := startCol + cellBlockMaxWidth + 1
-		writer.WriteString(fmt.Sprintf("#P %d 0", startCol))
+		writer.WriteString(fmt.Sprintf("#P %d %d", originJ+startCol, originI))
 		for i := 0; i < rows; i++ {
 			row := ""
-			for j := startCol; j < endColPlusOne; j++ {
+			for j := startCol; j < endColPlusOne && j < cols; j++ {
 				if gout.gol.Get(i, j) == statuses.ALIVE {
 					row += "*"
 				} else if gout.gol.Get(i, j) == statuses.DEAD {
@@ -73,30 +80,30 @@ func (gout *GolOutputer) SaveToLife105File(filename string) error {
 			writer.WriteString(fmt.Sprintf("\n%s", row))
 		}
 	}
-	writer.Flush()
-	return nil
+	return writer.Flush()
 }
 
 // SaveToLife106File : save the game of life instance to a
 // 1.06 .life file (1.06: https://www.conwaylife.com/wiki/Life_1.06)
 func (gout *GolOutputer) SaveToLife106File(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	writer := bufio.NewWriter(file)
+	return saveToFile(filename, gout.encodeLife106)
+}
+
+// encodeLife106 : write the game of life instance to a Life 1.06 stream
+func (gout *GolOutputer) encodeLife106(w io.Writer) error {
+	writer := bufio.NewWriter(w)
 
 	writer.WriteString("#Life 1.06")
 	rows := gout.gol.Rows()
 	cols := gout.gol.Cols()
+	// Unbounded grids are written in their real position
+	originI, originJ := gout.gol.Origin()
 	for i := 0; i < rows; i++ {
 		for j := 0; j < cols; j++ {
 			if gout.gol.Get(i, j) == statuses.ALIVE {
-				writer.WriteString(fmt.Sprintf("\n%d %d", i, j))
+				writer.WriteString(fmt.Sprintf("\n%d %d", originI+i, originJ+j))
 			}
 		}
 	}
-	writer.Flush()
-	return nil
+	return writer.Flush()
 }
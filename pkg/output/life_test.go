@@ -7,8 +7,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/input"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
 
 func TestSaveToLife105File(t *testing.T) {
@@ -35,7 +37,7 @@ func testSaveToLifeFile(t *testing.T, rows, cols int, version string, randomSeed
 	outputFilePathParts := strings.Split(outputFilePath, "/")
 	name := outputFilePathParts[len(outputFilePathParts)-1]
 	description := fmt.Sprintf("File path: %s", outputFilePath)
-	g := gol.NewRandomGol(name, description, "23/3", "dok",
+	g, _ := gol.NewRandomGol(name, description, "23/3", "dok",
 		"limited", "limited", rows, cols, randomSeed)
 
 	golo := NewGolOutputer(g)
@@ -58,3 +60,79 @@ func testSaveToLifeFile(t *testing.T, rows, cols int, version string, randomSeed
 		t.Errorf("Both game of life instances should be equal and they don't")
 	}
 }
+
+func TestSaveUnboundedToLifeFile(t *testing.T) {
+	for _, version := range []string{"1.05", "1.06"} {
+		file, err := ioutil.TempFile("", "temp_gol.life")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		outputFilePath := file.Name()
+		defer os.Remove(outputFilePath)
+		outputFilePathParts := strings.Split(outputFilePath, "/")
+		name := outputFilePathParts[len(outputFilePathParts)-1]
+		description := fmt.Sprintf("File path: %s", outputFilePath)
+
+		g, _ := gol.NewGol(name, description, "23/3", "dok", "unbounded", "unbounded", 3, 3, 0)
+		g.Set(0, 0, statuses.ALIVE)
+		g.Set(2, 1, statuses.ALIVE)
+		g.Set(1, 2, statuses.ALIVE)
+		g.SetOrigin(-7, 12)
+
+		golo := NewGolOutputer(g)
+		golo.SaveToLifeFile(outputFilePath, version)
+
+		gconf := base.NewGolConf(map[string]interface{}{
+			"rowLimitation": "unbounded",
+			"colLimitation": "unbounded",
+		})
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, readError := gr.ReadLifeFile(outputFilePath, gconf)
+		if readError != nil {
+			t.Error(fmt.Errorf("Couldn't load the file %s: %s", outputFilePath, readError))
+			return
+		}
+		equalsError := readG.EqualsError(g)
+		if equalsError != nil {
+			t.Errorf("Life %s: %s", version, equalsError)
+		}
+	}
+}
+
+func TestSaveRulesToLife105File(t *testing.T) {
+	file, err := ioutil.TempFile("", "temp_gol.life")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	outputFilePath := file.Name()
+	defer os.Remove(outputFilePath)
+
+	g, _ := gol.NewGol("HighLife", "", "B36/S23", "dok", "limited", "limited", 3, 3, 0)
+	g.Set(1, 1, statuses.ALIVE)
+	golo := NewGolOutputer(g)
+	if saveError := golo.SaveToLifeFile(outputFilePath, "1.05"); saveError != nil {
+		t.Error(saveError)
+		return
+	}
+
+	content, readFileError := ioutil.ReadFile(outputFilePath)
+	if readFileError != nil {
+		t.Error(readFileError)
+		return
+	}
+	if !strings.Contains(string(content), "#R 23/36\n") {
+		t.Errorf("Rules should be written in S/B notation, found:\n%s", content)
+	}
+
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, readError := gr.ReadLifeFile(outputFilePath, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if readG.Rules() != "B36/S23" {
+		t.Errorf("Rules should be B36/S23, but they are %s", readG.Rules())
+	}
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+	"github.com/diegojromerolopez/congolway/pkg/utils"
+)
+
+// macrocellWriter : builds the deduplicated node list of a macrocell file
+type macrocellWriter struct {
+	gout *GolOutputer
+	// Absolute coordinates of the top-left cell of the grid
+	originI, originJ int
+	multiState       bool
+	// Nodes (leaves or "level nw ne sw se" lines) and their indexes
+	nodes   []string
+	indexes map[string]int
+}
+
+// SaveToMacrocellFile : save the game of life instance to a Golly
+// macrocell (.mc) file. Identical subtrees of the quadtree are written
+// once. Unbounded grids are written in their real position, and bounded
+// grids have their top-left cell in the 0, 0 position.
+// See https://www.conwaylife.com/wiki/Macrocell
+func (gout *GolOutputer) SaveToMacrocellFile(filename string) error {
+	return saveToFile(filename, gout.encodeMacrocell)
+}
+
+// encodeMacrocell : write the game of life instance to a macrocell stream
+func (gout *GolOutputer) encodeMacrocell(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	g := gout.gol
+	mw := &macrocellWriter{
+		gout:       gout,
+		multiState: g.States() > 2,
+		indexes:    make(map[string]int),
+	}
+	if g.Unbounded() {
+		mw.originI, mw.originJ = g.Origin()
+	}
+
+	// Smallest quadtree centered in 0, 0 that contains the grid
+	level := 3
+	if mw.multiState {
+		level = 1
+	}
+	for !mw.contains(level) {
+		level++
+	}
+	half := (1 << uint(level)) / 2
+	mw.node(level, -half, -half)
+
+	writer.WriteString("[M2] (congolway)\n")
+	writer.WriteString(fmt.Sprintf("#R %s\n", gout.rules()))
+	if gout.generation() > 0 {
+		writer.WriteString(fmt.Sprintf("#G %d\n", gout.generation()))
+	}
+	writer.WriteString(fmt.Sprintf("#N %s\n", gout.name()))
+	if gout.description() != "" {
+		for _, descriptionLine := range strings.Split(gout.description(), "\n") {
+			writer.WriteString(fmt.Sprintf("#C %s\n", descriptionLine))
+		}
+	}
+	for _, node := range mw.nodes {
+		writer.WriteString(node + "\n")
+	}
+	return writer.Flush()
+}
+
+// contains : inform if a quadtree of a level centered
+// in 0, 0 contains all the cells of the grid
+func (mw *macrocellWriter) contains(level int) bool {
+	half := (1 << uint(level)) / 2
+	g := mw.gout.gol
+	return mw.originI >= -half && mw.originJ >= -half &&
+		mw.originI+g.Rows() <= half && mw.originJ+g.Cols() <= half
+}
+
+// get : return the status of the cell in the absolute coordinates i, j
+func (mw *macrocellWriter) get(i, j int) int {
+	g := mw.gout.gol
+	i -= mw.originI
+	j -= mw.originJ
+	if i < 0 || i >= g.Rows() || j < 0 || j >= g.Cols() {
+		return statuses.DEAD
+	}
+	return mw.gout.get(i, j)
+}
+
+// isEmpty : inform if the square of a side whose top-left
+// cell is in the absolute coordinates i, j has no cells
+// of the grid that are not dead
+func (mw *macrocellWriter) isEmpty(side, i, j int) bool {
+	g := mw.gout.gol
+	minI := utils.MaxInt(i-mw.originI, 0)
+	minJ := utils.MaxInt(j-mw.originJ, 0)
+	maxI := utils.MinInt(i+side-mw.originI, g.Rows())
+	maxJ := utils.MinInt(j+side-mw.originJ, g.Cols())
+	for gi := minI; gi < maxI; gi++ {
+		for gj := minJ; gj < maxJ; gj++ {
+			if mw.gout.get(gi, gj) != statuses.DEAD {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// node : return the index of the node of a level whose top-left cell is
+// in the absolute coordinates i, j (0 if it is empty), adding it and its
+// children to the node list if they are not in it yet
+func (mw *macrocellWriter) node(level, i, j int) int {
+	side := 1 << uint(level)
+	if mw.isEmpty(side, i, j) {
+		return 0
+	}
+	var node string
+	if level == 1 && mw.multiState {
+		node = fmt.Sprintf("1 %d %d %d %d", mw.get(i, j), mw.get(i, j+1), mw.get(i+1, j), mw.get(i+1, j+1))
+	} else if level == 3 && !mw.multiState {
+		node = mw.leaf(i, j)
+	} else {
+		half := side / 2
+		node = fmt.Sprintf(
+			"%d %d %d %d %d", level,
+			mw.node(level-1, i, j), mw.node(level-1, i, j+half),
+			mw.node(level-1, i+half, j), mw.node(level-1, i+half, j+half),
+		)
+	}
+	if index, isAdded := mw.indexes[node]; isAdded {
+		return index
+	}
+	mw.nodes = append(mw.nodes, node)
+	mw.indexes[node] = len(mw.nodes)
+	return len(mw.nodes)
+}
+
+// leaf : return the 8x8 leaf whose top-left cell is in the absolute
+// coordinates i, j as rows of . (dead) and * (alive) cells ended by $,
+// without the dead cells at the end of the rows nor the empty last rows
+func (mw *macrocellWriter) leaf(i, j int) string {
+	rows := make([]string, 8)
+	lastRow := -1
+	for di := 0; di < 8; di++ {
+		row := ""
+		for dj := 0; dj < 8; dj++ {
+			if mw.get(i+di, j+dj) == statuses.ALIVE {
+				row += "*"
+			} else {
+				row += "."
+			}
+		}
+		rows[di] = strings.TrimRight(row, ".")
+		if rows[di] != "" {
+			lastRow = di
+		}
+	}
+	return strings.Join(rows[:lastRow+1], "$") + "$"
+}
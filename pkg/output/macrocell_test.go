@@ -0,0 +1,58 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestUnboundedGolSavedToMacrocellFile(t *testing.T) {
+	g, _ := gol.NewRandomGol("Random", "A random\npattern", "23/3", "dok", "unbounded", "unbounded", 30, 40, int64(1))
+	g.Set(0, 0, statuses.ALIVE)
+	g.Set(29, 39, statuses.ALIVE)
+	g.SetOrigin(-70, 12)
+	gconf := base.NewGolConf(map[string]interface{}{
+		"rowLimitation": "unbounded",
+		"colLimitation": "unbounded",
+	})
+	content := testGolSavedToFile(t, g, gconf, ".mc")
+	if !strings.HasPrefix(content, "[M2]") || !strings.Contains(content, "#C A random\n#C pattern\n") {
+		t.Errorf("Wrong macrocell file:\n%s", content)
+	}
+}
+
+func TestBoundedGolSavedToMacrocellFile(t *testing.T) {
+	g, _ := gol.NewGol("Generations", "", "B2/S/C30", "dok", "limited", "limited", 4, 5, 3)
+	g.Set(0, 0, statuses.ALIVE)
+	g.Set(0, 2, statuses.DYING)
+	g.Set(3, 0, 27)
+	g.Set(3, 4, 29)
+	content := testGolSavedToFile(t, g, nil, ".mc")
+	if !strings.Contains(content, "#G 3\n") || !strings.Contains(content, "1 0 0 27 0\n") {
+		t.Errorf("Wrong macrocell file:\n%s", content)
+	}
+}
+
+func TestRepeatedSubtreesSavedToMacrocellFile(t *testing.T) {
+	g, _ := gol.NewGol("Blocks", "", "23/3", "dok", "limited", "limited", 64, 64, 0)
+	for i := 0; i < 64; i += 8 {
+		for j := 0; j < 64; j += 8 {
+			g.Set(i, j, statuses.ALIVE)
+			g.Set(i+7, j+7, statuses.ALIVE)
+		}
+	}
+	content := testGolSavedToFile(t, g, nil, ".mc")
+	// One leaf and one node by level from 4 to 7
+	nodes := 0
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		if !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "[") {
+			nodes++
+		}
+	}
+	if nodes != 5 {
+		t.Errorf("Repeated subtrees should be written once, found %d nodes:\n%s", nodes, content)
+	}
+}
@@ -0,0 +1,117 @@
+package output
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+)
+
+// npyMagic : first bytes of the NumPy .npy files
+const npyMagic = "\x93NUMPY"
+
+// npyHeaderAlignment : the header (including the magic string, version and
+// header length) is padded with spaces to be a multiple of this length
+const npyHeaderAlignment = 64
+
+// SaveToNpyFile : save the grid of the game of life instance to a NumPy
+// .npy file with a 2-D array (rows x cols) of uint8 states.
+// See https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html
+func (gout *GolOutputer) SaveToNpyFile(filename string) error {
+	return saveToFile(filename, gout.encodeNpy)
+}
+
+// SaveGenerationsToNpyFile : save the grids of a number of generations
+// (starting with the current one) to a NumPy .npy file with a 3-D array
+// (generations x rows x cols) of uint8 states
+func (gout *GolOutputer) SaveGenerationsToNpyFile(filename string, generations int) error {
+	return saveToFile(filename, func(w io.Writer) error {
+		return gout.encodeNpyGenerations(w, generations)
+	})
+}
+
+// encodeNpy : write the grid of the game of life
+// instance to a NumPy .npy stream as a 2-D array
+func (gout *GolOutputer) encodeNpy(w io.Writer) error {
+	g := gout.gol
+	if statesError := checkNpyStates(g); statesError != nil {
+		return statesError
+	}
+	writer := bufio.NewWriter(w)
+	writeNpyHeader(writer, []int{g.Rows(), g.Cols()})
+	writeNpyGrid(writer, g)
+	return writer.Flush()
+}
+
+// encodeNpyGenerations : write the grids of a number of generations
+// to a NumPy .npy stream as a 3-D array
+func (gout *GolOutputer) encodeNpyGenerations(w io.Writer, generations int) error {
+	if generations < 1 {
+		return fmt.Errorf("The number of generations must be a positive integer, found %d", generations)
+	}
+	g := gout.gol
+	if statesError := checkNpyStates(g); statesError != nil {
+		return statesError
+	}
+	rows, cols := g.Rows(), g.Cols()
+	writer := bufio.NewWriter(w)
+	writeNpyHeader(writer, []int{generations, rows, cols})
+	for generation := 0; generation < generations; generation++ {
+		if generation > 0 {
+			g = g.NextGeneration().(*gol.Gol)
+		}
+		// Unbounded grids can grow with the pattern
+		if g.Rows() != rows || g.Cols() != cols {
+			return fmt.Errorf("The grid of the generation %d has %dx%d cells, but the first one has %dx%d cells",
+				g.Generation(), g.Rows(), g.Cols(), rows, cols)
+		}
+		writeNpyGrid(writer, g)
+	}
+	return writer.Flush()
+}
+
+// checkNpyStates : return an error if the states
+// of the cells cannot be stored in uint8 values
+func checkNpyStates(g *gol.Gol) error {
+	if g.States() > math.MaxUint8+1 {
+		return fmt.Errorf("Only rules with at most %d states can be saved to .npy files, found %d",
+			math.MaxUint8+1, g.States())
+	}
+	return nil
+}
+
+// writeNpyHeader : write the magic string, version (1.0) and
+// header of a .npy file with a C-ordered uint8 array
+func writeNpyHeader(writer *bufio.Writer, shape []int) {
+	shapeParts := make([]string, len(shape))
+	for dimension, size := range shape {
+		shapeParts[dimension] = strconv.Itoa(size)
+	}
+	header := fmt.Sprintf("{'descr': '|u1', 'fortran_order': False, 'shape': (%s), }", strings.Join(shapeParts, ", "))
+	// The header ends with a newline after the padding
+	prefixLength := len(npyMagic) + 4
+	paddingLength := npyHeaderAlignment - (prefixLength+len(header)+1)%npyHeaderAlignment
+	if paddingLength == npyHeaderAlignment {
+		paddingLength = 0
+	}
+	header += strings.Repeat(" ", paddingLength) + "\n"
+
+	writer.WriteString(npyMagic)
+	writer.Write([]byte{1, 0})
+	binary.Write(writer, binary.LittleEndian, uint16(len(header)))
+	writer.WriteString(header)
+}
+
+// writeNpyGrid : write the states of the cells row by row
+func writeNpyGrid(writer *bufio.Writer, g *gol.Gol) {
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			writer.WriteByte(byte(g.Get(i, j)))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/input"
+)
+
+func TestSaveToNpyFile(t *testing.T) {
+	g, _ := gol.NewRandomGol("Random", "", "23/3", "dok", "limited", "limited", 10, 12, int64(1))
+	tempDir, tempDirError := ioutil.TempDir("", "")
+	if tempDirError != nil {
+		t.Error(tempDirError)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	outputFilePath := filepath.Join(tempDir, "random.npy")
+	if saveError := NewGolOutputer(g).SaveToFile(outputFilePath); saveError != nil {
+		t.Error(saveError)
+		return
+	}
+
+	content, _ := ioutil.ReadFile(outputFilePath)
+	expectedHeader := "\x93NUMPY\x01\x00\x76\x00{'descr': '|u1', 'fortran_order': False, 'shape': (10, 12), }"
+	if !bytes.HasPrefix(content, []byte(expectedHeader)) {
+		t.Errorf("Wrong header of the .npy file: %q", content[:len(expectedHeader)])
+		return
+	}
+	// The header is padded to 128 bytes
+	if len(content) != 128+10*12 || content[127] != '\n' {
+		t.Errorf("The .npy file has %d bytes, expected %d", len(content), 128+10*12)
+		return
+	}
+	for i := 0; i < g.Rows(); i++ {
+		for j := 0; j < g.Cols(); j++ {
+			if int(content[128+i*g.Cols()+j]) != g.Get(i, j) {
+				t.Errorf("Cell %d, %d is %d, expected %d", i, j, content[128+i*g.Cols()+j], g.Get(i, j))
+			}
+		}
+	}
+
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, readError := gr.ReadFile(outputFilePath, nil)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if !readG.GridEquals(g, "values") {
+		t.Errorf("The grid read from the .npy file is different")
+	}
+}
+
+func TestSaveGenerationsToNpyFile(t *testing.T) {
+	g, _ := gol.NewRandomGol("Random", "", "23/3", "dok", "unlimited", "unlimited", 8, 9, int64(1))
+	tempDir, tempDirError := ioutil.TempDir("", "")
+	if tempDirError != nil {
+		t.Error(tempDirError)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	outputFilePath := filepath.Join(tempDir, "random.npy")
+	generations := 5
+	if saveError := NewGolOutputer(g).SaveGenerationsToNpyFile(outputFilePath, generations); saveError != nil {
+		t.Error(saveError)
+		return
+	}
+
+	gr := input.NewGolReader(new(gol.Gol))
+	gconf := base.NewGolConf(map[string]interface{}{"rowLimitation": "unlimited", "colLimitation": "unlimited"})
+	gols, readError := gr.ReadNpyGenerations(outputFilePath, gconf)
+	if readError != nil {
+		t.Error(readError)
+		return
+	}
+	if len(gols) != generations {
+		t.Errorf("%d generations expected, found %d", generations, len(gols))
+		return
+	}
+	for generation, readG := range gols {
+		if readG.Generation() != generation || !readG.GridEquals(g, "values") {
+			t.Errorf("The generation %d read from the .npy file is different", generation)
+		}
+		g = g.NextGeneration().(*gol.Gol)
+	}
+
+	if saveError := NewGolOutputer(g).SaveGenerationsToNpyFile(outputFilePath, 0); saveError == nil {
+		t.Errorf("An error should have been returned when saving 0 generations")
+	}
+}
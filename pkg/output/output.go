@@ -2,8 +2,9 @@ package output
 
 import (
 	"fmt"
-	"strings"
+	"io"
 
+	"github.com/diegojromerolopez/congolway/pkg/base"
 	"github.com/diegojromerolopez/congolway/pkg/gol"
 	"github.com/diegojromerolopez/congolway/pkg/statuses"
 )
@@ -20,22 +21,48 @@ func NewGolOutputer(g *gol.Gol) *GolOutputer {
 	return &GolOutputer{g}
 }
 
-// SaveToFile : prints on stdout the current state of the grid
+// Gol : return the game of life instance written by the outputer
+func (gout *GolOutputer) Gol() *gol.Gol {
+	return gout.gol
+}
+
+// SaveToFile : save the game of life instance to a file whose format
+// depends on its extension. Files with a .gz or .zst extension after the
+// one of the format (e.g. "gun.rle.gz") are compressed.
 func (gout *GolOutputer) SaveToFile(filename string) error {
-	lastDotIndex := strings.LastIndex(filename, ".")
-	if lastDotIndex < 0 {
-		return fmt.Errorf("File \"%s\" has no extension. Only .txt and .cells files are allowed", filename)
+	format := formatByExtension(base.FormatExtension(filename))
+	if format == nil {
+		return fmt.Errorf("File extension of \"%s\" not recognized. Only %s extensions are allowed",
+			filename, extensionsString())
 	}
-	fileExtension := filename[lastDotIndex:]
-
-	if fileExtension == ".txt" {
-		return gout.SaveToCongolwayFile(filename, "dense")
-	} else if fileExtension == ".cells" {
-		return gout.SaveToCellsFile(filename)
-	} else if fileExtension == ".life" {
-		return gout.SaveToLifeFile(filename, "1.06")
+	return saveToFile(filename, func(w io.Writer) error {
+		return format.Encode(gout, w)
+	})
+}
+
+// saveToFile : create a file (compressed if it has a .gz or .zst
+// extension) and write the game of life instance to it
+func saveToFile(filename string, encode func(w io.Writer) error) error {
+	file, err := base.CreateFile(filename)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("File extension \"%s\" not recognized. Only .txt and .cells are allowed", fileExtension)
+	encodeError := encode(file)
+	closeError := file.Close()
+	if encodeError != nil {
+		return encodeError
+	}
+	return closeError
+}
+
+// Encode : write the game of life instance to a stream
+// in one of the registered formats (see Formats)
+func (gout *GolOutputer) Encode(w io.Writer, format string) error {
+	registeredFormat := formatByName(format)
+	if registeredFormat == nil {
+		return fmt.Errorf("Format \"%s\" not recognized. Only %v are allowed", format, Formats())
+	}
+	return registeredFormat.Encode(gout, w)
 }
 
 func (gout *GolOutputer) name() string {
@@ -64,14 +91,16 @@ func (gout *GolOutputer) neighborhoodTypeString() string {
 
 func (gout *GolOutputer) limitsString() string {
 	limitsStr := ""
-	if gout.gol.LimitRows() && gout.gol.LimitCols() {
+	if gout.gol.Unbounded() {
+		limitsStr += "unbounded"
+	} else if gout.gol.LimitRows() && gout.gol.LimitCols() {
 		limitsStr += "rows, cols"
 	} else if gout.gol.LimitRows() {
 		limitsStr += "rows"
 	} else if gout.gol.LimitCols() {
 		limitsStr += "cols"
 	} else {
-		panic("Impossible condition")
+		limitsStr += "no"
 	}
 	return limitsStr
 }
@@ -79,12 +108,7 @@ func (gout *GolOutputer) limitsString() string {
 // Stdout : prints on stdout the current state of the grid
 // Return the numbers of lines used
 func (gout *GolOutputer) Stdout(cellStringCorresp map[int]string) int {
-	if cellStringCorresp == nil {
-		cellStringCorresp = map[int]string{
-			statuses.DEAD:  "░",
-			statuses.ALIVE: "█",
-		}
-	}
+	cellStringCorresp = gout.cellStrings(cellStringCorresp)
 	g := gout.gol
 	rows := g.Rows()
 	cols := g.Cols()
@@ -99,3 +123,51 @@ func (gout *GolOutputer) Stdout(cellStringCorresp map[int]string) int {
 	}
 	return rows + 2
 }
+
+// StdoutChanges : prints on stdout the state of some cells of the grid
+// (e.g. the changed ones, see gol.ChangedCells) over the output of Stdout
+// for an earlier generation of the same size. The cursor of the terminal
+// must be in the first line of that output, and it is left in the line
+// after it, as Stdout does.
+// Return the numbers of lines used
+func (gout *GolOutputer) StdoutChanges(cellStringCorresp map[int]string, cells [][2]int) int {
+	cellStringCorresp = gout.cellStrings(cellStringCorresp)
+	g := gout.gol
+	rows := g.Rows()
+	// Lines are cleared, as they can be shorter than the previous ones
+	fmt.Printf("\033[2K%s\n", g.Name())
+	fmt.Printf("\033[2KGeneration: %d\n", g.Generation())
+	for _, cell := range cells {
+		// Save the position of the cursor (the first row), move
+		// it to the cell and restore it after printing the cell
+		fmt.Print("\0337")
+		if cell[0] > 0 {
+			fmt.Printf("\033[%dB", cell[0])
+		}
+		if cell[1] > 0 {
+			fmt.Printf("\033[%dC", cell[1])
+		}
+		fmt.Print(cellStringCorresp[g.Get(cell[0], cell[1])])
+		fmt.Print("\0338")
+	}
+	if rows > 0 {
+		fmt.Printf("\033[%dE", rows)
+	}
+	return rows + 2
+}
+
+// cellStrings : return the strings of the states of the cells,
+// or the default ones if cellStringCorresp is nil
+func (gout *GolOutputer) cellStrings(cellStringCorresp map[int]string) map[int]string {
+	if cellStringCorresp != nil {
+		return cellStringCorresp
+	}
+	cellStringCorresp = map[int]string{
+		statuses.DEAD:  "░",
+		statuses.ALIVE: "█",
+	}
+	for state := statuses.DYING; state < gout.gol.States(); state++ {
+		cellStringCorresp[state] = "▒"
+	}
+	return cellStringCorresp
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/input"
+)
+
+func TestEncode(t *testing.T) {
+	formats := []string{
+		base.CongolwayFormat, base.CellsFormat, base.LifeFormat,
+		base.Life105Format, base.Life106Format, base.RleFormat, base.MacrocellFormat,
+	}
+	g, _ := gol.NewRandomGol("Random", "A random pattern", "23/3", "dok", "limited", "limited", 10, 12, int64(1))
+	g.Set(0, 0, 1)
+	g.Set(9, 11, 1)
+	for _, format := range formats {
+		var buffer bytes.Buffer
+		if encodeError := NewGolOutputer(g).Encode(&buffer, format); encodeError != nil {
+			t.Errorf("%s could not be encoded: %s", format, encodeError)
+			continue
+		}
+		// The format is sniffed from the content
+		gr := input.NewGolReader(new(gol.Gol))
+		readG, decodeError := gr.Decode(&buffer, "", nil)
+		if decodeError != nil {
+			t.Errorf("%s could not be decoded: %s", format, decodeError)
+			continue
+		}
+		for i := 0; i < g.Rows(); i++ {
+			for j := 0; j < g.Cols(); j++ {
+				if readG.Get(i, j) != g.Get(i, j) {
+					t.Errorf("Cell %d, %d of %s is %d, expected %d", i, j, format, readG.Get(i, j), g.Get(i, j))
+				}
+			}
+		}
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	g, _ := gol.NewGol("Gol", "", "23/3", "dok", "limited", "limited", 5, 5, 0)
+	var buffer bytes.Buffer
+	for _, format := range []string{base.GifFormat, "plaintext"} {
+		if encodeError := NewGolOutputer(g).Encode(&buffer, format); encodeError == nil {
+			t.Errorf("An error should have been returned when encoding to %s", format)
+		}
+	}
+}
+
+// testGolSavedToFile : save a game of life to a file with the extension
+// of its format, check it is read back and return the contents of the file
+func testGolSavedToFile(t *testing.T, g *gol.Gol, gconf *base.GolConf, extension string) string {
+	file, err := ioutil.TempFile("", "temp_gol*"+extension)
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+	outputFilePath := file.Name()
+	defer os.Remove(outputFilePath)
+	// Only the path of the temporary file is used
+	if closeError := file.Close(); closeError != nil {
+		t.Error(closeError)
+		return ""
+	}
+
+	golo := NewGolOutputer(g)
+	if saveError := golo.SaveToFile(outputFilePath); saveError != nil {
+		t.Error(saveError)
+		return ""
+	}
+
+	content, readFileError := ioutil.ReadFile(outputFilePath)
+	if readFileError != nil {
+		t.Error(readFileError)
+		return ""
+	}
+
+	gr := input.NewGolReader(new(gol.Gol))
+	readG, readError := gr.ReadFile(outputFilePath, gconf)
+	if readError != nil {
+		t.Error(readError)
+		return string(content)
+	}
+	if equalsError := readG.EqualsError(g); equalsError != nil {
+		t.Error(equalsError)
+	}
+	if readG.LimitRows() != g.LimitRows() || readG.LimitCols() != g.LimitCols() {
+		t.Errorf("The limits of the rows and columns should be kept")
+	}
+	return string(content)
+}
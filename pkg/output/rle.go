@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// rleMaxLineLength : maximum length of the lines of RLE data
+const rleMaxLineLength = 70
+
+// SaveToRleFile : save the game of life instance to a
+// Run Length Encoded (.rle) file. Unbounded grids write the position
+// of their top-left cell in a #R line, and bounded grids whose rows
+// and columns are both limited (or both unlimited) write the Golly
+// plane (torus) topology after the rules.
+// See https://www.conwaylife.com/wiki/Run_Length_Encoded
+func (gout *GolOutputer) SaveToRleFile(filename string) error {
+	return saveToFile(filename, gout.encodeRle)
+}
+
+// encodeRle : write the game of life instance to a Run Length Encoded stream
+func (gout *GolOutputer) encodeRle(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	g := gout.gol
+	rows := g.Rows()
+	cols := g.Cols()
+
+	writer.WriteString(fmt.Sprintf("#N %s\n", gout.name()))
+	if gout.description() != "" {
+		for _, descriptionLine := range strings.Split(gout.description(), "\n") {
+			writer.WriteString(fmt.Sprintf("#C %s\n", descriptionLine))
+		}
+	}
+	topology := ""
+	if g.Unbounded() {
+		originI, originJ := g.Origin()
+		writer.WriteString(fmt.Sprintf("#R %d %d\n", originJ, originI))
+	} else if g.LimitRows() && g.LimitCols() {
+		topology = fmt.Sprintf(":P%d,%d", cols, rows)
+	} else if !g.LimitRows() && !g.LimitCols() {
+		topology = fmt.Sprintf(":T%d,%d", cols, rows)
+	}
+	writer.WriteString(fmt.Sprintf("x = %d, y = %d, rule = %s%s\n", cols, rows, gout.rules(), topology))
+	gout.writeRleData(writer)
+	return writer.Flush()
+}
+
+// writeRleData : write the cells as RLE runs in lines of at most
+// rleMaxLineLength characters, ended by !
+func (gout *GolOutputer) writeRleData(writer *bufio.Writer) {
+	g := gout.gol
+	rows := g.Rows()
+	cols := g.Cols()
+	multiState := g.States() > 2
+	line := ""
+	// Row where the last run was written. Rows without alive cells are
+	// only written when they are followed by rows with alive cells.
+	currentRow := 0
+	writeRun := func(runLength int, tag string) {
+		run := tag
+		if runLength > 1 {
+			run = strconv.Itoa(runLength) + tag
+		}
+		if len(line)+len(run) > rleMaxLineLength {
+			writer.WriteString(line + "\n")
+			line = ""
+		}
+		line += run
+	}
+	for i := 0; i < rows; i++ {
+		// Dead cells at the end of the rows are not written
+		lastCol := cols - 1
+		for lastCol >= 0 && gout.get(i, lastCol) == statuses.DEAD {
+			lastCol--
+		}
+		if lastCol < 0 {
+			continue
+		}
+		if i > currentRow {
+			writeRun(i-currentRow, "$")
+			currentRow = i
+		}
+		for j := 0; j <= lastCol; {
+			status := gout.get(i, j)
+			runLength := 1
+			for j+runLength <= lastCol && gout.get(i, j+runLength) == status {
+				runLength++
+			}
+			writeRun(runLength, rleTag(status, multiState))
+			j += runLength
+		}
+	}
+	writeRun(1, "!")
+	writer.WriteString(line + "\n")
+}
+
+// rleTag : return the RLE tag of a status. Two-state rules use b (dead)
+// and o (alive), while multi-state rules use . for dead cells and
+// A-X for the states 1-24, preceded by p-y for the states 25-255.
+func rleTag(status int, multiState bool) string {
+	if !multiState {
+		if status == statuses.ALIVE {
+			return "o"
+		}
+		return "b"
+	}
+	if status == statuses.DEAD {
+		return "."
+	}
+	letter := string(rune('A' + (status-1)%24))
+	if status <= 24 {
+		return letter
+	}
+	return string(rune('p'+(status-25)/24)) + letter
+}
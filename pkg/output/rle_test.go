@@ -0,0 +1,55 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/base"
+	"github.com/diegojromerolopez/congolway/pkg/gol"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestRandomGolSavedToRleFile(t *testing.T) {
+	for _, limitation := range []string{"limited", "unlimited"} {
+		g, _ := gol.NewRandomGol("Random", "A random\npattern", "23/3", "dok", limitation, limitation, 20, 150, int64(1))
+		testGolSavedToRleFile(t, g, nil)
+	}
+}
+
+func TestMultiStateGolSavedToRleFile(t *testing.T) {
+	g, _ := gol.NewGol("Generations", "", "B2/S/C30", "dok", "unbounded", "unbounded", 4, 5, 0)
+	g.Set(0, 1, statuses.ALIVE)
+	g.Set(0, 2, statuses.DYING)
+	g.Set(3, 0, 27)
+	g.Set(3, 4, 29)
+	g.SetOrigin(-3, 8)
+	gconf := base.NewGolConf(map[string]interface{}{
+		"rowLimitation": "unbounded",
+		"colLimitation": "unbounded",
+	})
+	content := testGolSavedToRleFile(t, g, gconf)
+	if !strings.Contains(content, "#R 8 -3\n") || !strings.Contains(content, ".AB3$pC3.pE!") {
+		t.Errorf("Wrong RLE file:\n%s", content)
+	}
+}
+
+func TestLargerThanLifeSavedToRleFile(t *testing.T) {
+	g, _ := gol.NewGol("Larger than Life", "", "R2,C0,M1,S2..5,B3..4,NC", "dok", "unlimited", "unlimited", 5, 5, 0)
+	g.Set(2, 2, statuses.ALIVE)
+	content := testGolSavedToRleFile(t, g, nil)
+	if !strings.Contains(content, "x = 5, y = 5, rule = R2,C0,M1,S2..5,B3..4,NC:T5,5\n") {
+		t.Errorf("Wrong RLE header:\n%s", content)
+	}
+}
+
+// testGolSavedToRleFile : save a game of life to a RLE file, check it is
+// read back and its data lines are not too long, and return its contents
+func testGolSavedToRleFile(t *testing.T, g *gol.Gol, gconf *base.GolConf) string {
+	content := testGolSavedToFile(t, g, gconf, ".rle")
+	for _, line := range strings.Split(content, "\n") {
+		if len(line) > 70 && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "x") {
+			t.Errorf("Lines of RLE data should have at most 70 characters, found %d", len(line))
+		}
+	}
+	return content
+}
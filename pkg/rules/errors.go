@@ -0,0 +1,7 @@
+package rules
+
+import "errors"
+
+// ErrInvalidRule : the rulestring cannot be parsed in any of the
+// accepted notations
+var ErrInvalidRule = errors.New("Invalid rule")
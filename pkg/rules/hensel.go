@@ -0,0 +1,141 @@
+package rules
+
+import "strings"
+
+// Isotropic non-totalistic rules (Hensel notation) distinguish the
+// configurations of the alive Moore neighbors of a cell that cannot be
+// transformed into one another by rotations or reflections.
+// There are 51 of these configurations, each one identified by a number of
+// alive neighbors and a letter.
+// See https://www.conwaylife.com/wiki/Isotropic_non-totalistic_rule
+//
+// A configuration is stored as a bit mask of the alive neighbors, where
+// each bit corresponds to a neighbor in the same order they are returned by
+// the Moore neighborhood function:
+//	bit 0: NW, bit 1: N, bit 2: NE,
+//	bit 3: W,            bit 4: E,
+//	bit 5: SW, bit 6: S, bit 7: SE
+
+// Configurations : number of configurations of the Moore neighborhood
+const Configurations = 256
+
+const (
+	nw = 1 << iota
+	n
+	ne
+	w
+	e
+	sw
+	s
+	se
+)
+
+// henselLetters : letters of the configurations of each number of
+// alive neighbors, in the order used when writing rules
+var henselLetters = [9]string{
+	"",
+	"ce",
+	"ceaikn",
+	"ceaiknjqry",
+	"ceaiknjqrytwz",
+	"ceaiknjqry",
+	"ceaikn",
+	"ce",
+	"",
+}
+
+// henselRepresentatives : a configuration of each letter (in the order of
+// henselLetters) for 1 to 4 alive neighbors. Configurations of 5 to 8 alive
+// neighbors have the letter of their complement.
+var henselRepresentatives = [5][]int{
+	{},
+	{nw, n},
+	{nw | ne, n | w, nw | n, w | e, nw | e, ne | sw},
+	{
+		nw | ne | sw, n | w | e, nw | n | w, nw | n | ne, n | e | sw,
+		nw | ne | w, n | ne | w, n | ne | sw, nw | w | e, nw | e | sw,
+	},
+	{
+		nw | ne | sw | se, n | w | e | s, nw | n | ne | w, nw | ne | w | e,
+		nw | n | e | sw, nw | n | ne | sw, n | w | e | sw, n | ne | e | sw,
+		nw | n | w | e, nw | ne | e | sw, nw | w | e | sw, n | ne | w | sw,
+		ne | w | e | sw,
+	},
+}
+
+// configurationLetters : letter of each configuration
+var configurationLetters = computeConfigurationLetters()
+
+// AliveNeighbors : return the number of alive neighbors of a configuration
+func AliveNeighbors(configuration int) int {
+	count := 0
+	for ; configuration != 0; configuration &= configuration - 1 {
+		count++
+	}
+	return count
+}
+
+// ConfigurationLetter : return the Hensel letter of a configuration,
+// or 0 if the number of alive neighbors is 0 or 8.
+func ConfigurationLetter(configuration int) byte {
+	return configurationLetters[configuration]
+}
+
+func computeConfigurationLetters() [Configurations]byte {
+	var letters [Configurations]byte
+	for count, representatives := range henselRepresentatives {
+		for letterI, representative := range representatives {
+			letter := henselLetters[count][letterI]
+			for _, configuration := range symmetries(representative) {
+				letters[configuration] = letter
+			}
+		}
+	}
+	for configuration := 0; configuration < Configurations; configuration++ {
+		if AliveNeighbors(configuration) > 4 {
+			letters[configuration] = letters[^configuration&(Configurations-1)]
+		}
+	}
+	return letters
+}
+
+// symmetries : return the configurations obtained by rotating
+// and reflecting a configuration
+func symmetries(configuration int) []int {
+	result := make([]int, 0, 8)
+	for reflection := 0; reflection < 2; reflection++ {
+		for rotation := 0; rotation < 4; rotation++ {
+			result = append(result, configuration)
+			configuration = rotate(configuration)
+		}
+		configuration = reflect(configuration)
+	}
+	return result
+}
+
+// rotate : rotate a configuration 90 degrees clockwise
+func rotate(configuration int) int {
+	return transform(configuration, [8]int{ne, e, se, n, s, nw, w, sw})
+}
+
+// reflect : reflect a configuration over the vertical axis
+func reflect(configuration int) int {
+	return transform(configuration, [8]int{ne, n, nw, e, w, se, s, sw})
+}
+
+// transform : move each neighbor i of the configuration to destinations[i]
+func transform(configuration int, destinations [8]int) int {
+	result := 0
+	for i, destination := range destinations {
+		if configuration&(1<<uint(i)) != 0 {
+			result |= destination
+		}
+	}
+	return result
+}
+
+// isHenselLetter : inform if a letter is used to identify
+// a configuration with count alive neighbors
+func isHenselLetter(count int, letter rune) bool {
+	return count < len(henselLetters) && strings.ContainsRune(henselLetters[count], letter)
+}
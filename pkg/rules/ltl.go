@@ -0,0 +1,200 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+)
+
+// Larger than Life rules generalize totalistic rules to neighborhoods of any
+// radius, where cells are born or survive if the number of alive cells in
+// their neighborhood is in a range. They are written as a list of
+// comma-separated parameters (e.g. "R5,C0,M1,S34..58,B34..45,NM"):
+//	R: radius of the neighborhood (from 1 to neighborhood.MaxRadius).
+//	C: number of states (0 or 2 for two states, 3 or more for Generations rules).
+//	M: 1 if the cell counts itself when alive, 0 otherwise.
+//	S: range of alive cells that make an alive cell survive.
+//	B: range of alive cells that make a dead cell be born.
+//	N: neighborhood, M for Moore, N for Von Neumann or C for circular.
+// Only R, S and B are required.
+// See https://www.conwaylife.com/wiki/Larger_than_Life
+
+// largerThanLifeNeighborhoods : neighborhood of each letter of the N parameter
+var largerThanLifeNeighborhoods = map[byte]int{
+	'M': neighborhood.MOORE,
+	'N': neighborhood.VONNEUMANN,
+	'C': neighborhood.CIRCULAR,
+}
+
+// isLargerThanLife : inform if a rule (in upper case)
+// is written in Larger than Life notation
+func isLargerThanLife(rule string) bool {
+	return strings.HasPrefix(rule, "R")
+}
+
+// parseLargerThanLife : parse a rule (in upper case) written
+// in Larger than Life notation
+func parseLargerThanLife(rulestring, rule string) (*Rule, error) {
+	parameters := make(map[byte]string)
+	for _, parameter := range strings.Split(rule, ",") {
+		parameter = strings.TrimSpace(parameter)
+		if len(parameter) < 2 || !strings.ContainsRune("RCMSBN", rune(parameter[0])) {
+			return nil, fmt.Errorf(
+				"%w \"%s\": expected R, C, M, S, B or N parameter, found \"%s\"",
+				ErrInvalidRule, rulestring, parameter,
+			)
+		}
+		if _, isRepeated := parameters[parameter[0]]; isRepeated {
+			return nil, fmt.Errorf("%w \"%s\": repeated %c parameter", ErrInvalidRule, rulestring, parameter[0])
+		}
+		parameters[parameter[0]] = parameter[1:]
+	}
+	for _, required := range []byte{'R', 'S', 'B'} {
+		if _, isPresent := parameters[required]; !isPresent {
+			return nil, fmt.Errorf("%w \"%s\": %c parameter is required", ErrInvalidRule, rulestring, required)
+		}
+	}
+
+	radius, radiusError := strconv.Atoi(parameters['R'])
+	if radiusError != nil || radius < 1 || radius > neighborhood.MaxRadius {
+		return nil, fmt.Errorf(
+			"%w \"%s\": the radius must be between 1 and %d, found %s",
+			ErrInvalidRule, rulestring, neighborhood.MaxRadius, parameters['R'],
+		)
+	}
+
+	states := 2
+	if statesParameter, isPresent := parameters['C']; isPresent {
+		var statesError error
+		states, statesError = strconv.Atoi(statesParameter)
+		if statesError != nil || states == 1 || states < 0 || states > MaxStates {
+			return nil, fmt.Errorf(
+				"%w \"%s\": the number of states must be 0 or between 2 and %d, found %s",
+				ErrInvalidRule, rulestring, MaxStates, statesParameter,
+			)
+		}
+		if states == 0 {
+			states = 2
+		}
+	}
+
+	middle := false
+	if middleParameter, isPresent := parameters['M']; isPresent {
+		if middleParameter != "0" && middleParameter != "1" {
+			return nil, fmt.Errorf("%w \"%s\": M must be 0 or 1, found %s", ErrInvalidRule, rulestring, middleParameter)
+		}
+		middle = middleParameter == "1"
+	}
+
+	neighborhoodType := neighborhood.MOORE
+	if neighborhoodParameter, isPresent := parameters['N']; isPresent {
+		var isKnown bool
+		neighborhoodType, isKnown = largerThanLifeNeighborhoods[neighborhoodParameter[0]]
+		if !isKnown || len(neighborhoodParameter) != 1 {
+			return nil, fmt.Errorf(
+				"%w \"%s\": N must be M (Moore), N (Von Neumann) or C (circular), found %s",
+				ErrInvalidRule, rulestring, neighborhoodParameter,
+			)
+		}
+	}
+
+	maxCount, sizeError := neighborhood.RangeSize(neighborhoodType, radius)
+	if sizeError != nil {
+		return nil, fmt.Errorf("%w \"%s\": %s", ErrInvalidRule, rulestring, sizeError)
+	}
+	if middle {
+		maxCount++
+	}
+	survival, survivalError := parseRange(parameters['S'], maxCount)
+	if survivalError != nil {
+		return nil, fmt.Errorf("%w \"%s\": invalid survival range, %s", ErrInvalidRule, rulestring, survivalError)
+	}
+	birth, birthError := parseRange(parameters['B'], maxCount)
+	if birthError != nil {
+		return nil, fmt.Errorf("%w \"%s\": invalid birth range, %s", ErrInvalidRule, rulestring, birthError)
+	}
+
+	r := newRule(birth, survival, nil, nil, neighborhoodType)
+	r.states = states
+	r.largerThanLife = true
+	r.radius = radius
+	r.middle = middle
+	return r, nil
+}
+
+// parseRange : parse a range of counts in "min..max" format
+func parseRange(rangeString string, maxCount int) (map[int]bool, error) {
+	limits := strings.Split(rangeString, "..")
+	if len(limits) != 2 {
+		return nil, fmt.Errorf("expected a range in min..max format, found %s", rangeString)
+	}
+	minCount, minError := strconv.Atoi(limits[0])
+	maxRangeCount, maxError := strconv.Atoi(limits[1])
+	if minError != nil || maxError != nil || minCount < 0 || minCount > maxRangeCount {
+		return nil, fmt.Errorf("expected a range in min..max format, found %s", rangeString)
+	}
+	if maxRangeCount > maxCount {
+		return nil, fmt.Errorf("%d cells found, but there are only %d in the neighborhood", maxRangeCount, maxCount)
+	}
+	counts := make(map[int]bool)
+	for count := minCount; count <= maxRangeCount; count++ {
+		counts[count] = true
+	}
+	return counts, nil
+}
+
+// IsLargerThanLife : inform if the rule is a Larger than Life rule
+func (r *Rule) IsLargerThanLife() bool {
+	return r.largerThanLife
+}
+
+// Radius : return the radius of the neighborhood of the rule
+// (1 for the rules that are not Larger than Life ones)
+func (r *Rule) Radius() int {
+	return r.radius
+}
+
+// IncludesMiddle : inform if an alive cell counts itself
+// as one of its alive neighbors
+func (r *Rule) IncludesMiddle() bool {
+	return r.middle
+}
+
+// largerThanLifeString : return the rule in Larger than Life notation
+// with all of its parameters (e.g. "R5,C0,M1,S34..58,B34..45,NM")
+func (r *Rule) largerThanLifeString() string {
+	states := r.states
+	if states == 2 {
+		states = 0
+	}
+	middle := 0
+	if r.middle {
+		middle = 1
+	}
+	neighborhoodLetter := byte('M')
+	for letter, neighborhoodType := range largerThanLifeNeighborhoods {
+		if neighborhoodType == r.neighborhood {
+			neighborhoodLetter = letter
+		}
+	}
+	return fmt.Sprintf(
+		"R%d,C%d,M%d,S%s,B%s,N%c",
+		r.radius, states, middle, rangeString(r.survival), rangeString(r.birth), neighborhoodLetter,
+	)
+}
+
+// rangeString : return a range of counts in "min..max" format
+func rangeString(counts map[int]bool) string {
+	minCount, maxCount := -1, -1
+	for count := range counts {
+		if minCount == -1 || count < minCount {
+			minCount = count
+		}
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	return fmt.Sprintf("%d..%d", minCount, maxCount)
+}
@@ -0,0 +1,454 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+// MaxStates : maximum number of states of the cells
+const MaxStates = 256
+
+// Rule : birth and survival conditions of a rule. Totalistic rules only
+// depend on the number of alive neighbors of each cell, while isotropic
+// non-totalistic rules depend on the configuration of the alive neighbors
+// (see hensel.go) and are only available for the Moore neighborhood.
+// See https://www.conwaylife.com/wiki/Rulestring
+type Rule struct {
+	birth        map[int]bool // Poor's man set
+	survival     map[int]bool // Poor's man set
+	neighborhood int
+	// Number of states of the cells: dead, alive
+	// and the dying states of Generations rules
+	states int
+	// Hensel letters of the conditions that only
+	// include some configurations of a count
+	birthLetters    map[int]string
+	survivalLetters map[int]string
+	// Transition tables for each configuration of the Moore neighborhood
+	birthTable    [Configurations]bool
+	survivalTable [Configurations]bool
+	// Larger than Life rules (see ltl.go)
+	largerThanLife bool
+	radius         int
+	middle         bool
+}
+
+// New : creates a totalistic rule from its birth and survival counts.
+// Use neighborhood.NONE as neighborhood type if the rule does not
+// force any neighborhood.
+func New(birth, survival map[int]bool, neighborhoodType int) *Rule {
+	birthCounts := make(map[int]bool)
+	for count, isSet := range birth {
+		if isSet {
+			birthCounts[count] = true
+		}
+	}
+	survivalCounts := make(map[int]bool)
+	for count, isSet := range survival {
+		if isSet {
+			survivalCounts[count] = true
+		}
+	}
+	return newRule(birthCounts, survivalCounts, nil, nil, neighborhoodType)
+}
+
+func newRule(birth, survival map[int]bool, birthLetters, survivalLetters map[int]string, neighborhoodType int) *Rule {
+	r := &Rule{
+		birth:           birth,
+		survival:        survival,
+		neighborhood:    neighborhoodType,
+		states:          2,
+		radius:          1,
+		birthLetters:    birthLetters,
+		survivalLetters: survivalLetters,
+	}
+	if r.birthLetters == nil {
+		r.birthLetters = make(map[int]string)
+	}
+	if r.survivalLetters == nil {
+		r.survivalLetters = make(map[int]string)
+	}
+	for configuration := 0; configuration < Configurations; configuration++ {
+		r.birthTable[configuration] = matches(configuration, r.birth, r.birthLetters)
+		r.survivalTable[configuration] = matches(configuration, r.survival, r.survivalLetters)
+	}
+	return r
+}
+
+// Parse : parse a rulestring. The following notations are accepted
+// (case insensitive):
+//   - B/S notation: "B3/S23" or "S23/B3".
+//   - S/B notation (Life 1.05): "23/3".
+//
+// Each number of neighbors can be followed by Hensel letters to only
+// include some of its configurations (e.g. "B2a/S12") or, if preceded by
+// a minus sign, to exclude them (e.g. "B2-a/S12"). Optionally, the rule
+// can have a third part with the number of states of Generations rules,
+// where alive cells that do not survive go through dying states before
+// being dead (e.g. "B2/S/C3" or "345/2/4"). Finally, the rule
+// can be ended with a neighborhood suffix: "V" for Von Neumann
+// neighborhood or "H" for hexagonal neighborhood.
+//
+// Larger than Life rules have their own notation
+// (e.g. "R5,C0,M1,S34..58,B34..45,NM"), see ltl.go.
+func Parse(rulestring string) (*Rule, error) {
+	return ParseForNeighborhood(rulestring, neighborhood.NONE)
+}
+
+// ParseForNeighborhood : parse a rule that will be used in a
+// neighborhood, so if the rule has no suffix, it can have conditions
+// up to the number of neighbors of that neighborhood (e.g. "B9/S3"
+// is a valid rule for the triangular neighborhood).
+func ParseForNeighborhood(rulestring string, defaultNeighborhoodType int) (*Rule, error) {
+	rule := strings.ToUpper(strings.TrimSpace(rulestring))
+	if rule == "" {
+		return nil, fmt.Errorf("%w \"%s\": empty rule", ErrInvalidRule, rulestring)
+	}
+	if isLargerThanLife(rule) {
+		return parseLargerThanLife(rulestring, rule)
+	}
+
+	neighborhoodType := neighborhood.NONE
+	switch rule[len(rule)-1] {
+	case 'V':
+		neighborhoodType = neighborhood.VONNEUMANN
+		rule = rule[:len(rule)-1]
+	case 'H':
+		neighborhoodType = neighborhood.HEXAGONAL
+		rule = rule[:len(rule)-1]
+	}
+
+	ruleParts := strings.Split(rule, "/")
+	if len(ruleParts) != 2 && len(ruleParts) != 3 {
+		return nil, fmt.Errorf(
+			"%w \"%s\": expected B/S (e.g. B3/S23) or S/B (e.g. 23/3) notation, "+
+				"optionally followed by the number of states (e.g. B2/S/C3 or 345/2/4)",
+			ErrInvalidRule, rulestring,
+		)
+	}
+
+	var birthPart, survivalPart, statesPart string
+	if strings.HasPrefix(ruleParts[0], "B") || strings.HasPrefix(ruleParts[0], "S") {
+		// B/S notation in any order, with the states as last part
+		birthFound, survivalFound := false, false
+		for _, rulePart := range ruleParts[:2] {
+			if strings.HasPrefix(rulePart, "B") && !birthFound {
+				birthPart, birthFound = rulePart[1:], true
+			} else if strings.HasPrefix(rulePart, "S") && !survivalFound {
+				survivalPart, survivalFound = rulePart[1:], true
+			}
+		}
+		if !birthFound || !survivalFound {
+			return nil, fmt.Errorf(
+				"%w \"%s\": expected one B (birth) and one S (survival) part",
+				ErrInvalidRule, rulestring,
+			)
+		}
+		if len(ruleParts) == 3 {
+			if !strings.HasPrefix(ruleParts[2], "C") {
+				return nil, fmt.Errorf(
+					"%w \"%s\": expected a C (number of states) part, found %s",
+					ErrInvalidRule, rulestring, ruleParts[2],
+				)
+			}
+			statesPart = ruleParts[2][1:]
+		}
+	} else {
+		// S/B notation, with the states as last part
+		survivalPart, birthPart = ruleParts[0], ruleParts[1]
+		if len(ruleParts) == 3 {
+			statesPart = ruleParts[2]
+		}
+	}
+
+	states := 2
+	if statesPart != "" || len(ruleParts) == 3 {
+		var statesError error
+		states, statesError = strconv.Atoi(statesPart)
+		if statesError != nil || states < 2 || states > MaxStates {
+			return nil, fmt.Errorf(
+				"%w \"%s\": the number of states must be between 2 and %d, found %s",
+				ErrInvalidRule, rulestring, MaxStates, statesPart,
+			)
+		}
+	}
+
+	maxCount := MaxNeighbors(neighborhoodType)
+	if neighborhoodType == neighborhood.NONE && MaxNeighbors(defaultNeighborhoodType) > maxCount {
+		maxCount = MaxNeighbors(defaultNeighborhoodType)
+	}
+	birth, birthLetters, birthError := parseConditions(birthPart, maxCount)
+	if birthError != nil {
+		return nil, fmt.Errorf("%w \"%s\": invalid birth conditions, %s", ErrInvalidRule, rulestring, birthError)
+	}
+	survival, survivalLetters, survivalError := parseConditions(survivalPart, maxCount)
+	if survivalError != nil {
+		return nil, fmt.Errorf("%w \"%s\": invalid survival conditions, %s", ErrInvalidRule, rulestring, survivalError)
+	}
+	r := newRule(birth, survival, birthLetters, survivalLetters, neighborhoodType)
+	r.states = states
+	if !r.IsTotalistic() && neighborhoodType != neighborhood.NONE && neighborhoodType != neighborhood.MOORE {
+		return nil, fmt.Errorf(
+			"%w \"%s\": non-totalistic conditions are only allowed for the Moore neighborhood",
+			ErrInvalidRule, rulestring,
+		)
+	}
+	return r, nil
+}
+
+// MaxNeighbors : return the number of neighbors of a cell in
+// a neighborhood. Rules that do not force any neighborhood
+// are checked against the Moore one.
+func MaxNeighbors(neighborhoodType int) int {
+	if neighborhoodType == neighborhood.NONE {
+		return neighborhood.Size(neighborhood.MOORE)
+	}
+	return neighborhood.Size(neighborhoodType)
+}
+
+// Birth : return the neighbor counts that make a dead cell alive
+// whatever the configuration of the neighbors is
+func (r *Rule) Birth() map[int]bool {
+	return r.birth
+}
+
+// Survival : return the neighbor counts that keep an alive cell alive
+// whatever the configuration of the neighbors is
+func (r *Rule) Survival() map[int]bool {
+	return r.survival
+}
+
+// IsTotalistic : inform if the rule only depends on the
+// number of alive neighbors
+func (r *Rule) IsTotalistic() bool {
+	return len(r.birthLetters) == 0 && len(r.survivalLetters) == 0
+}
+
+// IsBorn : inform if a dead cell with a configuration
+// of alive Moore neighbors becomes alive
+func (r *Rule) IsBorn(configuration int) bool {
+	return r.birthTable[configuration]
+}
+
+// Survives : inform if an alive cell with a configuration
+// of alive Moore neighbors keeps alive
+func (r *Rule) Survives(configuration int) bool {
+	return r.survivalTable[configuration]
+}
+
+// Neighborhood : return the neighborhood type forced by the rule suffix
+// or neighborhood.NONE if the rule has no suffix
+func (r *Rule) Neighborhood() int {
+	return r.neighborhood
+}
+
+// WithNeighborhood : return a copy of the rule with another neighborhood type
+func (r *Rule) WithNeighborhood(neighborhoodType int) *Rule {
+	clone := new(Rule)
+	*clone = *r
+	clone.neighborhood = neighborhoodType
+	return clone
+}
+
+// String : return the canonical representation of the rule, i.e.
+// in B/S notation, with the counts sorted and with the neighborhood
+// suffix if the neighborhood is not Moore (e.g. "B3/S23" or "B2/S34H").
+// Hensel letters are written in their shortest form (e.g. "B2-a/S12")
+// and the number of states only if there are dying states (e.g. "B2/S/C3").
+func (r *Rule) String() string {
+	if r.largerThanLife {
+		return r.largerThanLifeString()
+	}
+	return fmt.Sprintf(
+		"B%s/S%s%s%s",
+		conditionsString(r.birth, r.birthLetters),
+		conditionsString(r.survival, r.survivalLetters),
+		r.statesString("/C"),
+		r.suffix(),
+	)
+}
+
+// SurvivalBirthString : return the rule in the S/B notation used by
+// Life 1.05 files (e.g. "23/3" or "345/2/4"). This notation has
+// no neighborhood suffix. Larger than Life rules are always written
+// in their own notation.
+func (r *Rule) SurvivalBirthString() string {
+	if r.largerThanLife {
+		return r.largerThanLifeString()
+	}
+	return fmt.Sprintf(
+		"%s/%s%s",
+		conditionsString(r.survival, r.survivalLetters),
+		conditionsString(r.birth, r.birthLetters),
+		r.statesString("/"),
+	)
+}
+
+// States : return the number of states of the cells (2 if there are
+// no dying states). Dying states go from statuses.DYING to States()-1.
+func (r *Rule) States() int {
+	return r.states
+}
+
+// NextDyingState : return the state that follows an alive
+// cell that does not survive or a dying cell
+func (r *Rule) NextDyingState(state int) int {
+	if state == statuses.ALIVE {
+		state = statuses.DYING - 1
+	}
+	if state+1 < r.states {
+		return state + 1
+	}
+	return statuses.DEAD
+}
+
+func (r *Rule) statesString(prefix string) string {
+	if r.states == 2 {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", prefix, r.states)
+}
+
+// Equals : inform if two rules have the same conditions and neighborhood
+func (r *Rule) Equals(o *Rule) bool {
+	return r.String() == o.String()
+}
+
+func (r *Rule) suffix() string {
+	if r.neighborhood == neighborhood.VONNEUMANN {
+		return "V"
+	}
+	if r.neighborhood == neighborhood.HEXAGONAL {
+		return "H"
+	}
+	return ""
+}
+
+// parseConditions : parse the conditions of the birth or survival part of
+// a rule, returning the counts that include all their configurations and
+// the Hensel letters of the ones that only include some of them
+func parseConditions(conditionsString string, maxCount int) (map[int]bool, map[int]string, error) {
+	counts := make(map[int]bool)
+	letters := make(map[int]string)
+	conditions := strings.ToLower(conditionsString)
+	for i := 0; i < len(conditions); {
+		digit := conditions[i]
+		if digit < '0' || digit > '9' {
+			return nil, nil, fmt.Errorf("%c is not a number of neighbors", digit)
+		}
+		count := int(digit - '0')
+		if count > maxCount {
+			return nil, nil, fmt.Errorf("%d neighbors found, but there are only %d in the neighborhood", count, maxCount)
+		}
+		i++
+
+		negated := i < len(conditions) && conditions[i] == '-'
+		if negated {
+			i++
+		}
+		countLetters := ""
+		for ; i < len(conditions) && (conditions[i] < '0' || conditions[i] > '9'); i++ {
+			letter := rune(conditions[i])
+			if !isHenselLetter(count, letter) {
+				return nil, nil, fmt.Errorf("%c is not a configuration of %d neighbors", letter, count)
+			}
+			countLetters += string(letter)
+		}
+		if negated && countLetters == "" {
+			return nil, nil, fmt.Errorf("- must be followed by the configurations to exclude")
+		}
+
+		if countLetters == "" {
+			counts[count] = true
+			continue
+		}
+		if negated {
+			countLetters = lettersExcept(count, countLetters)
+		}
+		letters[count] = sortedLetters(count, letters[count]+countLetters)
+		if letters[count] == henselLetters[count] {
+			counts[count] = true
+		}
+	}
+	// Counts that include all of their configurations have no letters
+	for count := range counts {
+		delete(letters, count)
+	}
+	for count, countLetters := range letters {
+		if countLetters == "" {
+			delete(letters, count)
+		}
+	}
+	return counts, letters, nil
+}
+
+// matches : inform if a configuration of the Moore neighborhood
+// fulfills the conditions
+func matches(configuration int, counts map[int]bool, letters map[int]string) bool {
+	count := AliveNeighbors(configuration)
+	if counts[count] {
+		return true
+	}
+	countLetters, hasLetters := letters[count]
+	return hasLetters && strings.IndexByte(countLetters, ConfigurationLetter(configuration)) >= 0
+}
+
+// conditionsString : return the conditions sorted by number of neighbors.
+// Letters are negated if that makes them shorter.
+func conditionsString(counts map[int]bool, letters map[int]string) string {
+	sortedCounts := make([]int, 0, len(counts)+len(letters))
+	for count, isSet := range counts {
+		if isSet {
+			sortedCounts = append(sortedCounts, count)
+		}
+	}
+	for count := range letters {
+		if !counts[count] {
+			sortedCounts = append(sortedCounts, count)
+		}
+	}
+	sort.Ints(sortedCounts)
+	conditionsString := ""
+	for _, count := range sortedCounts {
+		conditionsString += fmt.Sprintf("%d", count)
+		if counts[count] {
+			continue
+		}
+		excludedLetters := lettersExcept(count, letters[count])
+		if len(excludedLetters) < len(letters[count]) {
+			conditionsString += "-" + excludedLetters
+		} else {
+			conditionsString += letters[count]
+		}
+	}
+	return conditionsString
+}
+
+// lettersExcept : return the Hensel letters of count neighbors
+// that are not in letters
+func lettersExcept(count int, letters string) string {
+	result := ""
+	for _, letter := range henselLetters[count] {
+		if !strings.ContainsRune(letters, letter) {
+			result += string(letter)
+		}
+	}
+	return result
+}
+
+// sortedLetters : return the letters without repetitions
+// and in the order they are written in rules
+func sortedLetters(count int, letters string) string {
+	result := ""
+	for _, letter := range henselLetters[count] {
+		if strings.ContainsRune(letters, letter) {
+			result += string(letter)
+		}
+	}
+	return result
+}
@@ -0,0 +1,219 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diegojromerolopez/congolway/pkg/neighborhood"
+	"github.com/diegojromerolopez/congolway/pkg/statuses"
+)
+
+func TestParse(t *testing.T) {
+	testParse(t, "B3/S23", "B3/S23", "23/3", neighborhood.NONE)
+	testParse(t, "S23/B3", "B3/S23", "23/3", neighborhood.NONE)
+	testParse(t, "23/3", "B3/S23", "23/3", neighborhood.NONE)
+	testParse(t, "b3/s23", "B3/S23", "23/3", neighborhood.NONE)
+	testParse(t, " B36/S32 ", "B36/S23", "23/36", neighborhood.NONE)
+	testParse(t, "B2/S", "B2/S", "/2", neighborhood.NONE)
+	testParse(t, "/2", "B2/S", "/2", neighborhood.NONE)
+	testParse(t, "B2/S34H", "B2/S34H", "34/2", neighborhood.HEXAGONAL)
+	testParse(t, "b1/s4v", "B1/S4V", "4/1", neighborhood.VONNEUMANN)
+	testParse(t, "23/3V", "B3/S23V", "23/3", neighborhood.VONNEUMANN)
+}
+
+func TestParseErrors(t *testing.T) {
+	invalidRules := []string{
+		"", "3", "B3", "B3/S23/C1", "B3/S23/X3", "B3/S23/C", "B3/S23/C257", "3/23/", "1/2/3/4", "B3/B23", "S3/S23",
+		"B3/S2x", "a3/23", "B2-/S23", "B0c/S23", "B2a/S23V", "B9/S23", "B5/S23V", "B7/S23H",
+	}
+	for _, invalidRule := range invalidRules {
+		rule, err := Parse(invalidRule)
+		if err == nil {
+			t.Errorf("Rule \"%s\" should be invalid, but it was parsed as %s", invalidRule, rule)
+		} else if !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Rule \"%s\" should return an invalid rule error, found %s", invalidRule, err)
+		}
+	}
+}
+
+func TestParseHensel(t *testing.T) {
+	testParse(t, "B2-a/S12", "B2-a/S12", "12/2-a", neighborhood.NONE)
+	testParse(t, "b2ceikn/s21", "B2-a/S12", "12/2-a", neighborhood.NONE)
+	testParse(t, "B3/S23-a", "B3/S23-a", "23-a/3", neighborhood.NONE)
+	testParse(t, "B3/S2ak", "B3/S2ak", "2ak/3", neighborhood.NONE)
+	testParse(t, "B2ka/S2ak2", "B2ak/S2", "2/2ak", neighborhood.NONE)
+	testParse(t, "B3/S2ceaikn3", "B3/S23", "23/3", neighborhood.NONE)
+	testParse(t, "B2a2-a/S", "B2/S", "/2", neighborhood.NONE)
+}
+
+func TestParseGenerations(t *testing.T) {
+	testParseStates(t, "B2/S/C3", "B2/S/C3", "/2/3", 3)
+	testParseStates(t, "345/2/4", "B2/S345/C4", "345/2/4", 4)
+	testParseStates(t, "s345/b2/c4", "B2/S345/C4", "345/2/4", 4)
+	testParseStates(t, "B3/S23/C2", "B3/S23", "23/3", 2)
+	testParseStates(t, "B2/S/C3V", "B2/S/C3V", "/2/3", 3)
+
+	rule, _ := Parse("B2/S/C4")
+	expectedNextStates := map[int]int{statuses.ALIVE: 2, 2: 3, 3: statuses.DEAD}
+	for state, expectedNextState := range expectedNextStates {
+		if rule.NextDyingState(state) != expectedNextState {
+			t.Errorf("State %d should be followed by %d, found %d", state, expectedNextState, rule.NextDyingState(state))
+		}
+	}
+	life, _ := Parse("B3/S23")
+	if life.NextDyingState(statuses.ALIVE) != statuses.DEAD {
+		t.Errorf("Alive cells should die if there are no dying states")
+	}
+}
+
+func TestHenselConfigurations(t *testing.T) {
+	configurationsCount := make(map[int]map[byte]int)
+	for configuration := 0; configuration < Configurations; configuration++ {
+		count := AliveNeighbors(configuration)
+		letter := ConfigurationLetter(configuration)
+		if (count == 0 || count == 8) != (letter == 0) {
+			t.Errorf("Configuration %08b has a wrong letter: %c", configuration, letter)
+		}
+		if configurationsCount[count] == nil {
+			configurationsCount[count] = make(map[byte]int)
+		}
+		configurationsCount[count][letter]++
+		// Isotropy: rotations and reflections have the same letter
+		for _, symmetric := range symmetries(configuration) {
+			if ConfigurationLetter(symmetric) != letter {
+				t.Errorf("Configurations %08b and %08b should have the same letter", configuration, symmetric)
+			}
+		}
+	}
+	classes := 0
+	for count, letters := range configurationsCount {
+		classes += len(letters)
+		if count > 0 && count < 8 && len(letters) != len(henselLetters[count]) {
+			t.Errorf("There should be %d configurations of %d neighbors, found %d",
+				len(henselLetters[count]), count, len(letters))
+		}
+	}
+	if classes != 51 {
+		t.Errorf("There should be 51 configurations, found %d", classes)
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	rule, _ := Parse("B2-a/S12")
+	if rule.IsTotalistic() {
+		t.Errorf("B2-a/S12 is not a totalistic rule")
+	}
+	if rule.IsBorn(nw | n) {
+		t.Errorf("A dead cell with the 2a configuration should not be born")
+	}
+	if !rule.IsBorn(nw | se) {
+		t.Errorf("A dead cell with the 2n configuration should be born")
+	}
+	if !rule.Survives(s) || !rule.Survives(w|s) || rule.Survives(0) {
+		t.Errorf("Survival conditions are wrong")
+	}
+
+	totalistic, _ := Parse("B3/S23")
+	if !totalistic.IsTotalistic() {
+		t.Errorf("B3/S23 is a totalistic rule")
+	}
+	for configuration := 0; configuration < Configurations; configuration++ {
+		count := AliveNeighbors(configuration)
+		if totalistic.IsBorn(configuration) != (count == 3) {
+			t.Errorf("Wrong birth transition for configuration %08b", configuration)
+		}
+		if totalistic.Survives(configuration) != (count == 2 || count == 3) {
+			t.Errorf("Wrong survival transition for configuration %08b", configuration)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	rule := New(
+		map[int]bool{3: true, 6: true, 1: false},
+		map[int]bool{3: true, 2: true},
+		neighborhood.MOORE,
+	)
+	if rule.String() != "B36/S23" {
+		t.Errorf("The rule should be B36/S23, but it is %s", rule)
+	}
+	if rule.Birth()[1] {
+		t.Errorf("Counts set to false must not be part of the rule")
+	}
+	if !rule.Equals(New(map[int]bool{6: true, 3: true}, map[int]bool{2: true, 3: true}, neighborhood.NONE)) {
+		t.Errorf("Both rules should be equal")
+	}
+}
+
+func testParseStates(t *testing.T, rulestring, expected, expectedSurvivalBirth string, expectedStates int) {
+	testParse(t, rulestring, expected, expectedSurvivalBirth, neighborhoodFromSuffix(expected))
+	rule, err := Parse(rulestring)
+	if err != nil {
+		return
+	}
+	if rule.States() != expectedStates {
+		t.Errorf("Rule \"%s\" should have %d states, but it has %d", rulestring, expectedStates, rule.States())
+	}
+}
+
+func neighborhoodFromSuffix(rulestring string) int {
+	if rulestring[len(rulestring)-1] == 'V' {
+		return neighborhood.VONNEUMANN
+	}
+	return neighborhood.NONE
+}
+
+func testParse(t *testing.T, rulestring, expected, expectedSurvivalBirth string, expectedNeighborhood int) {
+	rule, err := Parse(rulestring)
+	if err != nil {
+		t.Errorf("Rule \"%s\" should be valid, but it wasn't: %s", rulestring, err)
+		return
+	}
+	if rule.String() != expected {
+		t.Errorf("Rule \"%s\" should be %s, but it is %s", rulestring, expected, rule)
+	}
+	if rule.SurvivalBirthString() != expectedSurvivalBirth {
+		t.Errorf("Rule \"%s\" should be %s in S/B notation, but it is %s",
+			rulestring, expectedSurvivalBirth, rule.SurvivalBirthString())
+	}
+	if rule.Neighborhood() != expectedNeighborhood {
+		t.Errorf("Rule \"%s\" should have neighborhood %d, but it has %d",
+			rulestring, expectedNeighborhood, rule.Neighborhood())
+	}
+}
+
+func TestParseLargerThanLife(t *testing.T) {
+	testParse(t, "R5,C0,M1,S34..58,B34..45,NM", "R5,C0,M1,S34..58,B34..45,NM", "R5,C0,M1,S34..58,B34..45,NM",
+		neighborhood.MOORE)
+	testParse(t, "r2,b3..4,s2..5,nn", "R2,C0,M0,S2..5,B3..4,NN", "R2,C0,M0,S2..5,B3..4,NN", neighborhood.VONNEUMANN)
+	testParse(t, "R3,C2,S1..1,B0..36,NC", "R3,C0,M0,S1..1,B0..36,NC", "R3,C0,M0,S1..1,B0..36,NC", neighborhood.CIRCULAR)
+	testParse(t, "R1,C4,M0,S2..3,B3..3", "R1,C4,M0,S2..3,B3..3,NM", "R1,C4,M0,S2..3,B3..3,NM", neighborhood.MOORE)
+
+	rule, _ := Parse("R5,C3,M1,S34..58,B34..45,NM")
+	if !rule.IsLargerThanLife() || rule.Radius() != 5 || !rule.IncludesMiddle() || rule.States() != 3 {
+		t.Errorf("Wrong parameters of rule %s", rule)
+	}
+	if !rule.Survival()[34] || !rule.Survival()[58] || rule.Survival()[59] || !rule.Birth()[40] || rule.Birth()[33] {
+		t.Errorf("Wrong ranges of rule %s", rule)
+	}
+	life, _ := Parse("B3/S23")
+	if life.IsLargerThanLife() || life.Radius() != 1 || life.IncludesMiddle() {
+		t.Errorf("B3/S23 is not a Larger than Life rule")
+	}
+}
+
+func TestParseLargerThanLifeErrors(t *testing.T) {
+	invalidRules := []string{
+		"R", "R5", "R5,S2..3", "R0,S2..3,B3..3", "R101,S2..3,B3..3", "R2,C1,S2..3,B3..3", "R2,M2,S2..3,B3..3",
+		"R2,S3..2,B3..3", "R2,S2..3,B3", "R2,S2..3,B3..3,NH", "R2,S2..3,B3..3,R2", "R2,S2..3,B3..3,X1",
+		"R1,S2..3,B3..9", "R1,M1,S2..3,B3..10", "R2,S2..3,B3..13,NN",
+	}
+	for _, invalidRule := range invalidRules {
+		rule, err := Parse(invalidRule)
+		if err == nil {
+			t.Errorf("Rule \"%s\" should be invalid, but it was parsed as %s", invalidRule, rule)
+		} else if !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Rule \"%s\" should return an invalid rule error, found %s", invalidRule, err)
+		}
+	}
+}
@@ -7,4 +7,5 @@ const (
 	A     = 1 // ALIVE alias
 	D     = 0 // DEAD alias
 	VOID  = -1
+	DYING = 2 // First dying status of Generations rules
 )
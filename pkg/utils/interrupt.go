@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// InterruptContext : return a context that is cancelled when the process
+// receives an interrupt signal (e.g. Ctrl-C), and the function that
+// stops listening to the signal and releases the context
+func InterruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	go func() {
+		select {
+		case <-interrupts:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(interrupts)
+		cancel()
+	}
+}